@@ -2,11 +2,15 @@ package crypto
 
 import (
 	"errors"
+	"fmt"
 )
 
 // ErrNilPrivateKey is raised when a private key was expected but received nil
 var ErrNilPrivateKey = errors.New("private key is nil")
 
+// ErrNilPrivateKeys is raised when private keys are expected but received nil or empty
+var ErrNilPrivateKeys = errors.New("private keys are nil")
+
 // ErrInvalidPrivateKey is raised when an invalid private key is used
 var ErrInvalidPrivateKey = errors.New("private key is invalid")
 
@@ -135,3 +139,165 @@ var ErrWrongTypeAssertion = errors.New("wrong type assertion")
 
 // ErrNilLowLevelSigner signals a nil low level signer
 var ErrNilLowLevelSigner = errors.New("nil low level signer")
+
+// ErrInvalidCacheCapacity signals that an invalid cache capacity was provided
+var ErrInvalidCacheCapacity = errors.New("invalid cache capacity")
+
+// ErrPoPNotValid is raised when a proof of possession verification fails
+var ErrPoPNotValid = errors.New("proof of possession is invalid")
+
+// ErrNilProofOfPossessionSigner is raised when a valid proof of possession signer is expected but nil used
+var ErrNilProofOfPossessionSigner = errors.New("proof of possession signer is nil")
+
+// ErrProofsPubKeysLenMismatch is raised when the number of proofs does not match the number of public keys
+var ErrProofsPubKeysLenMismatch = errors.New("number of proofs does not match the number of public keys")
+
+// ErrInvalidThreshold is raised when an invalid threshold is used
+var ErrInvalidThreshold = errors.New("threshold is invalid")
+
+// ErrNotEnoughSignatureShares is raised when there are not enough signature shares to reach the threshold
+var ErrNotEnoughSignatureShares = errors.New("not enough signature shares")
+
+// ErrDuplicateIndex is raised when the same index is used multiple times
+var ErrDuplicateIndex = errors.New("duplicate index")
+
+// ErrInvalidDKGPhase is raised when a DKG operation is not allowed in the current phase of the protocol
+var ErrInvalidDKGPhase = errors.New("operation not allowed in the current DKG phase")
+
+// ErrInvalidDeal is raised when a DKG deal is malformed or conflicts with a previous deal of the same dealer
+var ErrInvalidDeal = errors.New("invalid DKG deal")
+
+// ErrInvalidShare is raised when a secret share is malformed or does not match the dealer commitments
+var ErrInvalidShare = errors.New("invalid secret share")
+
+// ErrDuplicateShare is raised when a DKG dealer sends more than one secret share to the same recipient
+var ErrDuplicateShare = errors.New("duplicate secret share")
+
+// ErrNotEnoughQualifiedDealers is raised when the number of qualified DKG dealers is below the threshold
+var ErrNotEnoughQualifiedDealers = errors.New("not enough qualified dealers")
+
+// ErrDuplicateMessage is raised when the same message is signed multiple times in an aggregate over distinct messages
+var ErrDuplicateMessage = errors.New("duplicate message")
+
+// ErrInsufficientSignedWeight is raised when the weight of the signers of a multi-signature is below the threshold
+var ErrInsufficientSignedWeight = errors.New("signed weight is below the threshold")
+
+// ErrRoundOutOfWindow is raised when a round is older than the retained rounds or too far ahead of the current round
+var ErrRoundOutOfWindow = errors.New("round is outside the retained rounds window")
+
+// ErrUnverifiedSigShare is raised when a signature share is stored in strict mode without the message to verify it
+var ErrUnverifiedSigShare = errors.New("signature share needs to be verified against its message")
+
+// ErrSigShareMessageMismatch is raised when a signature share is verified in strict mode against another message than
+// the one the previous signature shares were verified against
+var ErrSigShareMessageMismatch = errors.New("signature share is not over the message pinned in strict mode")
+
+// ErrEquivocation is raised when a signer is found signing different messages in the same round
+var ErrEquivocation = errors.New("signer equivocated")
+
+// ErrInvalidEquivocationProof is raised when an equivocation proof does not hold two valid signatures of the same
+// signer over different messages
+var ErrInvalidEquivocationProof = errors.New("invalid equivocation proof")
+
+// ErrInvalidSnapshot is raised when a snapshot of the multi-signer state can not be decoded
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrUnsupportedSnapshotVersion is raised when a snapshot of the multi-signer state has an unknown version
+var ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+// ErrInvalidPublicKeyLength is raised when a public key serialization does not have the length of a point
+var ErrInvalidPublicKeyLength = errors.New("public key has an invalid length")
+
+// ErrEmptyPassword is raised when an empty password is used to encrypt or decrypt a keystore
+var ErrEmptyPassword = errors.New("empty password")
+
+// ErrInvalidPassword is raised when a keystore can not be decrypted with the provided password
+var ErrInvalidPassword = errors.New("invalid password or corrupted keystore")
+
+// ErrInvalidKeystore is raised when a keystore can not be decoded
+var ErrInvalidKeystore = errors.New("invalid keystore")
+
+// ErrUnsupportedKeystoreVersion is raised when a keystore has an unknown version
+var ErrUnsupportedKeystoreVersion = errors.New("unsupported keystore version")
+
+// ErrUnsupportedKDF is raised when a keystore uses an unknown key derivation function
+var ErrUnsupportedKDF = errors.New("unsupported key derivation function")
+
+// ErrUnsupportedCipher is raised when a keystore uses an unknown cipher
+var ErrUnsupportedCipher = errors.New("unsupported cipher")
+
+// ErrSuiteMismatch is raised when a key is loaded with a key generator of another suite than the one it was created for
+var ErrSuiteMismatch = errors.New("suite mismatch")
+
+// ErrNoPEMBlock is raised when a PEM file holds no block
+var ErrNoPEMBlock = errors.New("no PEM block")
+
+// ErrInvalidPEMBlock is raised when a PEM block is malformed or does not hold a private key
+var ErrInvalidPEMBlock = errors.New("invalid PEM block")
+
+// ErrPublicKeyMismatch is raised when a public key does not match the public key generated from its private key
+var ErrPublicKeyMismatch = errors.New("public key does not match the private key")
+
+// ErrInvalidSeed is raised when a seed is too short to derive keys from it
+var ErrInvalidSeed = errors.New("invalid seed")
+
+// ErrInvalidDerivationPath is raised when a hierarchical key derivation path can not be parsed
+var ErrInvalidDerivationPath = errors.New("invalid derivation path")
+
+// InvalidSignaturesError is raised when some signatures of a set failed verification. It holds the positions of the
+// offending signatures and wraps the sentinel error describing the failure
+type InvalidSignaturesError struct {
+	Indexes []int
+	Err     error
+}
+
+// Error returns the error message, including the positions of the offending signatures
+func (e *InvalidSignaturesError) Error() string {
+	return fmt.Sprintf("%v at indexes %v", e.Err, e.Indexes)
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *InvalidSignaturesError) Unwrap() error {
+	return e.Err
+}
+
+// InvalidPublicKeyError is raised when a public key of a set is invalid. It holds the position of the offending key
+// and wraps the sentinel error giving the reason, such as ErrEmptyPubKeyString, ErrInvalidPublicKeyLength or
+// ErrInvalidPoint for a key off the curve
+type InvalidPublicKeyError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the error message, including the position of the offending public key
+func (e *InvalidPublicKeyError) Error() string {
+	return fmt.Sprintf("%v at index %d", e.Err, e.Index)
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *InvalidPublicKeyError) Unwrap() error {
+	return e.Err
+}
+
+// Is returns true for ErrInvalidPublicKeyString if the public key is malformed, as that error was reported for them
+// before the position of the offending key was available
+func (e *InvalidPublicKeyError) Is(target error) bool {
+	return target == ErrInvalidPublicKeyString && e.Err != ErrEmptyPubKeyString && e.Err != ErrNilPublicKey
+}
+
+// InvalidPEMBlockError is raised when a block of a PEM file can not be loaded. It holds the position of the offending
+// block and wraps the sentinel error giving the reason, such as ErrInvalidPEMBlock or ErrPublicKeyMismatch
+type InvalidPEMBlockError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the error message, including the position of the offending block
+func (e *InvalidPEMBlockError) Error() string {
+	return fmt.Sprintf("%v at PEM block %d", e.Err, e.Index)
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *InvalidPEMBlockError) Unwrap() error {
+	return e.Err
+}
@@ -10,6 +10,7 @@ require (
 	github.com/herumi/bls-go-binary v1.28.2
 	github.com/stretchr/testify v1.8.0
 	golang.org/x/crypto v0.0.0-20221012134737-56aed061732a
+	golang.org/x/text v0.22.0
 )
 
 require (
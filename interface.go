@@ -136,6 +136,14 @@ type KeyGenerator interface {
 	IsInterfaceNil() bool
 }
 
+// KeyDeriver derives private keys from a seed, following a hierarchical derivation path
+type KeyDeriver interface {
+	// DerivePrivateKey derives the private key of the derivation path from the seed
+	DerivePrivateKey(seed []byte, path string) (PrivateKey, error)
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
+
 // Key represents a crypto key - can be either private or public
 type Key interface {
 	// ToByteArray returns the byte array representation of the key
@@ -172,6 +180,16 @@ type SingleSigner interface {
 	IsInterfaceNil() bool
 }
 
+// ProofOfPossessionSigner provides functionality for proving and checking that a public key is backed by its private key
+type ProofOfPossessionSigner interface {
+	// CreateProofOfPossession creates a proof of possession for the given private key
+	CreateProofOfPossession(private PrivateKey) ([]byte, error)
+	// VerifyProofOfPossession verifies the proof of possession for the given public key
+	VerifyProofOfPossession(public PublicKey, pop []byte) error
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
+
 // MultiSigner provides functionality for multi-signing a message and verifying a multi-signed message
 type MultiSigner interface {
 	// MultiSigVerifier Provides functionality for verifying a multi-signature
@@ -204,6 +222,24 @@ type MultiSigVerifier interface {
 	IsInterfaceNil() bool
 }
 
+// SigSharesVerifier provides functionality for identifying the signers that broke an invalid multi-signature
+type SigSharesVerifier interface {
+	// VerifySigShares verifies the stored signature shares selected by the bitmap, returning the positions of the
+	// offending signers through an *InvalidSignaturesError
+	VerifySigShares(msg []byte, bitmap []byte) error
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
+
+// AggregatedSigVerifier provides functionality for verifying aggregated signatures of a fixed consensus group
+// without any mutable state
+type AggregatedSigVerifier interface {
+	// Verify verifies the aggregated signature of the signers selected by the bitmap over the message
+	Verify(msg []byte, bitmap []byte, aggSig []byte) error
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
+
 // LowLevelSignerBLS provides functionality to sign and verify BLS single/multi-signatures
 // Implementations act as a wrapper over a specific crypto library, such that changing the library requires only
 // writing a new implementation of this LowLevelSigner
@@ -222,6 +258,21 @@ type LowLevelSignerBLS interface {
 	IsInterfaceNil() bool
 }
 
+// AggregateSignerBLS provides functionality to aggregate and verify BLS signatures where each signer signed
+// a different message
+type AggregateSignerBLS interface {
+	// SignShare creates a BLS single signature over a given message
+	SignShare(privKey PrivateKey, message []byte) ([]byte, error)
+	// VerifySigShare verifies a BLS single signature
+	VerifySigShare(pubKey PublicKey, message []byte, sig []byte) error
+	// AggregateSignatures aggregates BLS single signatures given as byte arrays
+	AggregateSignatures(suite Suite, signatures [][]byte) ([]byte, error)
+	// AggregateVerify verifies an aggregated signature over the list of messages, message i being signed by pubKeys[i]
+	AggregateVerify(suite Suite, pubKeys []PublicKey, messages [][]byte, aggSigBytes []byte) error
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
+
 // PeerSignatureHandler is a wrapper over SingleSigner that buffers the peer signatures.
 // When it needs to sign or to verify a signature, it searches the buffer first.
 type PeerSignatureHandler interface {
@@ -1,6 +1,6 @@
 package mock
 
-import "crypto"
+import crypto "github.com/ME-MotherEarth/me-crypto"
 
 // SingleSignerStub -
 type SingleSignerStub struct {
@@ -0,0 +1,48 @@
+package ed25519
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ crypto.KeyDeriver = (*keyDeriver)(nil)
+
+// keyDeriver derives Ed25519 private keys from a seed following SLIP-0010, with hardened derivation paths
+type keyDeriver struct {
+	keyGen crypto.KeyGenerator
+}
+
+// NewKeyDeriver creates a key deriver whose private keys are created by the key generator, so they can be used with
+// the signers of its suite. The key generator needs to be of the Ed25519 suite
+func NewKeyDeriver(keyGen crypto.KeyGenerator) (*keyDeriver, error) {
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+	if check.IfNil(keyGen.Suite()) {
+		return nil, crypto.ErrNilSuite
+	}
+
+	_, isEd25519 := keyGen.Suite().CreateScalar().(*ed25519Scalar)
+	if !isEd25519 {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	return &keyDeriver{
+		keyGen: keyGen,
+	}, nil
+}
+
+// DerivePrivateKey derives the private key of the SLIP-0010 derivation path from the seed
+func (kd *keyDeriver) DerivePrivateKey(seed []byte, path string) (crypto.PrivateKey, error) {
+	key, err := DeriveKeyFromPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return kd.keyGen.PrivateKeyFromByteArray(key)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (kd *keyDeriver) IsInterfaceNil() bool {
+	return kd == nil
+}
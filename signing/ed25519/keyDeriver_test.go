@@ -0,0 +1,69 @@
+package ed25519_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519/singlesig"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyDeriver(t *testing.T) {
+	t.Parallel()
+
+	kd, err := ed25519.NewKeyDeriver(nil)
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	kd, err = ed25519.NewKeyDeriver(signing.NewKeyGenerator(nil))
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrNilSuite, err)
+
+	kd, err = ed25519.NewKeyDeriver(signing.NewKeyGenerator(mcl.NewSuiteBLS12()))
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+
+	kd, err = ed25519.NewKeyDeriver(signing.NewKeyGenerator(ed25519.NewEd25519()))
+	require.False(t, check.IfNil(kd))
+	require.Nil(t, err)
+}
+
+func TestKeyDeriver_DerivePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(ed25519.NewEd25519())
+	kd, _ := ed25519.NewKeyDeriver(keyGen)
+
+	for _, vector := range slip10TestVectors {
+		seed, _ := hex.DecodeString(vector.seed)
+
+		for _, node := range vector.nodes {
+			privKey, err := kd.DerivePrivateKey(seed, node.path)
+			require.Nil(t, err)
+
+			pubKeyBytes, _ := privKey.GeneratePublic().ToByteArray()
+			require.Equal(t, node.pubKey, hex.EncodeToString(pubKeyBytes), node.path)
+		}
+	}
+
+	// the derived keys sign with the Ed25519 signer
+	seed, _ := hex.DecodeString(slip10TestVectors[0].seed)
+	privKey, _ := kd.DerivePrivateKey(seed, "m/44'/508'/0'/0'/0'")
+	signer := &singlesig.Ed25519Signer{}
+	msg := []byte("message")
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+	require.Nil(t, signer.Verify(privKey.GeneratePublic(), msg, sig))
+
+	_, err = kd.DerivePrivateKey(seed, "m/44'/508'/0'/0'/0")
+	require.True(t, errors.Is(err, crypto.ErrInvalidDerivationPath))
+
+	_, err = kd.DerivePrivateKey(seed[:ed25519.MinSeedLen-1], "m/0'")
+	require.True(t, errors.Is(err, crypto.ErrInvalidSeed))
+}
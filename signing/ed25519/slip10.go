@@ -0,0 +1,118 @@
+package ed25519
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ME-MotherEarth/me-crypto"
+)
+
+const (
+	// HardenedKeyStart is the first index of the hardened child keys, the only ones SLIP-0010 defines for Ed25519
+	HardenedKeyStart = uint32(0x80000000)
+
+	// MinSeedLen and MaxSeedLen bound the length of the seed the SLIP-0010 master key is derived from
+	MinSeedLen = 16
+	MaxSeedLen = 64
+
+	slip10Curve        = "ed25519 seed"
+	derivationPathRoot = "m"
+	hardenedSuffix     = "'"
+)
+
+// DeriveMasterKey derives the SLIP-0010 master key from the seed, returning the 32 bytes Ed25519 seed of the private
+// key together with the chain code
+func DeriveMasterKey(seed []byte) ([]byte, []byte, error) {
+	if len(seed) < MinSeedLen || len(seed) > MaxSeedLen {
+		return nil, nil, fmt.Errorf("%w: seed length %d not between %d and %d bytes",
+			crypto.ErrInvalidSeed, len(seed), MinSeedLen, MaxSeedLen)
+	}
+
+	return hmacSplit([]byte(slip10Curve), seed)
+}
+
+// DeriveChildKey derives the SLIP-0010 child key with the given index from the parent key and chain code. Only the
+// hardened indexes, starting at HardenedKeyStart, can be used with Ed25519
+func DeriveChildKey(key []byte, chainCode []byte, index uint32) ([]byte, []byte, error) {
+	if len(key) != ed25519.SeedSize || len(chainCode) != ed25519.SeedSize {
+		return nil, nil, crypto.ErrInvalidParam
+	}
+	if index < HardenedKeyStart {
+		return nil, nil, fmt.Errorf("%w: index %d is not hardened", crypto.ErrInvalidDerivationPath, index)
+	}
+
+	// 0x00 || key || ser32(index)
+	data := make([]byte, 0, 1+len(key)+4)
+	data = append(data, 0)
+	data = append(data, key...)
+	data = binary.BigEndian.AppendUint32(data, index)
+
+	return hmacSplit(chainCode, data)
+}
+
+// DeriveKeyFromPath derives the 32 bytes Ed25519 seed of the private key of the derivation path from the seed, such
+// as m/44'/508'/0'/0'/0' for the first wallet key
+func DeriveKeyFromPath(seed []byte, path string) ([]byte, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode, err := DeriveMasterKey(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		key, chainCode, err = DeriveChildKey(key, chainCode, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+// ParseDerivationPath parses a SLIP-0010 derivation path into the indexes of the children, the path starting with the
+// master node m followed by decimal indexes below 2^31 separated by slashes. Each index needs to be marked as
+// hardened by a trailing apostrophe, the returned indexes including the HardenedKeyStart offset
+func ParseDerivationPath(path string) ([]uint32, error) {
+	nodes := strings.Split(strings.TrimSpace(path), "/")
+	if nodes[0] != derivationPathRoot {
+		return nil, fmt.Errorf("%w: %s does not start with %s", crypto.ErrInvalidDerivationPath, path, derivationPathRoot)
+	}
+
+	indexes := make([]uint32, 0, len(nodes)-1)
+	for _, node := range nodes[1:] {
+		if !strings.HasSuffix(node, hardenedSuffix) {
+			return nil, fmt.Errorf("%w: index %s in %s is not hardened", crypto.ErrInvalidDerivationPath, node, path)
+		}
+
+		value := strings.TrimSuffix(node, hardenedSuffix)
+		index, err := strconv.ParseUint(value, 10, 31)
+		if err != nil || value != strconv.FormatUint(index, 10) {
+			return nil, fmt.Errorf("%w: invalid index %s in %s", crypto.ErrInvalidDerivationPath, node, path)
+		}
+
+		indexes = append(indexes, uint32(index)+HardenedKeyStart)
+	}
+
+	return indexes, nil
+}
+
+func hmacSplit(key []byte, data []byte) ([]byte, []byte, error) {
+	mac := hmac.New(sha512.New, key)
+	_, err := mac.Write(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	digest := mac.Sum(nil)
+
+	return digest[:ed25519.SeedSize], digest[ed25519.SeedSize:], nil
+}
@@ -0,0 +1,394 @@
+package keystore
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// EIP2335Version is the version of the EIP-2335 keystore format
+	EIP2335Version = 4
+
+	eip2335ChecksumFunction = "sha256"
+	eip2335CipherFunction   = "aes-128-ctr"
+	eip2335PRF              = "hmac-sha256"
+	eip2335SecretLen        = 32
+	eip2335IVLen            = aes.BlockSize
+	uuidLen                 = 16
+)
+
+/*
+EIP2335Keystore is the JSON keystore defined by EIP-2335, used by the Ethereum consensus tooling for BLS12-381 keys.
+The decryption key is derived from the password with scrypt or PBKDF2, its second half being checked against the
+SHA-256 checksum and its first half being used to decrypt the secret with AES-128-CTR.
+
+The secret is the big endian serialization of the private key scalar, while the mcl library serializes the scalars
+as little endian. The public key is the compressed serialization of the secret multiplied by the G1 generator, as the
+Ethereum public keys are on G1
+*/
+type EIP2335Keystore struct {
+	Crypto      EIP2335Crypto `json:"crypto"`
+	Description string        `json:"description"`
+	PubKey      string        `json:"pubkey"`
+	Path        string        `json:"path"`
+	UUID        string        `json:"uuid"`
+	Version     int           `json:"version"`
+}
+
+// EIP2335Crypto holds the modules used to protect the secret of an EIP-2335 keystore
+type EIP2335Crypto struct {
+	KDF      EIP2335Module `json:"kdf"`
+	Checksum EIP2335Module `json:"checksum"`
+	Cipher   EIP2335Module `json:"cipher"`
+}
+
+// EIP2335Module holds a function of an EIP-2335 keystore together with its parameters and its message
+type EIP2335Module struct {
+	Function string          `json:"function"`
+	Params   json.RawMessage `json:"params"`
+	Message  string          `json:"message"`
+}
+
+type eip2335KDFParams struct {
+	DKLen int    `json:"dklen"`
+	N     int    `json:"n,omitempty"`
+	R     int    `json:"r,omitempty"`
+	P     int    `json:"p,omitempty"`
+	C     int    `json:"c,omitempty"`
+	PRF   string `json:"prf,omitempty"`
+	Salt  string `json:"salt"`
+}
+
+type eip2335CipherParams struct {
+	IV string `json:"iv"`
+}
+
+// ExportEIP2335 encrypts the BLS12-381 private key with the password into an EIP-2335 JSON keystore, recording the
+// EIP-2334 derivation path of the key. The key derivation function can be either scrypt or PBKDF2
+func ExportEIP2335(privKey crypto.PrivateKey, password []byte, path string, kdfConfig KDFConfig) ([]byte, error) {
+	salt, err := randomBytes(saltLen)
+	if err != nil {
+		return nil, err
+	}
+
+	iv, err := randomBytes(eip2335IVLen)
+	if err != nil {
+		return nil, err
+	}
+
+	uuid, err := randomBytes(uuidLen)
+	if err != nil {
+		return nil, err
+	}
+
+	return exportEIP2335(privKey, password, path, kdfConfig, salt, iv, formatUUID(uuid))
+}
+
+func exportEIP2335(
+	privKey crypto.PrivateKey,
+	password []byte,
+	path string,
+	kdfConfig KDFConfig,
+	salt []byte,
+	iv []byte,
+	uuid string,
+) ([]byte, error) {
+	if check.IfNil(privKey) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+
+	scalar, ok := privKey.Scalar().(*mcl.Scalar)
+	if !ok {
+		return nil, crypto.ErrSuiteMismatch
+	}
+
+	kdfParams, err := newEIP2335KDFParams(kdfConfig, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	kdfParamsJSON, err := json.Marshal(kdfParams)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherParamsJSON, err := json.Marshal(&eip2335CipherParams{IV: hex.EncodeToString(iv)})
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveEIP2335Key(kdfConfig.Function, kdfParams, password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := reverseBytes(scalar.Scalar.Serialize())
+	cipherMessage, err := aes128CTR(derivedKey[:16], iv, secret)
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &EIP2335Keystore{
+		Crypto: EIP2335Crypto{
+			KDF: EIP2335Module{
+				Function: kdfConfig.Function,
+				Params:   kdfParamsJSON,
+				Message:  "",
+			},
+			Checksum: EIP2335Module{
+				Function: eip2335ChecksumFunction,
+				Params:   json.RawMessage("{}"),
+				Message:  hex.EncodeToString(eip2335Checksum(derivedKey, cipherMessage)),
+			},
+			Cipher: EIP2335Module{
+				Function: eip2335CipherFunction,
+				Params:   cipherParamsJSON,
+				Message:  hex.EncodeToString(cipherMessage),
+			},
+		},
+		PubKey:  hex.EncodeToString(eip2335PubKey(scalar)),
+		Path:    path,
+		UUID:    uuid,
+		Version: EIP2335Version,
+	}
+
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// ImportEIP2335 decrypts the BLS12-381 private key from the EIP-2335 JSON keystore with the password, returning it
+// together with the derivation path recorded in the keystore. The key generator needs to be of a BLS12-381 suite
+func ImportEIP2335(keystoreJSON []byte, password []byte, keyGen crypto.KeyGenerator) (crypto.PrivateKey, string, error) {
+	if check.IfNil(keyGen) {
+		return nil, "", crypto.ErrNilKeyGenerator
+	}
+	_, isBLS12 := keyGen.Suite().CreateScalar().(*mcl.Scalar)
+	if !isBLS12 {
+		return nil, "", crypto.ErrSuiteMismatch
+	}
+
+	ks := &EIP2335Keystore{}
+	err := json.Unmarshal(keystoreJSON, ks)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", crypto.ErrInvalidKeystore, err)
+	}
+
+	secret, err := ks.decryptSecret(password)
+	if err != nil {
+		return nil, "", err
+	}
+
+	privKey, err := keyGen.PrivateKeyFromByteArray(reverseBytes(secret))
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %v", crypto.ErrInvalidPrivateKey, err)
+	}
+
+	scalar, ok := privKey.Scalar().(*mcl.Scalar)
+	if !ok || scalar.Scalar.IsZero() {
+		return nil, "", crypto.ErrInvalidPrivateKey
+	}
+
+	if len(ks.PubKey) > 0 && ks.PubKey != hex.EncodeToString(eip2335PubKey(scalar)) {
+		return nil, "", fmt.Errorf("%w: public key does not match the secret", crypto.ErrInvalidKeystore)
+	}
+
+	return privKey, ks.Path, nil
+}
+
+func (ks *EIP2335Keystore) decryptSecret(password []byte) ([]byte, error) {
+	if ks.Version != EIP2335Version {
+		return nil, crypto.ErrUnsupportedKeystoreVersion
+	}
+	if ks.Crypto.Checksum.Function != eip2335ChecksumFunction {
+		return nil, fmt.Errorf("%w: checksum %s", crypto.ErrInvalidKeystore, ks.Crypto.Checksum.Function)
+	}
+	if ks.Crypto.Cipher.Function != eip2335CipherFunction {
+		return nil, crypto.ErrUnsupportedCipher
+	}
+
+	kdfParams := &eip2335KDFParams{}
+	err := json.Unmarshal(ks.Crypto.KDF.Params, kdfParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: kdf params: %v", crypto.ErrInvalidKeystore, err)
+	}
+
+	cipherParams := &eip2335CipherParams{}
+	err = json.Unmarshal(ks.Crypto.Cipher.Params, cipherParams)
+	if err != nil {
+		return nil, fmt.Errorf("%w: cipher params: %v", crypto.ErrInvalidKeystore, err)
+	}
+
+	salt, err := hex.DecodeString(kdfParams.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("%w: salt", crypto.ErrInvalidKeystore)
+	}
+
+	iv, err := hex.DecodeString(cipherParams.IV)
+	if err != nil || len(iv) != eip2335IVLen {
+		return nil, fmt.Errorf("%w: iv", crypto.ErrInvalidKeystore)
+	}
+
+	cipherMessage, err := hex.DecodeString(ks.Crypto.Cipher.Message)
+	if err != nil || len(cipherMessage) != eip2335SecretLen {
+		return nil, fmt.Errorf("%w: cipher message", crypto.ErrInvalidKeystore)
+	}
+
+	checksum, err := hex.DecodeString(ks.Crypto.Checksum.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: checksum message", crypto.ErrInvalidKeystore)
+	}
+
+	derivedKey, err := deriveEIP2335Key(ks.Crypto.KDF.Function, kdfParams, password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(checksum, eip2335Checksum(derivedKey, cipherMessage)) {
+		return nil, crypto.ErrInvalidPassword
+	}
+
+	return aes128CTR(derivedKey[:16], iv, cipherMessage)
+}
+
+func newEIP2335KDFParams(kdfConfig KDFConfig, salt []byte) (*eip2335KDFParams, error) {
+	kdfParams := &eip2335KDFParams{
+		DKLen: derivedKeyLen,
+		Salt:  hex.EncodeToString(salt),
+	}
+
+	switch kdfConfig.Function {
+	case KDFScrypt:
+		kdfParams.N = kdfConfig.ScryptN
+		kdfParams.R = kdfConfig.ScryptR
+		kdfParams.P = kdfConfig.ScryptP
+	case KDFPBKDF2:
+		kdfParams.C = kdfConfig.PBKDF2Iterations
+		kdfParams.PRF = eip2335PRF
+	default:
+		return nil, crypto.ErrUnsupportedKDF
+	}
+
+	return kdfParams, nil
+}
+
+func deriveEIP2335Key(function string, kdfParams *eip2335KDFParams, password []byte, salt []byte) ([]byte, error) {
+	if kdfParams.DKLen != derivedKeyLen {
+		return nil, fmt.Errorf("%w: derived key length %d", crypto.ErrInvalidKeystore, kdfParams.DKLen)
+	}
+
+	processedPassword, err := processEIP2335Password(password)
+	if err != nil {
+		return nil, err
+	}
+
+	switch function {
+	case KDFScrypt:
+		err = checkKDFParams(KDFScrypt, KDFParams{
+			DKLen: kdfParams.DKLen,
+			N:     kdfParams.N,
+			R:     kdfParams.R,
+			P:     kdfParams.P,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return scrypt.Key(processedPassword, salt, kdfParams.N, kdfParams.R, kdfParams.P, kdfParams.DKLen)
+	case KDFPBKDF2:
+		if kdfParams.PRF != eip2335PRF {
+			return nil, fmt.Errorf("%w: prf %s", crypto.ErrUnsupportedKDF, kdfParams.PRF)
+		}
+		if kdfParams.C <= 0 || kdfParams.C > maxPBKDF2Iterations {
+			return nil, fmt.Errorf("%w: pbkdf2 c %d", crypto.ErrInvalidParam, kdfParams.C)
+		}
+
+		return pbkdf2.Key(processedPassword, salt, kdfParams.C, kdfParams.DKLen, sha256.New), nil
+	default:
+		return nil, crypto.ErrUnsupportedKDF
+	}
+}
+
+// processEIP2335Password normalizes the password to the NFKD form and strips the control codes, as EIP-2335 requires
+func processEIP2335Password(password []byte) ([]byte, error) {
+	if len(password) == 0 {
+		return nil, crypto.ErrEmptyPassword
+	}
+	if !utf8.Valid(password) {
+		return nil, fmt.Errorf("%w: password is not valid UTF-8", crypto.ErrInvalidParam)
+	}
+
+	normalized := norm.NFKD.String(string(password))
+	processed := make([]byte, 0, len(normalized))
+	for _, r := range normalized {
+		isControlCode := r <= 0x1f || (r >= 0x7f && r <= 0x9f)
+		if isControlCode {
+			continue
+		}
+
+		processed = utf8.AppendRune(processed, r)
+	}
+
+	if len(processed) == 0 {
+		return nil, crypto.ErrEmptyPassword
+	}
+
+	return processed, nil
+}
+
+func eip2335Checksum(derivedKey []byte, cipherMessage []byte) []byte {
+	hasher := sha256.New()
+	hasher.Write(derivedKey[16:32])
+	hasher.Write(cipherMessage)
+
+	return hasher.Sum(nil)
+}
+
+func eip2335PubKey(scalar *mcl.Scalar) []byte {
+	pubKey, err := mcl.NewPointG1().Mul(scalar)
+	if err != nil {
+		return nil
+	}
+
+	return mcl.SerializeG1Compressed(pubKey.(*mcl.PointG1).G1)
+}
+
+func aes128CTR(key []byte, iv []byte, input []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]byte, len(input))
+	cipher.NewCTR(block, iv).XORKeyStream(output, input)
+
+	return output, nil
+}
+
+func reverseBytes(buff []byte) []byte {
+	reversed := make([]byte, len(buff))
+	for i := range buff {
+		reversed[len(buff)-1-i] = buff[i]
+	}
+
+	return reversed
+}
+
+// formatUUID formats the random bytes as a version 4 UUID
+func formatUUID(buff []byte) string {
+	buff[6] = (buff[6] & 0x0f) | 0x40
+	buff[8] = (buff[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", buff[0:4], buff[4:6], buff[6:8], buff[8:10], buff[10:16])
+}
@@ -0,0 +1,329 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519"
+	"github.com/ME-MotherEarth/me-crypto/signing/keystore"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from EIP-2335
+const (
+	eip2335Password = "\U0001d531\U0001d522\U0001d530\U0001d531\U0001d52d\U0001d51e\U0001d530\U0001d530\U0001d534\U0001d52c\U0001d52f\U0001d521\U0001f511"
+	eip2335Secret   = "000000000019d6689c085ae165831e934ff763ae46a2a6c172b3f1b60a8ce26f"
+	eip2335PubKey   = "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07"
+	eip2335Salt     = "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+	eip2335IV       = "264daa3f303d7259501c93d997d84fe6"
+
+	eip2335ScryptKeystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "scrypt",
+            "params": {
+                "dklen": 32,
+                "n": 262144,
+                "p": 1,
+                "r": 8,
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "d2217fe5f3e9a1e34581ef8a78f7c9928e436d36dacc5e846690a5581e8ea484"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "06ae90d55fe0a6e9c5c3bc5b170827b2e5cce3929ed3f116c2811e6366dfe20f"
+        }
+    },
+    "description": "This is a test keystore that uses scrypt to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/3141592653/589793238",
+    "uuid": "1d85ae20-35c5-4611-98e8-aa14a633906f",
+    "version": 4
+}`
+
+	eip2335PBKDF2Keystore = `{
+    "crypto": {
+        "kdf": {
+            "function": "pbkdf2",
+            "params": {
+                "dklen": 32,
+                "c": 262144,
+                "prf": "hmac-sha256",
+                "salt": "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3"
+            },
+            "message": ""
+        },
+        "checksum": {
+            "function": "sha256",
+            "params": {},
+            "message": "8a9f5d9912ed7e75ea794bc5a89bca5f193721d30868ade6f73043c6ea6febf1"
+        },
+        "cipher": {
+            "function": "aes-128-ctr",
+            "params": {
+                "iv": "264daa3f303d7259501c93d997d84fe6"
+            },
+            "message": "cee03fde2af33149775b7223e7845e4fb2c8ae1792e5f99fe9ecf474cc8c16ad"
+        }
+    },
+    "description": "This is a test keystore that uses PBKDF2 to secure the secret.",
+    "pubkey": "9612d7a727c9d0a22e185a1c768478dfe919cada9266988cb32359c11f2b7b27f4ae4040902382ae2910c15e2b420d07",
+    "path": "m/12381/60/0/0",
+    "uuid": "64625def-3331-4eea-ab6f-782f3ed16a83",
+    "version": 4
+}`
+)
+
+func eip2335ScryptConfig() keystore.KDFConfig {
+	return keystore.KDFConfig{
+		Function: keystore.KDFScrypt,
+		ScryptN:  262144,
+		ScryptR:  8,
+		ScryptP:  1,
+	}
+}
+
+func eip2335PBKDF2Config() keystore.KDFConfig {
+	return keystore.KDFConfig{
+		Function:         keystore.KDFPBKDF2,
+		PBKDF2Iterations: 262144,
+	}
+}
+
+func fastPBKDF2Config() keystore.KDFConfig {
+	return keystore.KDFConfig{
+		Function:         keystore.KDFPBKDF2,
+		PBKDF2Iterations: 1 << 10,
+	}
+}
+
+// eip2335PrivateKey returns the private key of the test vectors, converting the big endian secret to the little
+// endian serialization of the mcl scalars
+func eip2335PrivateKey(t *testing.T) crypto.PrivateKey {
+	secret, _ := hex.DecodeString(eip2335Secret)
+	scalarBytes := make([]byte, len(secret))
+	for i := range secret {
+		scalarBytes[len(secret)-1-i] = secret[i]
+	}
+
+	privKey, err := signing.NewKeyGenerator(mcl.NewSuiteBLS12()).PrivateKeyFromByteArray(scalarBytes)
+	require.Nil(t, err)
+
+	return privKey
+}
+
+func TestImportEIP2335_TestVectors(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	expectedSecret, _ := new(big.Int).SetString(eip2335Secret, 16)
+
+	vectors := map[string]string{
+		"m/12381/60/3141592653/589793238": eip2335ScryptKeystore,
+		"m/12381/60/0/0":                  eip2335PBKDF2Keystore,
+	}
+	for expectedPath, keystoreJSON := range vectors {
+		privKey, path, err := keystore.ImportEIP2335([]byte(keystoreJSON), []byte(eip2335Password), keyGen)
+		require.Nil(t, err)
+		require.Equal(t, expectedPath, path)
+
+		scalar := privKey.Scalar().(*mcl.Scalar)
+		require.Equal(t, expectedSecret.String(), scalar.Scalar.GetString(10))
+
+		// the password is normalized, so its NFKD form decrypts the keystore as well
+		_, _, err = keystore.ImportEIP2335([]byte(keystoreJSON), []byte("testpassword\U0001f511"), keyGen)
+		require.Nil(t, err)
+	}
+}
+
+func TestExportEIP2335_TestVectors(t *testing.T) {
+	t.Parallel()
+
+	privKey := eip2335PrivateKey(t)
+	salt, _ := hex.DecodeString(eip2335Salt)
+	iv, _ := hex.DecodeString(eip2335IV)
+
+	vectors := map[string]keystore.KDFConfig{
+		eip2335ScryptKeystore: eip2335ScryptConfig(),
+		eip2335PBKDF2Keystore: eip2335PBKDF2Config(),
+	}
+	for vector, config := range vectors {
+		expected := &keystore.EIP2335Keystore{}
+		require.Nil(t, json.Unmarshal([]byte(vector), expected))
+
+		keystoreJSON, err := keystore.ExportEIP2335WithRandomness(
+			privKey,
+			[]byte(eip2335Password),
+			expected.Path,
+			config,
+			salt,
+			iv,
+			expected.UUID,
+		)
+		require.Nil(t, err)
+
+		ks := &keystore.EIP2335Keystore{}
+		require.Nil(t, json.Unmarshal(keystoreJSON, ks))
+		require.Equal(t, keystore.EIP2335Version, ks.Version)
+		require.Equal(t, eip2335PubKey, ks.PubKey)
+		require.Equal(t, expected.Path, ks.Path)
+		require.Equal(t, expected.UUID, ks.UUID)
+		require.Equal(t, expected.Crypto.KDF.Function, ks.Crypto.KDF.Function)
+		require.JSONEq(t, string(expected.Crypto.KDF.Params), string(ks.Crypto.KDF.Params))
+		require.Equal(t, expected.Crypto.Checksum.Message, ks.Crypto.Checksum.Message)
+		require.Equal(t, expected.Crypto.Cipher.Function, ks.Crypto.Cipher.Function)
+		require.JSONEq(t, string(expected.Crypto.Cipher.Params), string(ks.Crypto.Cipher.Params))
+		require.Equal(t, expected.Crypto.Cipher.Message, ks.Crypto.Cipher.Message)
+	}
+}
+
+func TestExportImportEIP2335(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	configs := []keystore.KDFConfig{fastScryptConfig(), fastPBKDF2Config()}
+
+	for _, config := range configs {
+		privKey, _ := keyGen.GeneratePair()
+
+		keystoreJSON, err := keystore.ExportEIP2335(privKey, password, "m/12381/3600/0/0/0", config)
+		require.Nil(t, err, config.Function)
+
+		ks := &keystore.EIP2335Keystore{}
+		require.Nil(t, json.Unmarshal(keystoreJSON, ks))
+		require.Len(t, ks.UUID, 36)
+		require.Equal(t, byte('4'), ks.UUID[14])
+
+		imported, path, err := keystore.ImportEIP2335(keystoreJSON, password, keyGen)
+		require.Nil(t, err, config.Function)
+		require.Equal(t, "m/12381/3600/0/0/0", path)
+
+		expectedBytes, _ := privKey.ToByteArray()
+		importedBytes, _ := imported.ToByteArray()
+		require.Equal(t, expectedBytes, importedBytes)
+
+		// the encryption is randomized
+		otherJSON, _ := keystore.ExportEIP2335(privKey, password, path, config)
+		require.NotEqual(t, keystoreJSON, otherJSON)
+	}
+}
+
+func TestExportEIP2335_ShouldErr(t *testing.T) {
+	t.Parallel()
+
+	privKey, _ := signing.NewKeyGenerator(mcl.NewSuiteBLS12()).GeneratePair()
+
+	_, err := keystore.ExportEIP2335(nil, password, "", fastScryptConfig())
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	_, err = keystore.ExportEIP2335(privKey, nil, "", fastScryptConfig())
+	require.Equal(t, crypto.ErrEmptyPassword, err)
+
+	// the control codes are stripped from the password
+	_, err = keystore.ExportEIP2335(privKey, []byte("\x00\x1f\x7f"), "", fastScryptConfig())
+	require.Equal(t, crypto.ErrEmptyPassword, err)
+
+	_, err = keystore.ExportEIP2335(privKey, password, "", fastArgon2idConfig())
+	require.Equal(t, crypto.ErrUnsupportedKDF, err)
+
+	config := fastPBKDF2Config()
+	config.PBKDF2Iterations = 0
+	_, err = keystore.ExportEIP2335(privKey, password, "", config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	ed25519Key, _ := signing.NewKeyGenerator(ed25519.NewEd25519()).GeneratePair()
+	_, err = keystore.ExportEIP2335(ed25519Key, password, "", fastScryptConfig())
+	require.Equal(t, crypto.ErrSuiteMismatch, err)
+}
+
+func TestImportEIP2335_ShouldErr(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, _ := keyGen.GeneratePair()
+	keystoreJSON, err := keystore.ExportEIP2335(privKey, password, "", fastPBKDF2Config())
+	require.Nil(t, err)
+
+	tamper := func(handler func(ks *keystore.EIP2335Keystore)) []byte {
+		ks := &keystore.EIP2335Keystore{}
+		_ = json.Unmarshal(keystoreJSON, ks)
+		handler(ks)
+		buff, _ := json.Marshal(ks)
+
+		return buff
+	}
+
+	_, _, err = keystore.ImportEIP2335(keystoreJSON, password, nil)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, _, err = keystore.ImportEIP2335(keystoreJSON, nil, keyGen)
+	require.Equal(t, crypto.ErrEmptyPassword, err)
+
+	_, _, err = keystore.ImportEIP2335(keystoreJSON, []byte("wrong password"), keyGen)
+	require.Equal(t, crypto.ErrInvalidPassword, err)
+
+	_, _, err = keystore.ImportEIP2335(keystoreJSON, password, signing.NewKeyGenerator(ed25519.NewEd25519()))
+	require.Equal(t, crypto.ErrSuiteMismatch, err)
+
+	_, _, err = keystore.ImportEIP2335([]byte("not a keystore"), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) { ks.Version = 3 }), password, keyGen)
+	require.Equal(t, crypto.ErrUnsupportedKeystoreVersion, err)
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) { ks.Crypto.Cipher.Function = "aes-256-gcm" }), password, keyGen)
+	require.Equal(t, crypto.ErrUnsupportedCipher, err)
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) { ks.Crypto.KDF.Function = keystore.KDFArgon2id }), password, keyGen)
+	require.Equal(t, crypto.ErrUnsupportedKDF, err)
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) {
+		ks.Crypto.KDF.Params = json.RawMessage(`{"dklen":32,"c":1024,"prf":"hmac-sha512","salt":"00"}`)
+	}), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrUnsupportedKDF))
+
+	// a crafted keystore can not require unbounded resources
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) {
+		ks.Crypto.KDF.Params = json.RawMessage(`{"dklen":32,"c":1073741824,"prf":"hmac-sha256","salt":"00"}`)
+	}), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) {
+		ks.Crypto.KDF.Function = keystore.KDFScrypt
+		ks.Crypto.KDF.Params = json.RawMessage(`{"dklen":32,"n":4194304,"r":1024,"p":1,"salt":"00"}`)
+	}), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) {
+		ks.Crypto.Cipher.Params = json.RawMessage(`{"iv":"00"}`)
+	}), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) { ks.Crypto.Cipher.Message = "00" }), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	// the checksum covers the cipher message
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) {
+		ks.Crypto.Cipher.Message = ks.Crypto.Cipher.Message[2:] + ks.Crypto.Cipher.Message[:2]
+	}), password, keyGen)
+	require.Equal(t, crypto.ErrInvalidPassword, err)
+
+	// the public key needs to match the secret
+	_, _, err = keystore.ImportEIP2335(tamper(func(ks *keystore.EIP2335Keystore) { ks.PubKey = eip2335PubKey }), password, keyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+}
@@ -0,0 +1,15 @@
+package keystore
+
+import crypto "github.com/ME-MotherEarth/me-crypto"
+
+func ExportEIP2335WithRandomness(
+	privKey crypto.PrivateKey,
+	password []byte,
+	path string,
+	kdfConfig KDFConfig,
+	salt []byte,
+	iv []byte,
+	uuid string,
+) ([]byte, error) {
+	return exportEIP2335(privKey, password, path, kdfConfig, salt, iv, uuid)
+}
@@ -0,0 +1,183 @@
+package keystore
+
+import (
+	"fmt"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// KDFScrypt is the name of the scrypt key derivation function
+	KDFScrypt = "scrypt"
+	// KDFArgon2id is the name of the argon2id key derivation function
+	KDFArgon2id = "argon2id"
+	// KDFPBKDF2 is the name of the PBKDF2 key derivation function with HMAC-SHA256, only used for EIP-2335 keystores
+	KDFPBKDF2 = "pbkdf2"
+)
+
+const (
+	derivedKeyLen = 32
+	saltLen       = 32
+
+	// the maximum costs accepted when decrypting, so a crafted keystore can not exhaust the resources. The scrypt
+	// memory, 128*r*(n+p) bytes, is bounded as a whole, as n and r multiply each other. The argon2id memory, in KiB,
+	// has the same 1 GiB limit, and as each pass goes over the whole memory, the memory times the passes is bounded too
+	maxScryptN          = 1 << 22
+	maxScryptP          = 16
+	maxScryptMemory     = 1 << 30
+	maxArgon2Memory     = maxScryptMemory / 1024
+	maxArgon2Time       = 1 << 10
+	maxArgon2MemoryTime = 4 * maxArgon2Memory
+	maxPBKDF2Iterations = 1 << 24
+
+	defaultScryptN          = 1 << 18
+	defaultScryptR          = 8
+	defaultScryptP          = 1
+	defaultArgon2Time       = 3
+	defaultArgon2Memory     = 64 * 1024
+	defaultArgon2Threads    = 4
+	defaultPBKDF2Iterations = 1 << 18
+)
+
+// KDFConfig holds the key derivation function used to derive the encryption key from the password, together with
+// its cost parameters. Only the parameters of the selected function are used
+type KDFConfig struct {
+	Function string
+	// ScryptN is the CPU/memory cost parameter of scrypt, a power of 2
+	ScryptN int
+	ScryptR int
+	ScryptP int
+	// Argon2Time is the number of passes over the memory
+	Argon2Time uint32
+	// Argon2Memory is the memory used, in KiB
+	Argon2Memory  uint32
+	Argon2Threads uint8
+	// PBKDF2Iterations is the iterations count of PBKDF2
+	PBKDF2Iterations int
+}
+
+// DefaultScryptConfig returns the scrypt configuration with the recommended interactive cost parameters
+func DefaultScryptConfig() KDFConfig {
+	return KDFConfig{
+		Function: KDFScrypt,
+		ScryptN:  defaultScryptN,
+		ScryptR:  defaultScryptR,
+		ScryptP:  defaultScryptP,
+	}
+}
+
+// DefaultArgon2idConfig returns the argon2id configuration with the recommended cost parameters
+func DefaultArgon2idConfig() KDFConfig {
+	return KDFConfig{
+		Function:      KDFArgon2id,
+		Argon2Time:    defaultArgon2Time,
+		Argon2Memory:  defaultArgon2Memory,
+		Argon2Threads: defaultArgon2Threads,
+	}
+}
+
+// DefaultPBKDF2Config returns the PBKDF2 configuration with the iterations count recommended by EIP-2335
+func DefaultPBKDF2Config() KDFConfig {
+	return KDFConfig{
+		Function:         KDFPBKDF2,
+		PBKDF2Iterations: defaultPBKDF2Iterations,
+	}
+}
+
+// KDFParams holds the parameters of the key derivation function as they are stored in the keystore
+type KDFParams struct {
+	Salt    string `json:"salt"`
+	DKLen   int    `json:"dklen"`
+	N       int    `json:"n,omitempty"`
+	R       int    `json:"r,omitempty"`
+	P       int    `json:"p,omitempty"`
+	Time    uint32 `json:"time,omitempty"`
+	Memory  uint32 `json:"memory,omitempty"`
+	Threads uint8  `json:"threads,omitempty"`
+}
+
+func (config KDFConfig) toParams(salt string) (KDFParams, error) {
+	params := KDFParams{
+		Salt:  salt,
+		DKLen: derivedKeyLen,
+	}
+
+	switch config.Function {
+	case KDFScrypt:
+		params.N = config.ScryptN
+		params.R = config.ScryptR
+		params.P = config.ScryptP
+	case KDFArgon2id:
+		params.Time = config.Argon2Time
+		params.Memory = config.Argon2Memory
+		params.Threads = config.Argon2Threads
+	default:
+		return KDFParams{}, crypto.ErrUnsupportedKDF
+	}
+
+	err := checkKDFParams(config.Function, params)
+	if err != nil {
+		return KDFParams{}, err
+	}
+
+	return params, nil
+}
+
+func checkKDFParams(function string, params KDFParams) error {
+	if params.DKLen != derivedKeyLen {
+		return fmt.Errorf("%w: derived key length %d", crypto.ErrInvalidKeystore, params.DKLen)
+	}
+
+	switch function {
+	case KDFScrypt:
+		isPowerOfTwo := params.N > 1 && params.N&(params.N-1) == 0
+		if !isPowerOfTwo || params.N > maxScryptN {
+			return fmt.Errorf("%w: scrypt n %d", crypto.ErrInvalidParam, params.N)
+		}
+		if params.R <= 0 || params.P <= 0 || params.P > maxScryptP {
+			return fmt.Errorf("%w: scrypt r %d, p %d", crypto.ErrInvalidParam, params.R, params.P)
+		}
+		if scryptMemory(params.N, params.R, params.P) > maxScryptMemory {
+			return fmt.Errorf("%w: scrypt n %d, r %d, p %d exceed the memory limit",
+				crypto.ErrInvalidParam, params.N, params.R, params.P)
+		}
+	case KDFArgon2id:
+		if params.Time == 0 || params.Time > maxArgon2Time {
+			return fmt.Errorf("%w: argon2id time %d", crypto.ErrInvalidParam, params.Time)
+		}
+		if params.Threads == 0 || params.Memory < 8*uint32(params.Threads) || params.Memory > maxArgon2Memory {
+			return fmt.Errorf("%w: argon2id memory %d, threads %d", crypto.ErrInvalidParam, params.Memory, params.Threads)
+		}
+		if uint64(params.Memory)*uint64(params.Time) > maxArgon2MemoryTime {
+			return fmt.Errorf("%w: argon2id memory %d, time %d exceed the cost limit",
+				crypto.ErrInvalidParam, params.Memory, params.Time)
+		}
+	default:
+		return crypto.ErrUnsupportedKDF
+	}
+
+	return nil
+}
+
+// scryptMemory returns the memory in bytes used by scrypt, saturating instead of overflowing for huge r
+func scryptMemory(n int, r int, p int) uint64 {
+	blocks := uint64(n) + uint64(p)
+	if uint64(r) > maxScryptMemory/128 {
+		return maxScryptMemory + 1
+	}
+
+	return 128 * uint64(r) * blocks
+}
+
+func deriveKey(function string, params KDFParams, password []byte, salt []byte) ([]byte, error) {
+	switch function {
+	case KDFScrypt:
+		return scrypt.Key(password, salt, params.N, params.R, params.P, params.DKLen)
+	case KDFArgon2id:
+		return argon2.IDKey(password, salt, params.Time, params.Memory, params.Threads, uint32(params.DKLen)), nil
+	default:
+		return nil, crypto.ErrUnsupportedKDF
+	}
+}
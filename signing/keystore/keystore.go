@@ -0,0 +1,219 @@
+package keystore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+const (
+	// Version is the version of the keystore format
+	Version = 1
+	// CipherAES256GCM is the name of the AES-256 cipher in GCM mode, used to encrypt the private keys
+	CipherAES256GCM = "aes-256-gcm"
+)
+
+/*
+Keystore is the JSON representation of an encrypted private key. The encryption key is derived from a password with
+the key derivation function, and the private key is encrypted with an AEAD cipher, authenticating as well the
+version, the suite name and the public key, so none of them can be changed without the decryption failing.
+
+The suite name is the one returned by crypto.Suite.String(), so loading the key with a key generator of another
+suite fails with ErrSuiteMismatch
+*/
+type Keystore struct {
+	Version int          `json:"version"`
+	Suite   string       `json:"suite"`
+	PubKey  string       `json:"pubkey"`
+	Crypto  CryptoParams `json:"crypto"`
+}
+
+// CryptoParams holds the parameters needed to decrypt the private key, all the binary fields being hex encoded
+type CryptoParams struct {
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdfparams"`
+	Cipher     string    `json:"cipher"`
+	Nonce      string    `json:"nonce"`
+	CipherText string    `json:"ciphertext"`
+}
+
+// Encrypt encrypts the private key with the password into a JSON keystore, using the key derivation function of the
+// configuration
+func Encrypt(privKey crypto.PrivateKey, password []byte, kdfConfig KDFConfig) ([]byte, error) {
+	if check.IfNil(privKey) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if len(password) == 0 {
+		return nil, crypto.ErrEmptyPassword
+	}
+
+	suite := privKey.Suite()
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+
+	privKeyBytes, err := privKey.ToByteArray()
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBytes, err := privKey.GeneratePublic().ToByteArray()
+	if err != nil {
+		return nil, err
+	}
+
+	salt, err := randomBytes(saltLen)
+	if err != nil {
+		return nil, err
+	}
+
+	kdfParams, err := kdfConfig.toParams(hex.EncodeToString(salt))
+	if err != nil {
+		return nil, err
+	}
+
+	ks := &Keystore{
+		Version: Version,
+		Suite:   suite.String(),
+		PubKey:  hex.EncodeToString(pubKeyBytes),
+		Crypto: CryptoParams{
+			KDF:       kdfConfig.Function,
+			KDFParams: kdfParams,
+			Cipher:    CipherAES256GCM,
+		},
+	}
+
+	derivedKey, err := deriveKey(ks.Crypto.KDF, kdfParams, password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := randomBytes(aead.NonceSize())
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText := aead.Seal(nil, nonce, privKeyBytes, ks.additionalData())
+	ks.Crypto.Nonce = hex.EncodeToString(nonce)
+	ks.Crypto.CipherText = hex.EncodeToString(cipherText)
+
+	return json.MarshalIndent(ks, "", "  ")
+}
+
+// Decrypt decrypts the private key from the JSON keystore with the password, the key being created by the key
+// generator of the suite the keystore was created for
+func Decrypt(keystoreJSON []byte, password []byte, keyGen crypto.KeyGenerator) (crypto.PrivateKey, error) {
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+	if len(password) == 0 {
+		return nil, crypto.ErrEmptyPassword
+	}
+
+	ks := &Keystore{}
+	err := json.Unmarshal(keystoreJSON, ks)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", crypto.ErrInvalidKeystore, err)
+	}
+
+	err = ks.checkHeader(keyGen.Suite())
+	if err != nil {
+		return nil, err
+	}
+
+	salt, nonce, cipherText, err := ks.decodeCryptoParams()
+	if err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := deriveKey(ks.Crypto.KDF, ks.Crypto.KDFParams, password, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := newAEAD(derivedKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nonce) != aead.NonceSize() {
+		return nil, fmt.Errorf("%w: nonce length %d", crypto.ErrInvalidKeystore, len(nonce))
+	}
+
+	privKeyBytes, err := aead.Open(nil, nonce, cipherText, ks.additionalData())
+	if err != nil {
+		return nil, crypto.ErrInvalidPassword
+	}
+
+	return keyGen.PrivateKeyFromByteArray(privKeyBytes)
+}
+
+func (ks *Keystore) checkHeader(suite crypto.Suite) error {
+	if ks.Version != Version {
+		return crypto.ErrUnsupportedKeystoreVersion
+	}
+	if check.IfNil(suite) {
+		return crypto.ErrNilSuite
+	}
+	if ks.Suite != suite.String() {
+		return fmt.Errorf("%w: keystore created for %s, loaded with %s", crypto.ErrSuiteMismatch, ks.Suite, suite.String())
+	}
+	if ks.Crypto.Cipher != CipherAES256GCM {
+		return crypto.ErrUnsupportedCipher
+	}
+
+	return checkKDFParams(ks.Crypto.KDF, ks.Crypto.KDFParams)
+}
+
+func (ks *Keystore) decodeCryptoParams() (salt []byte, nonce []byte, cipherText []byte, err error) {
+	salt, err = hex.DecodeString(ks.Crypto.KDFParams.Salt)
+	if err != nil || len(salt) == 0 {
+		return nil, nil, nil, fmt.Errorf("%w: salt", crypto.ErrInvalidKeystore)
+	}
+
+	nonce, err = hex.DecodeString(ks.Crypto.Nonce)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: nonce", crypto.ErrInvalidKeystore)
+	}
+
+	cipherText, err = hex.DecodeString(ks.Crypto.CipherText)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("%w: ciphertext", crypto.ErrInvalidKeystore)
+	}
+
+	return salt, nonce, cipherText, nil
+}
+
+// additionalData returns the header fields authenticated together with the private key
+func (ks *Keystore) additionalData() []byte {
+	return []byte(fmt.Sprintf("%d|%s|%s", ks.Version, ks.Suite, ks.PubKey))
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+func randomBytes(length int) ([]byte, error) {
+	buff := make([]byte, length)
+	_, err := rand.Read(buff)
+	if err != nil {
+		return nil, err
+	}
+
+	return buff, nil
+}
@@ -0,0 +1,223 @@
+package keystore_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519"
+	"github.com/ME-MotherEarth/me-crypto/signing/keystore"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+var password = []byte("correct horse battery staple")
+
+func fastScryptConfig() keystore.KDFConfig {
+	return keystore.KDFConfig{
+		Function: keystore.KDFScrypt,
+		ScryptN:  1 << 10,
+		ScryptR:  8,
+		ScryptP:  1,
+	}
+}
+
+func fastArgon2idConfig() keystore.KDFConfig {
+	return keystore.KDFConfig{
+		Function:      keystore.KDFArgon2id,
+		Argon2Time:    1,
+		Argon2Memory:  64,
+		Argon2Threads: 1,
+	}
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+
+	keyGens := map[string]crypto.KeyGenerator{
+		"BLS12-381": signing.NewKeyGenerator(mcl.NewSuiteBLS12()),
+		"Ed25519":   signing.NewKeyGenerator(ed25519.NewEd25519()),
+	}
+	configs := map[string]keystore.KDFConfig{
+		keystore.KDFScrypt:   fastScryptConfig(),
+		keystore.KDFArgon2id: fastArgon2idConfig(),
+	}
+
+	for keyGenName, keyGen := range keyGens {
+		for configName, config := range configs {
+			privKey, pubKey := keyGen.GeneratePair()
+
+			keystoreJSON, err := keystore.Encrypt(privKey, password, config)
+			require.Nil(t, err, keyGenName+" "+configName)
+
+			ks := &keystore.Keystore{}
+			require.Nil(t, json.Unmarshal(keystoreJSON, ks))
+			require.Equal(t, keystore.Version, ks.Version)
+			require.Equal(t, keyGen.Suite().String(), ks.Suite)
+			require.Equal(t, configName, ks.Crypto.KDF)
+			require.Equal(t, keystore.CipherAES256GCM, ks.Crypto.Cipher)
+
+			decrypted, err := keystore.Decrypt(keystoreJSON, password, keyGen)
+			require.Nil(t, err, keyGenName+" "+configName)
+
+			expectedBytes, _ := privKey.ToByteArray()
+			decryptedBytes, _ := decrypted.ToByteArray()
+			require.Equal(t, expectedBytes, decryptedBytes)
+
+			expectedPubKey, _ := pubKey.ToByteArray()
+			decryptedPubKey, _ := decrypted.GeneratePublic().ToByteArray()
+			require.Equal(t, expectedPubKey, decryptedPubKey)
+
+			// the encryption is randomized
+			otherJSON, _ := keystore.Encrypt(privKey, password, config)
+			require.NotEqual(t, keystoreJSON, otherJSON)
+		}
+	}
+}
+
+func TestEncrypt_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	privKey, _ := signing.NewKeyGenerator(mcl.NewSuiteBLS12()).GeneratePair()
+
+	_, err := keystore.Encrypt(nil, password, fastScryptConfig())
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	_, err = keystore.Encrypt(privKey, nil, fastScryptConfig())
+	require.Equal(t, crypto.ErrEmptyPassword, err)
+
+	_, err = keystore.Encrypt(privKey, password, keystore.KDFConfig{Function: "pbkdf2"})
+	require.Equal(t, crypto.ErrUnsupportedKDF, err)
+
+	config := fastScryptConfig()
+	config.ScryptN = 1000
+	_, err = keystore.Encrypt(privKey, password, config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	// the scrypt memory of 128*r*n bytes is bounded, whichever of n or r is oversized
+	config = fastScryptConfig()
+	config.ScryptR = 1 << 14
+	_, err = keystore.Encrypt(privKey, password, config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	config = fastScryptConfig()
+	config.ScryptN = 1 << 22
+	_, err = keystore.Encrypt(privKey, password, config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	config = fastScryptConfig()
+	config.ScryptP = 17
+	_, err = keystore.Encrypt(privKey, password, config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	config = fastArgon2idConfig()
+	config.Argon2Threads = 0
+	_, err = keystore.Encrypt(privKey, password, config)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+}
+
+func TestDefaultConfigs(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, keystore.KDFScrypt, keystore.DefaultScryptConfig().Function)
+	require.Equal(t, keystore.KDFArgon2id, keystore.DefaultArgon2idConfig().Function)
+
+	privKey, _ := signing.NewKeyGenerator(ed25519.NewEd25519()).GeneratePair()
+	_, err := keystore.Encrypt(privKey, password, keystore.DefaultArgon2idConfig())
+	require.Nil(t, err)
+}
+
+func TestDecrypt_ShouldErr(t *testing.T) {
+	t.Parallel()
+
+	blsKeyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, _ := blsKeyGen.GeneratePair()
+	keystoreJSON, err := keystore.Encrypt(privKey, password, fastScryptConfig())
+	require.Nil(t, err)
+
+	tamper := func(handler func(ks *keystore.Keystore)) []byte {
+		return tamperKeystore(keystoreJSON, handler)
+	}
+
+	_, err = keystore.Decrypt(keystoreJSON, password, nil)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, err = keystore.Decrypt(keystoreJSON, nil, blsKeyGen)
+	require.Equal(t, crypto.ErrEmptyPassword, err)
+
+	_, err = keystore.Decrypt(keystoreJSON, []byte("wrong password"), blsKeyGen)
+	require.Equal(t, crypto.ErrInvalidPassword, err)
+
+	_, err = keystore.Decrypt(keystoreJSON, password, signing.NewKeyGenerator(ed25519.NewEd25519()))
+	require.True(t, errors.Is(err, crypto.ErrSuiteMismatch))
+
+	_, err = keystore.Decrypt([]byte("not a keystore"), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Version = 2 }), password, blsKeyGen)
+	require.Equal(t, crypto.ErrUnsupportedKeystoreVersion, err)
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.Cipher = "aes-128-ctr" }), password, blsKeyGen)
+	require.Equal(t, crypto.ErrUnsupportedCipher, err)
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.KDF = "pbkdf2" }), password, blsKeyGen)
+	require.Equal(t, crypto.ErrUnsupportedKDF, err)
+
+	// a crafted keystore can not require unbounded resources
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.KDFParams.N = 1 << 30 }), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) {
+		ks.Crypto.KDFParams.N = 1 << 22
+		ks.Crypto.KDFParams.R = 1 << 10
+	}), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.KDFParams.R = 1 << 40 }), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	argon2idJSON, err := keystore.Encrypt(privKey, password, fastArgon2idConfig())
+	require.Nil(t, err)
+
+	// the argon2id memory is bounded as the scrypt one, and together with the number of passes
+	_, err = keystore.Decrypt(tamperKeystore(argon2idJSON, func(ks *keystore.Keystore) {
+		ks.Crypto.KDFParams.Memory = 1<<20 + 1
+	}), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, err = keystore.Decrypt(tamperKeystore(argon2idJSON, func(ks *keystore.Keystore) {
+		ks.Crypto.KDFParams.Memory = 1 << 20
+		ks.Crypto.KDFParams.Time = 5
+	}), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, err = keystore.Decrypt(tamperKeystore(argon2idJSON, func(ks *keystore.Keystore) {
+		ks.Crypto.KDFParams.Memory = 1 << 16
+		ks.Crypto.KDFParams.Time = 1 << 10
+	}), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.KDFParams.Salt = "zz" }), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.Crypto.Nonce = "00" }), password, blsKeyGen)
+	require.True(t, errors.Is(err, crypto.ErrInvalidKeystore))
+
+	// the public key is authenticated
+	_, otherPubKey := blsKeyGen.GeneratePair()
+	otherPubKeyBytes, _ := otherPubKey.ToByteArray()
+	_, err = keystore.Decrypt(tamper(func(ks *keystore.Keystore) { ks.PubKey = hex.EncodeToString(otherPubKeyBytes) }), password, blsKeyGen)
+	require.Equal(t, crypto.ErrInvalidPassword, err)
+}
+
+func tamperKeystore(keystoreJSON []byte, handler func(ks *keystore.Keystore)) []byte {
+	ks := &keystore.Keystore{}
+	_ = json.Unmarshal(keystoreJSON, ks)
+	handler(ks)
+	buff, _ := json.Marshal(ks)
+
+	return buff
+}
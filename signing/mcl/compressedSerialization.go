@@ -0,0 +1,213 @@
+package mcl
+
+import (
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// The compressed serialization used by the IETF BLS signature standard and the Ethereum consensus specification
+// (also known as the ZCash serialization format) encodes the x coordinate as big endian, with the three most
+// significant bits of the first byte used as flags. This differs from the mcl library native serialization, which is
+// little endian
+
+const (
+	fpByteSize          = 48
+	compressedFlag      = byte(0x80)
+	infinityFlag        = byte(0x40)
+	signFlag            = byte(0x20)
+	flagsMask           = compressedFlag | infinityFlag | signFlag
+	g1CompressedByteLen = fpByteSize
+	g2CompressedByteLen = 2 * fpByteSize
+)
+
+// SerializeG1Compressed returns the 48 bytes compressed serialization of the G1 point
+func SerializeG1Compressed(point *bls.G1) []byte {
+	buff := make([]byte, g1CompressedByteLen)
+	if point.IsZero() {
+		buff[0] = compressedFlag | infinityFlag
+		return buff
+	}
+
+	affine := &bls.G1{}
+	bls.G1Normalize(affine, point)
+	copy(buff, fpToBigEndian(&affine.X))
+	buff[0] |= compressedFlag
+	if affine.Y.IsNegative() {
+		buff[0] |= signFlag
+	}
+
+	return buff
+}
+
+// DeserializeG1Compressed returns the G1 point from its 48 bytes compressed serialization. It only checks that the
+// point is on the curve, the caller being responsible for the subgroup check
+func DeserializeG1Compressed(buff []byte) (*bls.G1, error) {
+	isInfinity, isNegative, err := parseCompressedFlags(buff, g1CompressedByteLen)
+	if err != nil {
+		return nil, err
+	}
+
+	point := &bls.G1{}
+	if isInfinity {
+		point.Clear()
+		return point, nil
+	}
+
+	x, err := fpFromBigEndian(withoutFlags(buff[:fpByteSize]))
+	if err != nil {
+		return nil, err
+	}
+
+	// y^2 = x^3 + 4
+	y2 := &bls.Fp{}
+	four := &bls.Fp{}
+	four.SetInt64(4)
+	bls.FpSqr(y2, x)
+	bls.FpMul(y2, y2, x)
+	bls.FpAdd(y2, y2, four)
+	if !bls.FpSquareRoot(&point.Y, y2) {
+		return nil, crypto.ErrInvalidPoint
+	}
+	if point.Y.IsNegative() != isNegative {
+		bls.FpNeg(&point.Y, &point.Y)
+	}
+
+	point.X = *x
+	point.Z.SetInt64(1)
+
+	return point, nil
+}
+
+// SerializeG2Compressed returns the 96 bytes compressed serialization of the G2 point. The x coordinate
+// x = x_0 + x_1 * u is encoded as x_1 || x_0
+func SerializeG2Compressed(point *bls.G2) []byte {
+	buff := make([]byte, g2CompressedByteLen)
+	if point.IsZero() {
+		buff[0] = compressedFlag | infinityFlag
+		return buff
+	}
+
+	affine := &bls.G2{}
+	bls.G2Normalize(affine, point)
+	copy(buff, fpToBigEndian(&affine.X.D[1]))
+	copy(buff[fpByteSize:], fpToBigEndian(&affine.X.D[0]))
+	buff[0] |= compressedFlag
+	if isFp2Negative(&affine.Y) {
+		buff[0] |= signFlag
+	}
+
+	return buff
+}
+
+// DeserializeG2Compressed returns the G2 point from its 96 bytes compressed serialization. It only checks that the
+// point is on the curve, the caller being responsible for the subgroup check
+func DeserializeG2Compressed(buff []byte) (*bls.G2, error) {
+	isInfinity, isNegative, err := parseCompressedFlags(buff, g2CompressedByteLen)
+	if err != nil {
+		return nil, err
+	}
+
+	point := &bls.G2{}
+	if isInfinity {
+		point.Clear()
+		return point, nil
+	}
+
+	x1, err := fpFromBigEndian(withoutFlags(buff[:fpByteSize]))
+	if err != nil {
+		return nil, err
+	}
+	x0, err := fpFromBigEndian(buff[fpByteSize:])
+	if err != nil {
+		return nil, err
+	}
+
+	point.X.D[0] = *x0
+	point.X.D[1] = *x1
+
+	// y^2 = x^3 + 4 * (1 + u)
+	y2 := &bls.Fp2{}
+	b := &bls.Fp2{}
+	b.D[0].SetInt64(4)
+	b.D[1].SetInt64(4)
+	bls.Fp2Sqr(y2, &point.X)
+	bls.Fp2Mul(y2, y2, &point.X)
+	bls.Fp2Add(y2, y2, b)
+	if !bls.Fp2SquareRoot(&point.Y, y2) {
+		return nil, crypto.ErrInvalidPoint
+	}
+	if isFp2Negative(&point.Y) != isNegative {
+		bls.Fp2Neg(&point.Y, &point.Y)
+	}
+
+	point.Z.D[0].SetInt64(1)
+
+	return point, nil
+}
+
+func parseCompressedFlags(buff []byte, expectedLen int) (isInfinity bool, isNegative bool, err error) {
+	if len(buff) != expectedLen {
+		return false, false, crypto.ErrInvalidPoint
+	}
+
+	flags := buff[0] & flagsMask
+	if flags&compressedFlag == 0 {
+		return false, false, crypto.ErrInvalidPoint
+	}
+
+	isInfinity = flags&infinityFlag != 0
+	isNegative = flags&signFlag != 0
+	if !isInfinity {
+		return false, isNegative, nil
+	}
+	if isNegative || buff[0]&^flagsMask != 0 {
+		return false, false, crypto.ErrInvalidPoint
+	}
+	for _, b := range buff[1:] {
+		if b != 0 {
+			return false, false, crypto.ErrInvalidPoint
+		}
+	}
+
+	return true, false, nil
+}
+
+// isFp2Negative returns true if y is lexicographically larger than -y, comparing the imaginary parts first
+func isFp2Negative(y *bls.Fp2) bool {
+	if !y.D[1].IsZero() {
+		return y.D[1].IsNegative()
+	}
+
+	return y.D[0].IsNegative()
+}
+
+func fpToBigEndian(fp *bls.Fp) []byte {
+	return reverseBytes(fp.Serialize())
+}
+
+func fpFromBigEndian(buff []byte) (*bls.Fp, error) {
+	fp := &bls.Fp{}
+	err := fp.Deserialize(reverseBytes(buff))
+	if err != nil {
+		return nil, crypto.ErrInvalidPoint
+	}
+
+	return fp, nil
+}
+
+func withoutFlags(buff []byte) []byte {
+	cleared := make([]byte, len(buff))
+	copy(cleared, buff)
+	cleared[0] &^= flagsMask
+
+	return cleared
+}
+
+func reverseBytes(buff []byte) []byte {
+	reversed := make([]byte, len(buff))
+	for i := range buff {
+		reversed[len(buff)-1-i] = buff[i]
+	}
+
+	return reversed
+}
@@ -0,0 +1,124 @@
+package mcl_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	g1GeneratorCompressed = "97f1d3a73197d7942695638c4fa9ac0fc3688c4f9774b905a14e3a3f171bac586c55e83ff97a1aeffb3af00adb22c6bb"
+	g2GeneratorCompressed = "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+	infinityCompressedPrefix = "c0"
+)
+
+func TestSerializeG1Compressed_Generator(t *testing.T) {
+	t.Parallel()
+
+	generator := mcl.NewPointG1()
+	require.Equal(t, g1GeneratorCompressed, hex.EncodeToString(mcl.SerializeG1Compressed(generator.G1)))
+
+	buff, _ := hex.DecodeString(g1GeneratorCompressed)
+	point, err := mcl.DeserializeG1Compressed(buff)
+	require.Nil(t, err)
+	require.True(t, point.IsEqual(generator.G1))
+}
+
+func TestSerializeG2Compressed_Generator(t *testing.T) {
+	t.Parallel()
+
+	buff, _ := hex.DecodeString(g2GeneratorCompressed)
+	point, err := mcl.DeserializeG2Compressed(buff)
+	require.Nil(t, err)
+	require.True(t, point.IsValidOrder())
+	require.True(t, point.IsEqual(mcl.GeneratorG2IETF().G2))
+	require.Equal(t, g2GeneratorCompressed, hex.EncodeToString(mcl.SerializeG2Compressed(point)))
+
+	// the mcl library uses a different G2 generator
+	require.False(t, point.IsEqual(mcl.NewPointG2().G2))
+}
+
+func TestSerializeCompressed_RandomPointsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for i := 0; i < 20; i++ {
+		scalar := mcl.NewScalar()
+		scalar.Scalar.SetByCSPRNG()
+
+		g1 := &bls.G1{}
+		bls.G1Mul(g1, mcl.NewPointG1().G1, scalar.Scalar)
+		g1Restored, err := mcl.DeserializeG1Compressed(mcl.SerializeG1Compressed(g1))
+		require.Nil(t, err)
+		require.True(t, g1Restored.IsEqual(g1))
+
+		g2 := &bls.G2{}
+		bls.G2Mul(g2, mcl.NewPointG2().G2, scalar.Scalar)
+		g2Restored, err := mcl.DeserializeG2Compressed(mcl.SerializeG2Compressed(g2))
+		require.Nil(t, err)
+		require.True(t, g2Restored.IsEqual(g2))
+	}
+}
+
+func TestSerializeCompressed_Infinity(t *testing.T) {
+	t.Parallel()
+
+	g1 := &bls.G1{}
+	g1.Clear()
+	buff := mcl.SerializeG1Compressed(g1)
+	require.Equal(t, infinityCompressedPrefix, hex.EncodeToString(buff[:1]))
+	g1Restored, err := mcl.DeserializeG1Compressed(buff)
+	require.Nil(t, err)
+	require.True(t, g1Restored.IsZero())
+
+	g2 := &bls.G2{}
+	g2.Clear()
+	buff = mcl.SerializeG2Compressed(g2)
+	require.Equal(t, infinityCompressedPrefix, hex.EncodeToString(buff[:1]))
+	g2Restored, err := mcl.DeserializeG2Compressed(buff)
+	require.Nil(t, err)
+	require.True(t, g2Restored.IsZero())
+}
+
+func TestDeserializeCompressed_InvalidBytesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	valid, _ := hex.DecodeString(g1GeneratorCompressed)
+
+	_, err := mcl.DeserializeG1Compressed(valid[1:])
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+
+	uncompressed := append([]byte{}, valid...)
+	uncompressed[0] &^= 0x80
+	_, err = mcl.DeserializeG1Compressed(uncompressed)
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+
+	invalidInfinity := make([]byte, 48)
+	invalidInfinity[0] = 0xc0
+	invalidInfinity[47] = 1
+	_, err = mcl.DeserializeG1Compressed(invalidInfinity)
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+
+	// x larger than the field modulus
+	tooLarge := make([]byte, 48)
+	for i := range tooLarge {
+		tooLarge[i] = 0xff
+	}
+	tooLarge[0] = 0x9f
+	_, err = mcl.DeserializeG1Compressed(tooLarge)
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+
+	// x = 1 is not on the curve, as 5 is not a square
+	notOnCurve := make([]byte, 48)
+	notOnCurve[0] = 0x80
+	notOnCurve[47] = 1
+	_, err = mcl.DeserializeG1Compressed(notOnCurve)
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+
+	_, err = mcl.DeserializeG2Compressed(valid)
+	require.Equal(t, crypto.ErrInvalidPoint, err)
+}
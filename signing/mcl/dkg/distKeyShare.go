@@ -0,0 +1,31 @@
+package dkg
+
+import (
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+// DistKeyShare is the output of a successful DKG run for one participant
+type DistKeyShare struct {
+	// Index is the participant index, which is also the evaluation point of its share
+	Index uint16
+	// Share is the participant secret share of the group private key
+	Share crypto.Scalar
+	// Commitments are the commitments of the group polynomial, the first one being the group public key
+	Commitments []crypto.Point
+	// Qualified holds the sorted indexes of the dealers that contributed to the group key
+	Qualified []uint16
+}
+
+// GroupPublicKey returns the public key corresponding to the distributed group private key
+func (dks *DistKeyShare) GroupPublicKey() crypto.Point {
+	return dks.Commitments[0]
+}
+
+// PublicKeyShare returns the public key corresponding to the secret share of the participant with the given index
+func (dks *DistKeyShare) PublicKeyShare(index uint16) (crypto.Point, error) {
+	if index == 0 {
+		return nil, crypto.ErrIndexOutOfBounds
+	}
+
+	return evaluateCommitments(dks.Commitments, dks.Share, index)
+}
@@ -0,0 +1,35 @@
+package dkg_test
+
+import (
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/dkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistKeyShare_PublicKeyShare(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	// constant polynomial f(x) = s
+	secret := suite.CreateScalar()
+	groupPubKey, _ := suite.CreatePointForScalar(secret)
+	keyShare := &dkg.DistKeyShare{
+		Index:       1,
+		Share:       secret,
+		Commitments: []crypto.Point{groupPubKey},
+	}
+
+	assert.Equal(t, groupPubKey, keyShare.GroupPublicKey())
+
+	pubKeyShare, err := keyShare.PublicKeyShare(0)
+	assert.Nil(t, pubKeyShare)
+	assert.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	pubKeyShare, err = keyShare.PublicKeyShare(7)
+	assert.Nil(t, err)
+	equal, _ := pubKeyShare.Equal(groupPubKey)
+	assert.True(t, equal)
+}
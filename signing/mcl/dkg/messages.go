@@ -0,0 +1,27 @@
+package dkg
+
+// Deal holds the Feldman commitments of a dealer's secret polynomial and is broadcast to all participants
+type Deal struct {
+	Dealer      uint16   `json:"dealer"`
+	Commitments [][]byte `json:"commitments"`
+}
+
+// Share holds the evaluation of a dealer's secret polynomial for one recipient and must be sent over a private channel
+type Share struct {
+	Dealer    uint16 `json:"dealer"`
+	Recipient uint16 `json:"recipient"`
+	Value     []byte `json:"value"`
+}
+
+// Complaint is broadcast by a participant that received a missing or invalid share from a dealer
+type Complaint struct {
+	Complainer uint16 `json:"complainer"`
+	Dealer     uint16 `json:"dealer"`
+}
+
+// Justification is broadcast by a dealer in response to a complaint and publicly reveals the disputed share
+type Justification struct {
+	Dealer     uint16 `json:"dealer"`
+	Complainer uint16 `json:"complainer"`
+	Value      []byte `json:"value"`
+}
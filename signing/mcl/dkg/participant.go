@@ -0,0 +1,513 @@
+package dkg
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+)
+
+/*
+participant runs a Pedersen style distributed key generation (joint Feldman) for a t-of-n BLS key, without a trusted
+dealer. Each participant acts as dealer for a random polynomial of degree t-1, and the group secret is the sum of the
+free coefficients of the polynomials of all qualified dealers. The protocol is driven by the caller through messages:
+
+ 1. Deal: every participant broadcasts its Feldman commitments (on G2) and privately sends a share to every other one
+ 2. Complaints: every participant checks the received shares against the commitments and broadcasts complaints
+ 3. Justifications: an accused dealer answers every complaint by publicly revealing the disputed share
+ 4. Finalize: dealers that did not deal or did not correctly justify all complaints against them are disqualified
+
+The participant does no networking, so the messages must be delivered by the caller: deals, complaints and
+justifications over a reliable broadcast channel and shares over private channels.
+*/
+type participant struct {
+	mut             sync.Mutex
+	suite           crypto.Suite
+	index           uint16
+	threshold       uint16
+	numParticipants uint16
+	phase           phase
+
+	deals          map[uint16][]crypto.Point
+	dealsBytes     map[uint16][][]byte
+	shares         map[uint16]crypto.Scalar
+	dealtShares    map[uint16]crypto.Scalar
+	complaints     map[uint16]map[uint16]struct{}
+	justifications map[uint16]map[uint16]crypto.Scalar
+	disqualified   map[uint16]struct{}
+}
+
+type phase uint8
+
+const (
+	phaseDeal phase = iota
+	phaseShares
+	phaseComplaints
+	phaseFinalized
+)
+
+// NewParticipant creates a DKG participant with the given index (1-based) out of numParticipants, for a group key
+// that can be used by any threshold participants
+func NewParticipant(suite crypto.Suite, index uint16, threshold uint16, numParticipants uint16) (*participant, error) {
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+	if index == 0 || index > numParticipants {
+		return nil, crypto.ErrIndexOutOfBounds
+	}
+	if threshold == 0 || threshold > numParticipants {
+		return nil, crypto.ErrInvalidThreshold
+	}
+
+	return &participant{
+		suite:           suite,
+		index:           index,
+		threshold:       threshold,
+		numParticipants: numParticipants,
+		phase:           phaseDeal,
+		deals:           make(map[uint16][]crypto.Point),
+		dealsBytes:      make(map[uint16][][]byte),
+		shares:          make(map[uint16]crypto.Scalar),
+		dealtShares:     make(map[uint16]crypto.Scalar),
+		complaints:      make(map[uint16]map[uint16]struct{}),
+		justifications:  make(map[uint16]map[uint16]crypto.Scalar),
+		disqualified:    make(map[uint16]struct{}),
+	}, nil
+}
+
+// Deal creates the participant secret polynomial and returns the deal to be broadcast together with the shares
+// to be sent privately to the other participants. The participant own share is kept internally
+func (p *participant) Deal() (*Deal, []*Share, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.phase != phaseDeal {
+		return nil, nil, crypto.ErrInvalidDKGPhase
+	}
+
+	coefficients := make([]crypto.Scalar, p.threshold)
+	commitments := make([]crypto.Point, p.threshold)
+	commitmentsBytes := make([][]byte, p.threshold)
+	for k := range coefficients {
+		coefficient, err := p.suite.CreateScalar().Pick()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		commitment, err := p.suite.CreatePointForScalar(coefficient)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		commitmentsBytes[k], err = commitment.MarshalBinary()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		coefficients[k] = coefficient
+		commitments[k] = commitment
+	}
+
+	shares := make([]*Share, 0, p.numParticipants-1)
+	for recipient := uint16(1); recipient <= p.numParticipants; recipient++ {
+		shareValue, err := multisig.EvaluatePolynomial(coefficients, recipient)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		p.dealtShares[recipient] = shareValue
+		if recipient == p.index {
+			p.shares[p.index] = shareValue
+			continue
+		}
+
+		shareBytes, err := shareValue.MarshalBinary()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		shares = append(shares, &Share{
+			Dealer:    p.index,
+			Recipient: recipient,
+			Value:     shareBytes,
+		})
+	}
+
+	p.deals[p.index] = commitments
+	p.dealsBytes[p.index] = commitmentsBytes
+	p.phase = phaseShares
+
+	deal := &Deal{
+		Dealer:      p.index,
+		Commitments: commitmentsBytes,
+	}
+
+	return deal, shares, nil
+}
+
+// ProcessDeal stores the commitments broadcast by another dealer. Deals can be processed before or after the
+// participant created its own deal. A dealer that broadcasts a malformed deal or two different deals is disqualified
+func (p *participant) ProcessDeal(deal *Deal) error {
+	if deal == nil {
+		return crypto.ErrNilParam
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if !p.isDealing() {
+		return crypto.ErrInvalidDKGPhase
+	}
+	if !p.isValidIndex(deal.Dealer) || deal.Dealer == p.index {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	previousDeal, exists := p.dealsBytes[deal.Dealer]
+	if exists {
+		if !sameCommitments(previousDeal, deal.Commitments) {
+			p.disqualified[deal.Dealer] = struct{}{}
+			return crypto.ErrInvalidDeal
+		}
+
+		return nil
+	}
+
+	commitments, err := p.commitmentsFromBytes(deal.Commitments)
+	if err != nil {
+		p.disqualified[deal.Dealer] = struct{}{}
+		return err
+	}
+
+	p.deals[deal.Dealer] = commitments
+	p.dealsBytes[deal.Dealer] = deal.Commitments
+
+	return nil
+}
+
+// ProcessShare stores the share privately received from a dealer. The share is checked against the dealer commitments
+// only when the complaints are created, so deals and shares can be processed in any order. A dealer can send only one
+// share to each recipient
+func (p *participant) ProcessShare(share *Share) error {
+	if share == nil {
+		return crypto.ErrNilParam
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if !p.isDealing() {
+		return crypto.ErrInvalidDKGPhase
+	}
+	if share.Recipient != p.index {
+		return crypto.ErrInvalidParam
+	}
+	if !p.isValidIndex(share.Dealer) || share.Dealer == p.index {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	_, exists := p.shares[share.Dealer]
+	if exists {
+		return crypto.ErrDuplicateShare
+	}
+
+	shareValue := p.suite.CreateScalar()
+	err := shareValue.UnmarshalBinary(share.Value)
+	if err != nil {
+		return crypto.ErrInvalidShare
+	}
+
+	p.shares[share.Dealer] = shareValue
+
+	return nil
+}
+
+// Complaints ends the deal phase and returns the complaints to be broadcast against the dealers that sent
+// a missing or invalid share. Dealers that did not broadcast a deal at all are disqualified without complaints
+func (p *participant) Complaints() ([]*Complaint, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.phase != phaseShares {
+		return nil, crypto.ErrInvalidDKGPhase
+	}
+
+	complaints := make([]*Complaint, 0)
+	for dealer := uint16(1); dealer <= p.numParticipants; dealer++ {
+		if dealer == p.index {
+			continue
+		}
+
+		commitments, hasDeal := p.deals[dealer]
+		if !hasDeal {
+			p.disqualified[dealer] = struct{}{}
+			continue
+		}
+
+		if p.isValidShare(commitments, p.shares[dealer], p.index) {
+			continue
+		}
+
+		delete(p.shares, dealer)
+		p.addComplaint(dealer, p.index)
+		complaints = append(complaints, &Complaint{
+			Complainer: p.index,
+			Dealer:     dealer,
+		})
+	}
+
+	p.phase = phaseComplaints
+
+	return complaints, nil
+}
+
+// ProcessComplaint records a complaint broadcast by another participant. If the complaint is against this participant,
+// the justification that has to be broadcast is returned, otherwise the returned justification is nil
+func (p *participant) ProcessComplaint(complaint *Complaint) (*Justification, error) {
+	if complaint == nil {
+		return nil, crypto.ErrNilParam
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.phase != phaseComplaints {
+		return nil, crypto.ErrInvalidDKGPhase
+	}
+	if !p.isValidIndex(complaint.Dealer) || !p.isValidIndex(complaint.Complainer) {
+		return nil, crypto.ErrIndexOutOfBounds
+	}
+	if complaint.Dealer == complaint.Complainer {
+		return nil, crypto.ErrInvalidParam
+	}
+
+	p.addComplaint(complaint.Dealer, complaint.Complainer)
+	if complaint.Dealer != p.index {
+		return nil, nil
+	}
+
+	shareValue, exists := p.dealtShares[complaint.Complainer]
+	if !exists {
+		return nil, crypto.ErrInvalidShare
+	}
+
+	shareBytes, err := shareValue.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Justification{
+		Dealer:     p.index,
+		Complainer: complaint.Complainer,
+		Value:      shareBytes,
+	}, nil
+}
+
+// ProcessJustification records a justification broadcast by an accused dealer
+func (p *participant) ProcessJustification(justification *Justification) error {
+	if justification == nil {
+		return crypto.ErrNilParam
+	}
+
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.phase != phaseComplaints {
+		return crypto.ErrInvalidDKGPhase
+	}
+	if !p.isValidIndex(justification.Dealer) || !p.isValidIndex(justification.Complainer) {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	shareValue := p.suite.CreateScalar()
+	err := shareValue.UnmarshalBinary(justification.Value)
+	if err != nil {
+		return crypto.ErrInvalidShare
+	}
+
+	dealerJustifications, exists := p.justifications[justification.Dealer]
+	if !exists {
+		dealerJustifications = make(map[uint16]crypto.Scalar)
+		p.justifications[justification.Dealer] = dealerJustifications
+	}
+	dealerJustifications[justification.Complainer] = shareValue
+
+	return nil
+}
+
+// Finalize ends the protocol, disqualifying the dealers that did not correctly justify all the complaints against them,
+// and returns the participant share of the group key
+func (p *participant) Finalize() (*DistKeyShare, error) {
+	p.mut.Lock()
+	defer p.mut.Unlock()
+
+	if p.phase != phaseComplaints {
+		return nil, crypto.ErrInvalidDKGPhase
+	}
+
+	for dealer, complainers := range p.complaints {
+		for complainer := range complainers {
+			justifiedShare, isJustified := p.justifications[dealer][complainer]
+			if !isJustified || !p.isValidShare(p.deals[dealer], justifiedShare, complainer) {
+				p.disqualified[dealer] = struct{}{}
+				break
+			}
+
+			if complainer == p.index {
+				p.shares[dealer] = justifiedShare
+			}
+		}
+	}
+
+	qualified := make([]uint16, 0, p.numParticipants)
+	for dealer := range p.deals {
+		_, isDisqualified := p.disqualified[dealer]
+		if !isDisqualified {
+			qualified = append(qualified, dealer)
+		}
+	}
+	if len(qualified) < int(p.threshold) {
+		return nil, crypto.ErrNotEnoughQualifiedDealers
+	}
+	sort.Slice(qualified, func(i, j int) bool {
+		return qualified[i] < qualified[j]
+	})
+
+	var err error
+	share := p.suite.CreateScalar().Zero()
+	groupCommitments := make([]crypto.Point, p.threshold)
+	for k := range groupCommitments {
+		groupCommitments[k] = p.suite.CreatePoint().Null()
+	}
+
+	for _, dealer := range qualified {
+		share, err = share.Add(p.shares[dealer])
+		if err != nil {
+			return nil, err
+		}
+
+		for k, commitment := range p.deals[dealer] {
+			groupCommitments[k], err = groupCommitments[k].Add(commitment)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	p.phase = phaseFinalized
+
+	return &DistKeyShare{
+		Index:       p.index,
+		Share:       share,
+		Commitments: groupCommitments,
+		Qualified:   qualified,
+	}, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (p *participant) IsInterfaceNil() bool {
+	return p == nil
+}
+
+// isDealing returns true while deals and shares can be received, that is before the complaints are created
+func (p *participant) isDealing() bool {
+	return p.phase == phaseDeal || p.phase == phaseShares
+}
+
+func (p *participant) isValidIndex(index uint16) bool {
+	return index > 0 && index <= p.numParticipants
+}
+
+func (p *participant) addComplaint(dealer uint16, complainer uint16) {
+	complainers, exists := p.complaints[dealer]
+	if !exists {
+		complainers = make(map[uint16]struct{})
+		p.complaints[dealer] = complainers
+	}
+
+	complainers[complainer] = struct{}{}
+}
+
+// isValidShare checks the Feldman relation share*G2 == sum(C_k * x^k)
+func (p *participant) isValidShare(commitments []crypto.Point, share crypto.Scalar, x uint16) bool {
+	if len(commitments) == 0 || check.IfNil(share) {
+		return false
+	}
+
+	expected, err := evaluateCommitments(commitments, share, x)
+	if err != nil {
+		return false
+	}
+
+	sharePoint, err := p.suite.CreatePointForScalar(share)
+	if err != nil {
+		return false
+	}
+
+	isValid, err := sharePoint.Equal(expected)
+
+	return err == nil && isValid
+}
+
+func (p *participant) commitmentsFromBytes(commitmentsBytes [][]byte) ([]crypto.Point, error) {
+	if len(commitmentsBytes) != int(p.threshold) {
+		return nil, crypto.ErrInvalidDeal
+	}
+
+	commitments := make([]crypto.Point, len(commitmentsBytes))
+	for k, commitmentBytes := range commitmentsBytes {
+		err := p.suite.CheckPointValid(commitmentBytes)
+		if err != nil {
+			return nil, crypto.ErrInvalidDeal
+		}
+
+		commitment := p.suite.CreatePoint()
+		err = commitment.UnmarshalBinary(commitmentBytes)
+		if err != nil {
+			return nil, crypto.ErrInvalidDeal
+		}
+
+		commitments[k] = commitment
+	}
+
+	return commitments, nil
+}
+
+func sameCommitments(first [][]byte, second [][]byte) bool {
+	if len(first) != len(second) {
+		return false
+	}
+
+	for i := range first {
+		if !bytes.Equal(first[i], second[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// evaluateCommitments evaluates with Horner's method the committed polynomial in the exponent in x.
+// The scalar is only used as a template for creating the scalar x
+func evaluateCommitments(commitments []crypto.Point, scalar crypto.Scalar, x uint16) (crypto.Point, error) {
+	xScalar := scalar.Clone()
+	xScalar.SetInt64(int64(x))
+
+	var err error
+	result := commitments[len(commitments)-1].Clone()
+	for k := len(commitments) - 2; k >= 0; k-- {
+		result, err = result.Mul(xScalar)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = result.Add(commitments[k])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,374 @@
+package dkg_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/dkg"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type participantHandler interface {
+	Deal() (*dkg.Deal, []*dkg.Share, error)
+	ProcessDeal(deal *dkg.Deal) error
+	ProcessShare(share *dkg.Share) error
+	Complaints() ([]*dkg.Complaint, error)
+	ProcessComplaint(complaint *dkg.Complaint) (*dkg.Justification, error)
+	ProcessJustification(justification *dkg.Justification) error
+	Finalize() (*dkg.DistKeyShare, error)
+}
+
+// network simulates the transport layer, allowing messages to be altered or dropped
+type network struct {
+	skipDealers       map[uint16]bool
+	alterShare        func(share *dkg.Share)
+	dropJustification func(justification *dkg.Justification) bool
+}
+
+// transfer simulates sending a message over the wire
+func transfer(t *testing.T, in interface{}, out interface{}) {
+	buff, err := json.Marshal(in)
+	require.Nil(t, err)
+	err = json.Unmarshal(buff, out)
+	require.Nil(t, err)
+}
+
+func createParticipants(t *testing.T, threshold uint16, numParticipants uint16) []participantHandler {
+	suite := mcl.NewSuiteBLS12()
+	participants := make([]participantHandler, numParticipants)
+	for i := range participants {
+		p, err := dkg.NewParticipant(suite, uint16(i+1), threshold, numParticipants)
+		require.Nil(t, err)
+		participants[i] = p
+	}
+
+	return participants
+}
+
+func runDKG(t *testing.T, threshold uint16, numParticipants uint16, net *network) []*dkg.DistKeyShare {
+	participants := createParticipants(t, threshold, numParticipants)
+
+	deals := make([]*dkg.Deal, 0, numParticipants)
+	shares := make([]*dkg.Share, 0)
+	for i, p := range participants {
+		deal, dealtShares, err := p.Deal()
+		require.Nil(t, err)
+		if net.skipDealers[uint16(i+1)] {
+			continue
+		}
+
+		deals = append(deals, deal)
+		shares = append(shares, dealtShares...)
+	}
+
+	for _, deal := range deals {
+		for i, p := range participants {
+			if uint16(i+1) == deal.Dealer {
+				continue
+			}
+
+			received := &dkg.Deal{}
+			transfer(t, deal, received)
+			require.Nil(t, p.ProcessDeal(received))
+		}
+	}
+
+	for _, share := range shares {
+		received := &dkg.Share{}
+		transfer(t, share, received)
+		if net.alterShare != nil {
+			net.alterShare(received)
+		}
+		require.Nil(t, participants[share.Recipient-1].ProcessShare(received))
+	}
+
+	complaints := make([]*dkg.Complaint, 0)
+	for _, p := range participants {
+		participantComplaints, err := p.Complaints()
+		require.Nil(t, err)
+		complaints = append(complaints, participantComplaints...)
+	}
+
+	justifications := make([]*dkg.Justification, 0)
+	for _, complaint := range complaints {
+		for _, p := range participants {
+			received := &dkg.Complaint{}
+			transfer(t, complaint, received)
+			justification, err := p.ProcessComplaint(received)
+			require.Nil(t, err)
+			if justification == nil {
+				continue
+			}
+			if net.dropJustification != nil && net.dropJustification(justification) {
+				continue
+			}
+
+			justifications = append(justifications, justification)
+		}
+	}
+
+	for _, justification := range justifications {
+		for _, p := range participants {
+			received := &dkg.Justification{}
+			transfer(t, justification, received)
+			require.Nil(t, p.ProcessJustification(received))
+		}
+	}
+
+	keyShares := make([]*dkg.DistKeyShare, numParticipants)
+	for i, p := range participants {
+		keyShare, err := p.Finalize()
+		require.Nil(t, err)
+		keyShares[i] = keyShare
+	}
+
+	return keyShares
+}
+
+func requireConsistentKeyShares(t *testing.T, keyShares []*dkg.DistKeyShare, expectedQualified []uint16) {
+	for _, keyShare := range keyShares {
+		require.Equal(t, expectedQualified, keyShare.Qualified)
+
+		equal, err := keyShare.GroupPublicKey().Equal(keyShares[0].GroupPublicKey())
+		require.Nil(t, err)
+		require.True(t, equal)
+
+		// the share must match the public share computed by any other participant
+		for _, other := range keyShares {
+			pubKeyShare, errShare := other.PublicKeyShare(keyShare.Index)
+			require.Nil(t, errShare)
+
+			expected, _ := mcl.NewSuiteBLS12().CreatePointForScalar(keyShare.Share)
+			equal, _ = pubKeyShare.Equal(expected)
+			require.True(t, equal)
+		}
+	}
+}
+
+func requireThresholdSignatureWorks(t *testing.T, keyShares []*dkg.DistKeyShare, threshold uint16, signers []int) {
+	suite := mcl.NewSuiteBLS12()
+	kg := signing.NewKeyGenerator(suite)
+	msg := []byte("message signed with the distributed key")
+
+	sharePubKeys := make([]crypto.PublicKey, len(keyShares))
+	for i := range keyShares {
+		point, err := keyShares[0].PublicKeyShare(uint16(i + 1))
+		require.Nil(t, err)
+		pointBytes, _ := point.MarshalBinary()
+		sharePubKeys[i], err = kg.PublicKeyFromByteArray(pointBytes)
+		require.Nil(t, err)
+	}
+
+	signer, err := multisig.NewBlsThresholdSigner(threshold, sharePubKeys)
+	require.Nil(t, err)
+
+	sigs := make([][]byte, 0, len(signers))
+	pubKeys := make([]crypto.PublicKey, 0, len(signers))
+	for _, idx := range signers {
+		shareBytes, _ := keyShares[idx].Share.MarshalBinary()
+		privKey, errKey := kg.PrivateKeyFromByteArray(shareBytes)
+		require.Nil(t, errKey)
+
+		sig, errSig := signer.SignShare(privKey, msg)
+		require.Nil(t, errSig)
+		sigs = append(sigs, sig)
+		pubKeys = append(pubKeys, sharePubKeys[idx])
+	}
+
+	aggSig, err := signer.AggregateSignatures(suite, sigs, pubKeys)
+	require.Nil(t, err)
+
+	groupPubKeyBytes, _ := keyShares[0].GroupPublicKey().MarshalBinary()
+	groupPubKey, err := kg.PublicKeyFromByteArray(groupPubKeyBytes)
+	require.Nil(t, err)
+
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey}, aggSig, msg)
+	require.Nil(t, err)
+}
+
+func TestNewParticipant_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+
+	p, err := dkg.NewParticipant(nil, 1, 2, 3)
+	assert.True(t, check.IfNil(p))
+	assert.Equal(t, crypto.ErrNilSuite, err)
+
+	p, err = dkg.NewParticipant(suite, 0, 2, 3)
+	assert.True(t, check.IfNil(p))
+	assert.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	p, err = dkg.NewParticipant(suite, 4, 2, 3)
+	assert.True(t, check.IfNil(p))
+	assert.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	p, err = dkg.NewParticipant(suite, 1, 0, 3)
+	assert.True(t, check.IfNil(p))
+	assert.Equal(t, crypto.ErrInvalidThreshold, err)
+
+	p, err = dkg.NewParticipant(suite, 1, 4, 3)
+	assert.True(t, check.IfNil(p))
+	assert.Equal(t, crypto.ErrInvalidThreshold, err)
+
+	p, err = dkg.NewParticipant(suite, 1, 2, 3)
+	assert.False(t, check.IfNil(p))
+	assert.Nil(t, err)
+}
+
+func TestParticipant_HonestRunShouldProduceConsistentShares(t *testing.T) {
+	t.Parallel()
+
+	keyShares := runDKG(t, 3, 5, &network{})
+
+	requireConsistentKeyShares(t, keyShares, []uint16{1, 2, 3, 4, 5})
+	requireThresholdSignatureWorks(t, keyShares, 3, []int{0, 2, 4})
+	requireThresholdSignatureWorks(t, keyShares, 3, []int{3, 1, 0})
+}
+
+func TestParticipant_JustifiedComplaintShouldKeepDealer(t *testing.T) {
+	t.Parallel()
+
+	net := &network{
+		alterShare: func(share *dkg.Share) {
+			if share.Dealer == 2 && share.Recipient == 3 {
+				share.Value = mcl.NewScalar().Scalar.Serialize()
+			}
+		},
+	}
+	keyShares := runDKG(t, 3, 5, net)
+
+	requireConsistentKeyShares(t, keyShares, []uint16{1, 2, 3, 4, 5})
+	requireThresholdSignatureWorks(t, keyShares, 3, []int{2, 3, 4})
+}
+
+func TestParticipant_UnjustifiedComplaintShouldDisqualifyDealer(t *testing.T) {
+	t.Parallel()
+
+	net := &network{
+		alterShare: func(share *dkg.Share) {
+			if share.Dealer == 2 && share.Recipient == 3 {
+				share.Value = mcl.NewScalar().Scalar.Serialize()
+			}
+		},
+		dropJustification: func(justification *dkg.Justification) bool {
+			return justification.Dealer == 2
+		},
+	}
+	keyShares := runDKG(t, 3, 5, net)
+
+	requireConsistentKeyShares(t, keyShares, []uint16{1, 3, 4, 5})
+	requireThresholdSignatureWorks(t, keyShares, 3, []int{1, 2, 4})
+}
+
+func TestParticipant_MissingDealShouldDisqualifyDealer(t *testing.T) {
+	t.Parallel()
+
+	net := &network{
+		skipDealers: map[uint16]bool{4: true},
+	}
+	keyShares := runDKG(t, 2, 4, net)
+
+	for _, keyShare := range keyShares[:3] {
+		assert.Equal(t, []uint16{1, 2, 3}, keyShare.Qualified)
+	}
+	requireThresholdSignatureWorks(t, keyShares[:3], 2, []int{0, 2})
+}
+
+func TestParticipant_PhaseOrderShouldBeEnforced(t *testing.T) {
+	t.Parallel()
+
+	participants := createParticipants(t, 2, 3)
+	p := participants[0]
+
+	_, err := p.Complaints()
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+	_, err = p.Finalize()
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+
+	_, _, err = p.Deal()
+	assert.Nil(t, err)
+	_, _, err = p.Deal()
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+	_, err = p.ProcessComplaint(&dkg.Complaint{Complainer: 2, Dealer: 1})
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+
+	_, err = p.Complaints()
+	assert.Nil(t, err)
+	err = p.ProcessShare(&dkg.Share{Dealer: 2, Recipient: 1})
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+	err = p.ProcessDeal(&dkg.Deal{Dealer: 2})
+	assert.Equal(t, crypto.ErrInvalidDKGPhase, err)
+}
+
+func TestParticipant_DealsReceivedBeforeOwnDealShouldBeAccepted(t *testing.T) {
+	t.Parallel()
+
+	participants := createParticipants(t, 2, 3)
+	deals := make([]*dkg.Deal, len(participants))
+	shares := make([][]*dkg.Share, len(participants))
+
+	// the first participant receives the deals and shares of the others before dealing itself
+	for i := len(participants) - 1; i >= 0; i-- {
+		var err error
+		deals[i], shares[i], err = participants[i].Deal()
+		require.Nil(t, err)
+
+		for j, p := range participants {
+			if j == i {
+				continue
+			}
+
+			require.Nil(t, p.ProcessDeal(deals[i]))
+		}
+		for _, share := range shares[i] {
+			require.Nil(t, participants[share.Recipient-1].ProcessShare(share))
+		}
+	}
+
+	keyShares := make([]*dkg.DistKeyShare, len(participants))
+	for i, p := range participants {
+		complaints, err := p.Complaints()
+		require.Nil(t, err)
+		require.Empty(t, complaints)
+
+		keyShares[i], err = p.Finalize()
+		require.Nil(t, err)
+	}
+
+	requireConsistentKeyShares(t, keyShares, []uint16{1, 2, 3})
+	requireThresholdSignatureWorks(t, keyShares, 2, []int{0, 2})
+}
+
+func TestParticipant_InvalidMessagesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	participants := createParticipants(t, 2, 3)
+	deal, shares, _ := participants[1].Deal()
+	p := participants[0]
+	_, _, _ = p.Deal()
+
+	assert.Equal(t, crypto.ErrNilParam, p.ProcessDeal(nil))
+	assert.Equal(t, crypto.ErrIndexOutOfBounds, p.ProcessDeal(&dkg.Deal{Dealer: 1}))
+	assert.Equal(t, crypto.ErrIndexOutOfBounds, p.ProcessDeal(&dkg.Deal{Dealer: 4}))
+	assert.Equal(t, crypto.ErrInvalidDeal, p.ProcessDeal(&dkg.Deal{Dealer: 3, Commitments: deal.Commitments[:1]}))
+	assert.Equal(t, crypto.ErrNilParam, p.ProcessShare(nil))
+	assert.Equal(t, crypto.ErrInvalidParam, p.ProcessShare(&dkg.Share{Dealer: 2, Recipient: 3}))
+	assert.Equal(t, crypto.ErrInvalidShare, p.ProcessShare(&dkg.Share{Dealer: 2, Recipient: 1, Value: []byte("invalid")}))
+
+	assert.Nil(t, p.ProcessDeal(deal))
+	assert.Nil(t, p.ProcessDeal(deal))
+	conflictingDeal := &dkg.Deal{
+		Dealer:      deal.Dealer,
+		Commitments: [][]byte{deal.Commitments[1], deal.Commitments[0]},
+	}
+	assert.Equal(t, crypto.ErrInvalidDeal, p.ProcessDeal(conflictingDeal))
+	assert.Nil(t, p.ProcessShare(shares[0]))
+	assert.Equal(t, crypto.ErrDuplicateShare, p.ProcessShare(shares[0]))
+}
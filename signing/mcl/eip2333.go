@@ -0,0 +1,170 @@
+package mcl
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// MinSeedLen is the minimum length of the seed the EIP-2333 master key is derived from
+	MinSeedLen = 32
+
+	eip2333Salt        = "BLS-SIG-KEYGEN-SALT-"
+	eip2333OKMLen      = 48
+	lamportChunkLen    = 32
+	lamportNumChunks   = 255
+	scalarLen          = 32
+	derivationPathRoot = "m"
+)
+
+// groupOrder is the order r of the BLS12-381 groups, the private keys being scalars modulo r
+var groupOrder, _ = new(big.Int).SetString("73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001", 16)
+
+// DeriveMasterSK derives the EIP-2333 master private key from the seed, which needs at least MinSeedLen bytes
+func DeriveMasterSK(seed []byte) (*Scalar, error) {
+	if len(seed) < MinSeedLen {
+		return nil, fmt.Errorf("%w: seed shorter than %d bytes", crypto.ErrInvalidSeed, MinSeedLen)
+	}
+
+	return hkdfModR(seed)
+}
+
+// DeriveChildSK derives the EIP-2333 child private key with the given index from the parent private key, going
+// through the lamport public key of the parent so the parent can not be recovered from its children
+func DeriveChildSK(parentSK *Scalar, index uint32) (*Scalar, error) {
+	if parentSK == nil || parentSK.Scalar == nil {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+
+	return hkdfModR(parentSKToLamportPK(parentSK, index))
+}
+
+// DeriveSKFromPath derives the private key of the EIP-2334 derivation path from the seed, such as m/12381/3600/0/0/0
+// for the signing key of the first validator
+func DeriveSKFromPath(seed []byte, path string) (*Scalar, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	sk, err := DeriveMasterSK(seed)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range indexes {
+		sk, err = DeriveChildSK(sk, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return sk, nil
+}
+
+// ParseDerivationPath parses an EIP-2334 derivation path into the indexes of the children, the path starting with the
+// master node m followed by decimal indexes below 2^32 separated by slashes
+func ParseDerivationPath(path string) ([]uint32, error) {
+	nodes := strings.Split(strings.TrimSpace(path), "/")
+	if nodes[0] != derivationPathRoot {
+		return nil, fmt.Errorf("%w: %s does not start with %s", crypto.ErrInvalidDerivationPath, path, derivationPathRoot)
+	}
+
+	indexes := make([]uint32, 0, len(nodes)-1)
+	for _, node := range nodes[1:] {
+		index, err := strconv.ParseUint(node, 10, 32)
+		if err != nil || node != strconv.FormatUint(index, 10) {
+			return nil, fmt.Errorf("%w: invalid index %s in %s", crypto.ErrInvalidDerivationPath, node, path)
+		}
+
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+// hkdfModR derives a non-zero scalar from the input keying material, hashing the salt again while the result is zero
+func hkdfModR(ikm []byte) (*Scalar, error) {
+	salt := []byte(eip2333Salt)
+	// IKM || I2OSP(0, 1)
+	extendedIKM := append(append(make([]byte, 0, len(ikm)+1), ikm...), 0)
+	// key_info || I2OSP(L, 2), with an empty key_info
+	info := []byte{0, eip2333OKMLen}
+
+	sk := new(big.Int)
+	for sk.Sign() == 0 {
+		saltHash := sha256.Sum256(salt)
+		salt = saltHash[:]
+
+		okm := make([]byte, eip2333OKMLen)
+		_, err := io.ReadFull(hkdf.New(sha256.New, extendedIKM, salt, info), okm)
+		if err != nil {
+			return nil, err
+		}
+
+		sk.SetBytes(okm)
+		sk.Mod(sk, groupOrder)
+	}
+
+	return scalarFromBigInt(sk)
+}
+
+func parentSKToLamportPK(parentSK *Scalar, index uint32) []byte {
+	salt := make([]byte, 4)
+	binary.BigEndian.PutUint32(salt, index)
+
+	ikm := scalarToBigEndian(parentSK)
+	notIKM := make([]byte, len(ikm))
+	for i := range ikm {
+		notIKM[i] = ^ikm[i]
+	}
+
+	hasher := sha256.New()
+	for _, lamportIKM := range [][]byte{ikm, notIKM} {
+		lamportSK := ikmToLamportSK(lamportIKM, salt)
+		for i := 0; i < lamportNumChunks; i++ {
+			chunkHash := sha256.Sum256(lamportSK[i*lamportChunkLen : (i+1)*lamportChunkLen])
+			hasher.Write(chunkHash[:])
+		}
+	}
+
+	return hasher.Sum(nil)
+}
+
+func ikmToLamportSK(ikm []byte, salt []byte) []byte {
+	okm := make([]byte, lamportChunkLen*lamportNumChunks)
+	// the output length is within the HKDF limit of 255 hashes, so the read can not fail
+	_, _ = io.ReadFull(hkdf.New(sha256.New, ikm, salt, nil), okm)
+
+	return okm
+}
+
+// scalarToBigEndian returns the 32 bytes big endian serialization of the scalar, as mcl serializes them little endian
+func scalarToBigEndian(sc *Scalar) []byte {
+	littleEndian := sc.Scalar.Serialize()
+	bigEndian := make([]byte, scalarLen)
+	for i := 0; i < len(littleEndian) && i < scalarLen; i++ {
+		bigEndian[scalarLen-1-i] = littleEndian[i]
+	}
+
+	return bigEndian
+}
+
+func scalarFromBigInt(value *big.Int) (*Scalar, error) {
+	sc := &Scalar{Scalar: &bls.Fr{}}
+	err := sc.Scalar.SetString(value.Text(10), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
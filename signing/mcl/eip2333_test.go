@@ -0,0 +1,145 @@
+package mcl
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from EIP-2333
+var eip2333TestVectors = []struct {
+	seed       string
+	masterSK   string
+	childIndex uint32
+	childSK    string
+}{
+	{
+		seed:       "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		masterSK:   "6083874454709270928345386274498605044986640685124978867557563392430687146096",
+		childIndex: 0,
+		childSK:    "20397789859736650942317412262472558107875392172444076792671091975210932703118",
+	},
+	{
+		seed:       "3141592653589793238462643383279502884197169399375105820974944592",
+		masterSK:   "29757020647961307431480504535336562678282505419141012933316116377660817309383",
+		childIndex: 3141592653,
+		childSK:    "25457201688850691947727629385191704516744796114925897962676248250929345014287",
+	},
+	{
+		seed:       "0099FF991111002299DD7744EE3355BBDD8844115566CC55663355668888CC00",
+		masterSK:   "27580842291869792442942448775674722299803720648445448686099262467207037398656",
+		childIndex: 4294967295,
+		childSK:    "29358610794459428860402234341874281240803786294062035874021252734817515685787",
+	},
+	{
+		seed:       "d4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3",
+		masterSK:   "19022158461524446591288038168518313374041767046816487870552872741050760015818",
+		childIndex: 42,
+		childSK:    "31372231650479070279774297061823572166496564838472787488249775572789064611981",
+	},
+}
+
+func TestDeriveMasterSKAndChildSK_TestVectors(t *testing.T) {
+	t.Parallel()
+
+	for _, vector := range eip2333TestVectors {
+		seed, _ := hex.DecodeString(vector.seed)
+
+		masterSK, err := DeriveMasterSK(seed)
+		require.Nil(t, err)
+		require.Equal(t, vector.masterSK, masterSK.Scalar.GetString(10))
+
+		childSK, err := DeriveChildSK(masterSK, vector.childIndex)
+		require.Nil(t, err)
+		require.Equal(t, vector.childSK, childSK.Scalar.GetString(10))
+	}
+}
+
+func TestDeriveMasterSK_ShortSeedShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sk, err := DeriveMasterSK(make([]byte, MinSeedLen-1))
+	require.Nil(t, sk)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSeed))
+}
+
+func TestDeriveChildSK_NilParentShouldErr(t *testing.T) {
+	t.Parallel()
+
+	sk, err := DeriveChildSK(nil, 0)
+	require.Nil(t, sk)
+	require.Equal(t, crypto.ErrNilPrivateKeyScalar, err)
+
+	sk, err = DeriveChildSK(&Scalar{}, 0)
+	require.Nil(t, sk)
+	require.Equal(t, crypto.ErrNilPrivateKeyScalar, err)
+}
+
+func TestDeriveSKFromPath(t *testing.T) {
+	t.Parallel()
+
+	vector := eip2333TestVectors[0]
+	seed, _ := hex.DecodeString(vector.seed)
+
+	masterSK, err := DeriveSKFromPath(seed, "m")
+	require.Nil(t, err)
+	require.Equal(t, vector.masterSK, masterSK.Scalar.GetString(10))
+
+	childSK, err := DeriveSKFromPath(seed, "m/0")
+	require.Nil(t, err)
+	require.Equal(t, vector.childSK, childSK.Scalar.GetString(10))
+
+	// each node of the path derives a child of the previous one
+	expectedSK := masterSK
+	for _, index := range []uint32{12381, 3600, 5, 0, 0} {
+		expectedSK, err = DeriveChildSK(expectedSK, index)
+		require.Nil(t, err)
+	}
+	validatorSK, err := DeriveSKFromPath(seed, "m/12381/3600/5/0/0")
+	require.Nil(t, err)
+	require.True(t, validatorSK.Scalar.IsEqual(expectedSK.Scalar))
+
+	_, err = DeriveSKFromPath(seed, "m/12381/3600/a/0/0")
+	require.True(t, errors.Is(err, crypto.ErrInvalidDerivationPath))
+
+	_, err = DeriveSKFromPath(seed[:MinSeedLen-1], "m/0")
+	require.True(t, errors.Is(err, crypto.ErrInvalidSeed))
+}
+
+func TestParseDerivationPath(t *testing.T) {
+	t.Parallel()
+
+	indexes, err := ParseDerivationPath("m")
+	require.Nil(t, err)
+	require.Empty(t, indexes)
+
+	indexes, err = ParseDerivationPath("m/12381/3600/7/0/0")
+	require.Nil(t, err)
+	require.Equal(t, []uint32{12381, 3600, 7, 0, 0}, indexes)
+
+	indexes, err = ParseDerivationPath("m/4294967295")
+	require.Nil(t, err)
+	require.Equal(t, []uint32{4294967295}, indexes)
+
+	invalidPaths := []string{
+		"",
+		"12381/3600/0/0/0",
+		"M/12381",
+		"m/",
+		"m//0",
+		"m/12381/",
+		"m/-1",
+		"m/+1",
+		"m/01",
+		"m/4294967296",
+		"m/0'",
+		"m/0x10",
+	}
+	for _, path := range invalidPaths {
+		_, err = ParseDerivationPath(path)
+		require.True(t, errors.Is(err, crypto.ErrInvalidDerivationPath), path)
+	}
+}
@@ -0,0 +1,276 @@
+package mcl
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+const (
+	// hashToFieldLen is the number of bytes L used to derive a field element, ceil((ceil(log2(p)) + k) / 8) with k = 128
+	hashToFieldLen = 64
+	// maxDSTLen is the maximum length of a domain separation tag, longer tags are hashed first
+	maxDSTLen = 255
+	// maxExpandLen is the maximum length of the output of expand_message_xmd with SHA-256
+	maxExpandLen = 255 * sha256.Size
+	// clearCofactorG1 is the effective cofactor h_eff used to clear the cofactor of points on E1
+	clearCofactorG1 = uint64(0xd201000000010001)
+)
+
+const oversizeDSTPrefix = "H2C-OVERSIZE-DST-"
+
+type sswuParams struct {
+	a       bls.Fp
+	b       bls.Fp
+	z       bls.Fp
+	bOverZA bls.Fp
+	minusBA bls.Fp
+	xNum    []bls.Fp
+	xDen    []bls.Fp
+	yNum    []bls.Fp
+	yDen    []bls.Fp
+}
+
+var (
+	sswuG1       *sswuParams
+	initSswuOnce sync.Once
+)
+
+/*
+HashToG1 hashes the message to a point on G1 with the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite defined in RFC 9380:
+
+	u0, u1 = hash_to_field(msg, 2)
+	Q0, Q1 = iso_map(map_to_curve_simple_swu(u0)), iso_map(map_to_curve_simple_swu(u1))
+	P = clear_cofactor(Q0 + Q1)
+
+Unlike the hashing used by the mcl library, which depends on the global library configuration, the domain
+separation tag is explicit so the result is interoperable with other implementations of the standard
+*/
+func HashToG1(msg []byte, dst []byte) (*PointG1, error) {
+	initSswuOnce.Do(initSswuParams)
+
+	uniformBytes, err := ExpandMessageXMD(msg, dst, 2*hashToFieldLen)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := &bls.G1{}
+	sum.Clear()
+	for i := 0; i < 2; i++ {
+		u := &bls.Fp{}
+		err = u.SetBigEndianMod(uniformBytes[i*hashToFieldLen : (i+1)*hashToFieldLen])
+		if err != nil {
+			return nil, err
+		}
+
+		q := mapToG1(u)
+		bls.G1Add(sum, sum, q)
+	}
+
+	return &PointG1{
+		G1: clearCofactor(sum),
+	}, nil
+}
+
+// ExpandMessageXMD implements expand_message_xmd from RFC 9380 with SHA-256, producing lenInBytes uniformly random
+// bytes from the message and the domain separation tag
+func ExpandMessageXMD(msg []byte, dst []byte, lenInBytes int) ([]byte, error) {
+	if len(dst) == 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+	if lenInBytes <= 0 || lenInBytes > maxExpandLen {
+		return nil, crypto.ErrInvalidParam
+	}
+	if len(dst) > maxDSTLen {
+		hashedDST := sha256.Sum256(append([]byte(oversizeDSTPrefix), dst...))
+		dst = hashedDST[:]
+	}
+
+	dstPrime := append(append(make([]byte, 0, len(dst)+1), dst...), byte(len(dst)))
+	lenInBytesStr := make([]byte, 2)
+	binary.BigEndian.PutUint16(lenInBytesStr, uint16(lenInBytes))
+
+	hasher := sha256.New()
+	hasher.Write(make([]byte, hasher.BlockSize()))
+	hasher.Write(msg)
+	hasher.Write(lenInBytesStr)
+	hasher.Write([]byte{0})
+	hasher.Write(dstPrime)
+	b0 := hasher.Sum(nil)
+
+	hasher.Reset()
+	hasher.Write(b0)
+	hasher.Write([]byte{1})
+	hasher.Write(dstPrime)
+	bi := hasher.Sum(nil)
+
+	uniformBytes := make([]byte, 0, lenInBytes+sha256.Size)
+	uniformBytes = append(uniformBytes, bi...)
+	for i := 2; len(uniformBytes) < lenInBytes; i++ {
+		xored := make([]byte, sha256.Size)
+		for j := range xored {
+			xored[j] = b0[j] ^ bi[j]
+		}
+
+		hasher.Reset()
+		hasher.Write(xored)
+		hasher.Write([]byte{byte(i)})
+		hasher.Write(dstPrime)
+		bi = hasher.Sum(nil)
+		uniformBytes = append(uniformBytes, bi...)
+	}
+
+	return uniformBytes[:lenInBytes], nil
+}
+
+// mapToG1 maps the field element to a point on E1, which is not necessarily in the G1 subgroup
+func mapToG1(u *bls.Fp) *bls.G1 {
+	x, y := mapToCurveSimpleSWU(u)
+
+	return isoMap(x, y)
+}
+
+// mapToCurveSimpleSWU maps the field element to a point on the isogenous curve E'
+func mapToCurveSimpleSWU(u *bls.Fp) (*bls.Fp, *bls.Fp) {
+	params := sswuG1
+
+	// tv1 = 1 / (Z^2 * u^4 + Z * u^2)
+	zu2 := &bls.Fp{}
+	bls.FpSqr(zu2, u)
+	bls.FpMul(zu2, zu2, &params.z)
+	tv1 := &bls.Fp{}
+	bls.FpSqr(tv1, zu2)
+	bls.FpAdd(tv1, tv1, zu2)
+
+	// x1 = (-B / A) * (1 + tv1), or B / (Z * A) in the exceptional case tv1 == 0
+	x1 := &bls.Fp{}
+	if tv1.IsZero() {
+		*x1 = params.bOverZA
+	} else {
+		bls.FpInv(tv1, tv1)
+		one := &bls.Fp{}
+		one.SetInt64(1)
+		bls.FpAdd(x1, tv1, one)
+		bls.FpMul(x1, x1, &params.minusBA)
+	}
+
+	x := x1
+	y := &bls.Fp{}
+	if !bls.FpSquareRoot(y, curveEquation(x1, params)) {
+		// x2 = Z * u^2 * x1, g(x2) is guaranteed to be a square
+		x2 := &bls.Fp{}
+		bls.FpMul(x2, zu2, x1)
+		bls.FpSquareRoot(y, curveEquation(x2, params))
+		x = x2
+	}
+
+	if u.IsOdd() != y.IsOdd() {
+		bls.FpNeg(y, y)
+	}
+
+	return x, y
+}
+
+// curveEquation returns x^3 + A' * x + B'
+func curveEquation(x *bls.Fp, params *sswuParams) *bls.Fp {
+	gx := &bls.Fp{}
+	bls.FpSqr(gx, x)
+	bls.FpAdd(gx, gx, &params.a)
+	bls.FpMul(gx, gx, x)
+	bls.FpAdd(gx, gx, &params.b)
+
+	return gx
+}
+
+// isoMap maps the point from E' to E1 through the 11-isogeny
+func isoMap(xPrime *bls.Fp, yPrime *bls.Fp) *bls.G1 {
+	params := sswuG1
+	xNum := evaluatePolynomial(params.xNum, xPrime, false)
+	xDen := evaluatePolynomial(params.xDen, xPrime, true)
+	yNum := evaluatePolynomial(params.yNum, xPrime, false)
+	yDen := evaluatePolynomial(params.yDen, xPrime, true)
+
+	point := &bls.G1{}
+	if xDen.IsZero() || yDen.IsZero() {
+		point.Clear()
+		return point
+	}
+
+	bls.FpDiv(&point.X, xNum, xDen)
+	bls.FpDiv(&point.Y, yNum, yDen)
+	bls.FpMul(&point.Y, &point.Y, yPrime)
+	point.Z.SetInt64(1)
+
+	return point
+}
+
+// evaluatePolynomial evaluates the polynomial with the given coefficients using Horner's method. A monic polynomial
+// has an implicit leading coefficient equal to 1
+func evaluatePolynomial(coefficients []bls.Fp, x *bls.Fp, monic bool) *bls.Fp {
+	result := &bls.Fp{}
+	if monic {
+		result.SetInt64(1)
+	} else {
+		last := len(coefficients) - 1
+		*result = coefficients[last]
+		coefficients = coefficients[:last]
+	}
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		bls.FpMul(result, result, x)
+		bls.FpAdd(result, result, &coefficients[i])
+	}
+
+	return result
+}
+
+// clearCofactor multiplies the point by the effective cofactor. The multiplication is done with double-and-add,
+// as the optimized scalar multiplication of the library assumes the point is in the G1 subgroup
+func clearCofactor(point *bls.G1) *bls.G1 {
+	result := &bls.G1{}
+	result.Clear()
+	for bit := 63; bit >= 0; bit-- {
+		bls.G1Dbl(result, result)
+		if clearCofactorG1&(uint64(1)<<uint(bit)) != 0 {
+			bls.G1Add(result, result, point)
+		}
+	}
+
+	return result
+}
+
+func initSswuParams() {
+	doInit.Do(blsInit)
+
+	params := &sswuParams{
+		xNum: fpsFromHex(isoXNum),
+		xDen: fpsFromHex(isoXDen),
+		yNum: fpsFromHex(isoYNum),
+		yDen: fpsFromHex(isoYDen),
+	}
+	params.a = fpsFromHex([]string{sswuIsoA})[0]
+	params.b = fpsFromHex([]string{sswuIsoB})[0]
+	params.z = fpsFromHex([]string{sswuZ})[0]
+
+	bls.FpDiv(&params.minusBA, &params.b, &params.a)
+	bls.FpNeg(&params.minusBA, &params.minusBA)
+	bls.FpMul(&params.bOverZA, &params.z, &params.a)
+	bls.FpDiv(&params.bOverZA, &params.b, &params.bOverZA)
+
+	sswuG1 = params
+}
+
+func fpsFromHex(values []string) []bls.Fp {
+	fps := make([]bls.Fp, len(values))
+	for i, value := range values {
+		err := fps[i].SetString(value, 16)
+		if err != nil {
+			panic(err.Error())
+		}
+	}
+
+	return fps
+}
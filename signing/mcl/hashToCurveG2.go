@@ -0,0 +1,230 @@
+package mcl
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+type sswuParamsG2 struct {
+	a       bls.Fp2
+	b       bls.Fp2
+	z       bls.Fp2
+	bOverZA bls.Fp2
+	minusBA bls.Fp2
+	xNum    []bls.Fp2
+	xDen    []bls.Fp2
+	yNum    []bls.Fp2
+	yDen    []bls.Fp2
+	hEff    *big.Int
+}
+
+var (
+	sswuG2         *sswuParamsG2
+	initSswuG2Once sync.Once
+)
+
+/*
+HashToG2 hashes the message to a point on G2 with the BLS12381G2_XMD:SHA-256_SSWU_RO_ suite defined in RFC 9380:
+
+	u0, u1 = hash_to_field(msg, 2), each u_i being an element of Fp2
+	Q0, Q1 = iso_map(map_to_curve_simple_swu(u0)), iso_map(map_to_curve_simple_swu(u1))
+	P = clear_cofactor(Q0 + Q1)
+
+This is the hashing used by the BLS signatures on G2, as for example in the Ethereum consensus specification
+*/
+func HashToG2(msg []byte, dst []byte) (*PointG2, error) {
+	initSswuG2Once.Do(initSswuParamsG2)
+
+	uniformBytes, err := ExpandMessageXMD(msg, dst, 4*hashToFieldLen)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := &bls.G2{}
+	sum.Clear()
+	for i := 0; i < 2; i++ {
+		u := &bls.Fp2{}
+		for j := 0; j < 2; j++ {
+			offset := (2*i + j) * hashToFieldLen
+			err = u.D[j].SetBigEndianMod(uniformBytes[offset : offset+hashToFieldLen])
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		q := mapToG2(u)
+		bls.G2Add(sum, sum, q)
+	}
+
+	return &PointG2{
+		G2: clearCofactorOnG2(sum),
+	}, nil
+}
+
+// mapToG2 maps the field element to a point on E2, which is not necessarily in the G2 subgroup
+func mapToG2(u *bls.Fp2) *bls.G2 {
+	x, y := mapToCurveSimpleSWUG2(u)
+
+	return isoMapG2(x, y)
+}
+
+// mapToCurveSimpleSWUG2 maps the field element to a point on the isogenous curve E2'
+func mapToCurveSimpleSWUG2(u *bls.Fp2) (*bls.Fp2, *bls.Fp2) {
+	params := sswuG2
+
+	// tv1 = 1 / (Z^2 * u^4 + Z * u^2)
+	zu2 := &bls.Fp2{}
+	bls.Fp2Sqr(zu2, u)
+	bls.Fp2Mul(zu2, zu2, &params.z)
+	tv1 := &bls.Fp2{}
+	bls.Fp2Sqr(tv1, zu2)
+	bls.Fp2Add(tv1, tv1, zu2)
+
+	// x1 = (-B / A) * (1 + tv1), or B / (Z * A) in the exceptional case tv1 == 0
+	x1 := &bls.Fp2{}
+	if tv1.IsZero() {
+		*x1 = params.bOverZA
+	} else {
+		bls.Fp2Inv(tv1, tv1)
+		one := &bls.Fp2{}
+		one.D[0].SetInt64(1)
+		bls.Fp2Add(x1, tv1, one)
+		bls.Fp2Mul(x1, x1, &params.minusBA)
+	}
+
+	x := x1
+	y := &bls.Fp2{}
+	if !bls.Fp2SquareRoot(y, curveEquationG2(x1, params)) {
+		// x2 = Z * u^2 * x1, g(x2) is guaranteed to be a square
+		x2 := &bls.Fp2{}
+		bls.Fp2Mul(x2, zu2, x1)
+		bls.Fp2SquareRoot(y, curveEquationG2(x2, params))
+		x = x2
+	}
+
+	if sgn0Fp2(u) != sgn0Fp2(y) {
+		bls.Fp2Neg(y, y)
+	}
+
+	return x, y
+}
+
+// sgn0Fp2 returns the sign of the element as defined in RFC 9380: the parity of c_0, or of c_1 when c_0 is zero
+func sgn0Fp2(value *bls.Fp2) bool {
+	if value.D[0].IsZero() {
+		return value.D[1].IsOdd()
+	}
+
+	return value.D[0].IsOdd()
+}
+
+// curveEquationG2 returns x^3 + A' * x + B'
+func curveEquationG2(x *bls.Fp2, params *sswuParamsG2) *bls.Fp2 {
+	gx := &bls.Fp2{}
+	bls.Fp2Sqr(gx, x)
+	bls.Fp2Add(gx, gx, &params.a)
+	bls.Fp2Mul(gx, gx, x)
+	bls.Fp2Add(gx, gx, &params.b)
+
+	return gx
+}
+
+// isoMapG2 maps the point from E2' to E2 through the 3-isogeny
+func isoMapG2(xPrime *bls.Fp2, yPrime *bls.Fp2) *bls.G2 {
+	params := sswuG2
+	xNum := evaluatePolynomialFp2(params.xNum, xPrime, false)
+	xDen := evaluatePolynomialFp2(params.xDen, xPrime, true)
+	yNum := evaluatePolynomialFp2(params.yNum, xPrime, false)
+	yDen := evaluatePolynomialFp2(params.yDen, xPrime, true)
+
+	point := &bls.G2{}
+	if xDen.IsZero() || yDen.IsZero() {
+		point.Clear()
+		return point
+	}
+
+	bls.Fp2Div(&point.X, xNum, xDen)
+	bls.Fp2Div(&point.Y, yNum, yDen)
+	bls.Fp2Mul(&point.Y, &point.Y, yPrime)
+	point.Z.D[0].SetInt64(1)
+
+	return point
+}
+
+// evaluatePolynomialFp2 evaluates the polynomial with the given coefficients using Horner's method. A monic
+// polynomial has an implicit leading coefficient equal to 1
+func evaluatePolynomialFp2(coefficients []bls.Fp2, x *bls.Fp2, monic bool) *bls.Fp2 {
+	result := &bls.Fp2{}
+	if monic {
+		result.D[0].SetInt64(1)
+	} else {
+		last := len(coefficients) - 1
+		*result = coefficients[last]
+		coefficients = coefficients[:last]
+	}
+
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		bls.Fp2Mul(result, result, x)
+		bls.Fp2Add(result, result, &coefficients[i])
+	}
+
+	return result
+}
+
+// clearCofactorOnG2 multiplies the point by the effective cofactor. The multiplication is done with double-and-add,
+// as the effective cofactor is larger than the group order and the optimized scalar multiplication of the library
+// assumes the point is in the G2 subgroup
+func clearCofactorOnG2(point *bls.G2) *bls.G2 {
+	hEff := sswuG2.hEff
+
+	result := &bls.G2{}
+	result.Clear()
+	for bit := hEff.BitLen() - 1; bit >= 0; bit-- {
+		bls.G2Dbl(result, result)
+		if hEff.Bit(bit) == 1 {
+			bls.G2Add(result, result, point)
+		}
+	}
+
+	return result
+}
+
+func initSswuParamsG2() {
+	doInit.Do(blsInit)
+
+	params := &sswuParamsG2{
+		xNum: fp2sFromHex(isoXNumG2),
+		xDen: fp2sFromHex(isoXDenG2),
+		yNum: fp2sFromHex(isoYNumG2),
+		yDen: fp2sFromHex(isoYDenG2),
+	}
+	params.a = fp2sFromHex([][2]string{sswuIsoAG2})[0]
+	params.b = fp2sFromHex([][2]string{sswuIsoBG2})[0]
+	params.z = fp2sFromHex([][2]string{sswuZG2})[0]
+
+	bls.Fp2Div(&params.minusBA, &params.b, &params.a)
+	bls.Fp2Neg(&params.minusBA, &params.minusBA)
+	bls.Fp2Mul(&params.bOverZA, &params.z, &params.a)
+	bls.Fp2Div(&params.bOverZA, &params.b, &params.bOverZA)
+
+	hEff, ok := big.NewInt(0).SetString(clearCofactorG2, 16)
+	if !ok {
+		panic("invalid effective cofactor for G2")
+	}
+	params.hEff = hEff
+
+	sswuG2 = params
+}
+
+func fp2sFromHex(values [][2]string) []bls.Fp2 {
+	fp2s := make([]bls.Fp2, len(values))
+	for i, value := range values {
+		components := fpsFromHex(value[:])
+		fp2s[i].D[0] = components[0]
+		fp2s[i].D[1] = components[1]
+	}
+
+	return fp2s
+}
@@ -0,0 +1,173 @@
+package mcl_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+// test vectors from RFC 9380, appendix K.1
+func TestExpandMessageXMD_RFCVectors(t *testing.T) {
+	t.Parallel()
+
+	dst := []byte("QUUX-V01-CS02-with-expander-SHA256-128")
+	vectors := []struct {
+		msg        string
+		lenInBytes int
+		expected   string
+	}{
+		{
+			msg:        "",
+			lenInBytes: 0x20,
+			expected:   "68a985b87eb6b46952128911f2a4412bbc302a9d759667f87f7a21d803f07235",
+		},
+		{
+			msg:        "abc",
+			lenInBytes: 0x20,
+			expected:   "d8ccab23b5985ccea865c6c97b6e5b8350e794e603b4b97902f53a8a0d605615",
+		},
+		{
+			msg:        "",
+			lenInBytes: 0x80,
+			expected: "af84c27ccfd45d41914fdff5df25293e221afc53d8ad2ac06d5e3e29485dadbee0d121587713a3e0dd4d5e69e93eb7cd4" +
+				"f5df4cd103e188cf60cb02edc3edf18eda8576c412b18ffb658e3dd6ec849469b979d444cf7b26911a08e63cf31f9dcc5417" +
+				"08d3491184472c2c29bb749d4286b004ceb5ee6b9a7fa5b646c993f0ced",
+		},
+	}
+
+	for _, vector := range vectors {
+		output, err := mcl.ExpandMessageXMD([]byte(vector.msg), dst, vector.lenInBytes)
+		require.Nil(t, err)
+		require.Equal(t, vector.expected, hex.EncodeToString(output))
+	}
+}
+
+func TestExpandMessageXMD_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	output, err := mcl.ExpandMessageXMD([]byte("msg"), nil, 32)
+	require.Nil(t, output)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	output, err = mcl.ExpandMessageXMD([]byte("msg"), []byte("dst"), 0)
+	require.Nil(t, output)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	output, err = mcl.ExpandMessageXMD([]byte("msg"), []byte("dst"), 255*32+1)
+	require.Nil(t, output)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+}
+
+// test vectors from RFC 9380, appendix J.9.1
+func TestHashToG1_RFCVectors(t *testing.T) {
+	t.Parallel()
+
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G1_XMD:SHA-256_SSWU_RO_")
+	vectors := []struct {
+		msg string
+		x   string
+		y   string
+	}{
+		{
+			msg: "",
+			x:   "052926add2207b76ca4fa57a8734416c8dc95e24501772c814278700eed6d1e4e8cf62d9c09db0fac349612b759e79a1",
+			y:   "08ba738453bfed09cb546dbb0783dbb3a5f1f566ed67bb6be0e8c67e2e81a4cc68ee29813bb7994998f3eae0c9c6a265",
+		},
+		{
+			msg: "abc",
+			x:   "03567bc5ef9c690c2ab2ecdf6a96ef1c139cc0b2f284dca0a9a7943388a49a3aee664ba5379a7655d3c68900be2f6903",
+			y:   "0b9c15f3fe6e5cf4211f346271d7b01c8f3b28be689c8429c85b67af215533311f0b8dfaaa154fa6b88176c229f2885d",
+		},
+		{
+			msg: "abcdef0123456789",
+			x:   "11e0b079dea29a68f0383ee94fed1b940995272407e3bb916bbf268c263ddd57a6a27200a784cbc248e84f357ce82d98",
+			y:   "03a87ae2caf14e8ee52e51fa2ed8eefe80f02457004ba4d486d6aa1f517c0889501dc7413753f9599b099ebcbbd2d709",
+		},
+	}
+
+	for _, vector := range vectors {
+		point, err := mcl.HashToG1([]byte(vector.msg), dst)
+		require.Nil(t, err)
+		require.True(t, point.IsValidOrder())
+
+		expected := &bls.G1{}
+		err = expected.SetString("1 "+vector.x+" "+vector.y, 16)
+		require.Nil(t, err)
+		require.True(t, expected.IsEqual(point.G1), "message %q", vector.msg)
+	}
+}
+
+func TestHashToG1_DifferentDSTShouldGiveDifferentPoints(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	point1, err := mcl.HashToG1(msg, []byte("DST-1"))
+	require.Nil(t, err)
+	point2, err := mcl.HashToG1(msg, []byte("DST-2"))
+	require.Nil(t, err)
+
+	require.False(t, point1.IsEqual(point2.G1))
+}
+
+// test vectors from RFC 9380, appendix J.10.1
+func TestHashToG2_RFCVectors(t *testing.T) {
+	t.Parallel()
+
+	dst := []byte("QUUX-V01-CS02-with-BLS12381G2_XMD:SHA-256_SSWU_RO_")
+	vectors := []struct {
+		msg string
+		x0  string
+		x1  string
+		y0  string
+		y1  string
+	}{
+		{
+			msg: "",
+			x0:  "0141ebfbdca40eb85b87142e130ab689c673cf60f1a3e98d69335266f30d9b8d4ac44c1038e9dcdd5393faf5c41fb78a",
+			x1:  "05cb8437535e20ecffaef7752baddf98034139c38452458baeefab379ba13dff5bf5dd71b72418717047f5b0f37da03d",
+			y0:  "0503921d7f6a12805e72940b963c0cf3471c7b2a524950ca195d11062ee75ec076daf2d4bc358c4b190c0c98064fdd92",
+			y1:  "12424ac32561493f3fe3c260708a12b7c620e7be00099a974e259ddc7d1f6395c3c811cdd19f1e8dbf3e9ecfdcbab8d6",
+		},
+		{
+			msg: "abc",
+			x0:  "02c2d18e033b960562aae3cab37a27ce00d80ccd5ba4b7fe0e7a210245129dbec7780ccc7954725f4168aff2787776e6",
+			x1:  "139cddbccdc5e91b9623efd38c49f81a6f83f175e80b06fc374de9eb4b41dfe4ca3a230ed250fbe3a2acf73a41177fd8",
+			y0:  "1787327b68159716a37440985269cf584bcb1e621d3a7202be6ea05c4cfe244aeb197642555a0645fb87bf7466b2ba48",
+			y1:  "00aa65dae3c8d732d10ecd2c50f8a1baf3001578f71c694e03866e9f3d49ac1e1ce70dd94a733534f106d4cec0eddd16",
+		},
+		{
+			msg: "abcdef0123456789",
+			x0:  "121982811d2491fde9ba7ed31ef9ca474f0e1501297f68c298e9f4c0028add35aea8bb83d53c08cfc007c1e005723cd0",
+			x1:  "190d119345b94fbd15497bcba94ecf7db2cbfd1e1fe7da034d26cbba169fb3968288b3fafb265f9ebd380512a71c3f2c",
+			y0:  "05571a0f8d3c08d094576981f4a3b8eda0a8e771fcdcc8ecceaf1356a6acf17574518acb506e435b639353c2e14827c8",
+			y1:  "0bb5e7572275c567462d91807de765611490205a941a5a6af3b1691bfe596c31225d3aabdf15faff860cb4ef17c7c3be",
+		},
+	}
+
+	for _, vector := range vectors {
+		point, err := mcl.HashToG2([]byte(vector.msg), dst)
+		require.Nil(t, err)
+		require.True(t, point.IsValidOrder())
+
+		expected := &bls.G2{}
+		err = expected.SetString("1 "+vector.x0+" "+vector.x1+" "+vector.y0+" "+vector.y1, 16)
+		require.Nil(t, err)
+		require.True(t, expected.IsEqual(point.G2), "message %q", vector.msg)
+	}
+}
+
+func TestHashToG2_DifferentDSTShouldGiveDifferentPoints(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	point1, err := mcl.HashToG2(msg, []byte("DST-1"))
+	require.Nil(t, err)
+	point2, err := mcl.HashToG2(msg, []byte("DST-2"))
+	require.Nil(t, err)
+
+	require.False(t, point1.IsEqual(point2.G2))
+}
@@ -0,0 +1,89 @@
+package mcl
+
+// Constants of the 3-isogeny map from the curve E2' isogenous to E2 (y^2 = x^3 + 4 * (1 + u)) used by the simplified
+// SWU map, as defined in RFC 9380, appendix E.3. Every constant is an element c_0 + c_1 * u of Fp2 given as the hex
+// encoded pair {c_0, c_1}, and the polynomial coefficients are given in increasing order of the power of x
+
+// sswuIsoAG2 is the A' coefficient of the isogenous curve E2': y^2 = x^3 + A' * x + B', A' = 240 * u
+var sswuIsoAG2 = [2]string{"0", "f0"}
+
+// sswuIsoBG2 is the B' coefficient of the isogenous curve E2': y^2 = x^3 + A' * x + B', B' = 1012 * (1 + u)
+var sswuIsoBG2 = [2]string{"3f4", "3f4"}
+
+// sswuZG2 is the Z parameter of the simplified SWU map for E2', Z = -(2 + u)
+var sswuZG2 = [2]string{
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaa9",
+	"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaaaa",
+}
+
+// isoXNumG2 holds the coefficients k_(1,0)...k_(1,3) of the x numerator polynomial
+var isoXNumG2 = [][2]string{
+	{
+		"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+		"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97d6",
+	},
+	{
+		"0",
+		"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71a",
+	},
+	{
+		"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71e",
+		"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38d",
+	},
+	{
+		"171d6541fa38ccfaed6dea691f5fb614cb14b4e7f4e810aa22d6108f142b85757098e38d0f671c7188e2aaaaaaaa5ed1",
+		"0",
+	},
+}
+
+// isoXDenG2 holds the coefficients k_(2,0) and k_(2,1) of the monic x denominator polynomial
+var isoXDenG2 = [][2]string{
+	{
+		"0",
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa63",
+	},
+	{
+		"c",
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa9f",
+	},
+}
+
+// isoYNumG2 holds the coefficients k_(3,0)...k_(3,3) of the y numerator polynomial
+var isoYNumG2 = [][2]string{
+	{
+		"1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+		"1530477c7ab4113b59a4c18b076d11930f7da5d4a07f649bf54439d87d27e500fc8c25ebf8c92f6812cfc71c71c6d706",
+	},
+	{
+		"0",
+		"5c759507e8e333ebb5b7a9a47d7ed8532c52d39fd3a042a88b58423c50ae15d5c2638e343d9c71c6238aaaaaaaa97be",
+	},
+	{
+		"11560bf17baa99bc32126fced787c88f984f87adf7ae0c7f9a208c6b4f20a4181472aaa9cb8d555526a9ffffffffc71c",
+		"8ab05f8bdd54cde190937e76bc3e447cc27c3d6fbd7063fcd104635a790520c0a395554e5c6aaaa9354ffffffffe38f",
+	},
+	{
+		"124c9ad43b6cf79bfbf7043de3811ad0761b0f37a1e26286b0e977c69aa274524e79097a56dc4bd9e1b371c71c718b10",
+		"0",
+	},
+}
+
+// isoYDenG2 holds the coefficients k_(4,0)...k_(4,2) of the monic y denominator polynomial
+var isoYDenG2 = [][2]string{
+	{
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa8fb",
+	},
+	{
+		"0",
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffa9d3",
+	},
+	{
+		"12",
+		"1a0111ea397fe69a4b1ba7b6434bacd764774b84f38512bf6730d2a0f6b0f6241eabfffeb153ffffb9feffffffffaa99",
+	},
+}
+
+// clearCofactorG2 is the hex encoded effective cofactor h_eff used to clear the cofactor of points on E2
+const clearCofactorG2 = "bc69f08f2ee75b3584c6a0ea91b352888e2a8e9145ad7689986ff031508ffe1329c2f178731db956d82bf015d1212b02" +
+	"ec0ec69d7477c1ae954cbc06689f6a359894c0adebbf6b4e8020005aaa95551"
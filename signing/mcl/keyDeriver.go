@@ -0,0 +1,53 @@
+package mcl
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ crypto.KeyDeriver = (*keyDeriver)(nil)
+
+// keyDeriver derives BLS12-381 private keys from a seed following EIP-2333, with EIP-2334 derivation paths
+type keyDeriver struct {
+	keyGen crypto.KeyGenerator
+}
+
+// NewKeyDeriver creates a key deriver whose private keys are created by the key generator, so they can be used with
+// the signers of its suite. The key generator needs to be of a BLS12-381 suite
+func NewKeyDeriver(keyGen crypto.KeyGenerator) (*keyDeriver, error) {
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+	if check.IfNil(keyGen.Suite()) {
+		return nil, crypto.ErrNilSuite
+	}
+
+	_, isBLS12 := keyGen.Suite().CreateScalar().(*Scalar)
+	if !isBLS12 {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	return &keyDeriver{
+		keyGen: keyGen,
+	}, nil
+}
+
+// DerivePrivateKey derives the private key of the EIP-2334 derivation path from the seed
+func (kd *keyDeriver) DerivePrivateKey(seed []byte, path string) (crypto.PrivateKey, error) {
+	sk, err := DeriveSKFromPath(seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	skBytes, err := sk.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	return kd.keyGen.PrivateKeyFromByteArray(skBytes)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (kd *keyDeriver) IsInterfaceNil() bool {
+	return kd == nil
+}
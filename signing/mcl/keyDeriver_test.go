@@ -0,0 +1,67 @@
+package mcl
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewKeyDeriver(t *testing.T) {
+	t.Parallel()
+
+	kd, err := NewKeyDeriver(nil)
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	kd, err = NewKeyDeriver(signing.NewKeyGenerator(nil))
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrNilSuite, err)
+
+	kd, err = NewKeyDeriver(signing.NewKeyGenerator(ed25519.NewEd25519()))
+	require.True(t, check.IfNil(kd))
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+
+	kd, err = NewKeyDeriver(signing.NewKeyGenerator(NewSuiteBLS12()))
+	require.False(t, check.IfNil(kd))
+	require.Nil(t, err)
+}
+
+func TestKeyDeriver_DerivePrivateKey(t *testing.T) {
+	t.Parallel()
+
+	vector := eip2333TestVectors[0]
+	seed, _ := hex.DecodeString(vector.seed)
+	keyGen := signing.NewKeyGenerator(NewSuiteBLS12())
+	kd, _ := NewKeyDeriver(keyGen)
+
+	privKey, err := kd.DerivePrivateKey(seed, "m/0")
+	require.Nil(t, err)
+	require.Equal(t, vector.childSK, privKey.Scalar().(*Scalar).Scalar.GetString(10))
+
+	// the derived keys are usable with the key generator
+	privKeyBytes, _ := privKey.ToByteArray()
+	loaded, err := keyGen.PrivateKeyFromByteArray(privKeyBytes)
+	require.Nil(t, err)
+	expectedPubKey, _ := privKey.GeneratePublic().ToByteArray()
+	loadedPubKey, _ := loaded.GeneratePublic().ToByteArray()
+	require.Equal(t, expectedPubKey, loadedPubKey)
+
+	// the derivation is deterministic and distinct for each validator
+	first, _ := kd.DerivePrivateKey(seed, "m/12381/3600/0/0/0")
+	firstAgain, _ := kd.DerivePrivateKey(seed, "m/12381/3600/0/0/0")
+	second, _ := kd.DerivePrivateKey(seed, "m/12381/3600/1/0/0")
+	firstBytes, _ := first.ToByteArray()
+	firstAgainBytes, _ := firstAgain.ToByteArray()
+	secondBytes, _ := second.ToByteArray()
+	require.Equal(t, firstBytes, firstAgainBytes)
+	require.NotEqual(t, firstBytes, secondBytes)
+
+	privKey, err = kd.DerivePrivateKey(seed, "n/0")
+	require.Nil(t, privKey)
+	require.NotNil(t, err)
+}
@@ -1,6 +1,8 @@
 package multisig
 
 import (
+	"strings"
+
 	"github.com/ME-MotherEarth/me-core/core/check"
 	"github.com/ME-MotherEarth/me-core/hashing"
 	crypto "github.com/ME-MotherEarth/me-crypto"
@@ -14,10 +16,56 @@ var _ crypto.LowLevelSignerBLS = (*BlsMultiSigner)(nil)
 // 16bytes output hasher!
 const hasherOutputSize = 16
 
+const scalarByteLen = 32
+
+// PubKeyEncoding selects how a public key point is encoded when hashed into its modified BLS coefficient
+type PubKeyEncoding uint8
+
+const (
+	// PubKeyEncodingHexString hashes the base 16 string of the public key point. This is the encoding used since the
+	// first version and the default one, so the coefficients, and so the aggregated signatures, stay unchanged
+	PubKeyEncodingHexString PubKeyEncoding = iota
+	// PubKeyEncodingBinary hashes the binary serialization of the public key point, avoiding the string conversion.
+	// The aggregated signatures are not compatible with the ones created with PubKeyEncodingHexString
+	PubKeyEncodingBinary
+)
+
 // BlsMultiSigner provides an implements of the crypto.LowLevelSignerBLS interface
 type BlsMultiSigner struct {
 	singlesig.BlsSingleSigner
-	Hasher hashing.Hasher
+	Hasher         hashing.Hasher
+	PubKeyEncoding PubKeyEncoding
+	cache          *coefficientsCache
+}
+
+// NewBlsMultiSignerWithCache creates a BlsMultiSigner that keeps the modified BLS coefficients of the most recently
+// used cacheCapacity public key sets, so they are not recomputed on each aggregation and verification for the same
+// validator set. The hasher and the encoding must not be changed afterwards, as the cached coefficients depend on them
+func NewBlsMultiSignerWithCache(
+	hasher hashing.Hasher,
+	pubKeyEncoding PubKeyEncoding,
+	cacheCapacity int,
+) (*BlsMultiSigner, error) {
+	if check.IfNil(hasher) {
+		return nil, crypto.ErrNilHasher
+	}
+	if hasher.Size() != hasherOutputSize {
+		return nil, crypto.ErrWrongSizeHasher
+	}
+	if pubKeyEncoding > PubKeyEncodingBinary {
+		return nil, crypto.ErrInvalidParam
+	}
+
+	cache, err := newCoefficientsCache(cacheCapacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlsMultiSigner{
+		Hasher:         hasher,
+		PubKeyEncoding: pubKeyEncoding,
+		cache:          cache,
+	}, nil
 }
 
 // SignShare produces a BLS signature share (single BLS signature) over a given message
@@ -98,7 +146,7 @@ func (bms *BlsMultiSigner) VerifyAggregatedSig(
 		return crypto.ErrInvalidSuite
 	}
 
-	preparedPubKeys, err := preparePublicKeys(pubKeys, bms.Hasher, suite)
+	prepared, err := bms.getPreparedPubKeys(suite, pubKeys)
 	if err != nil {
 		return err
 	}
@@ -109,7 +157,7 @@ func (bms *BlsMultiSigner) VerifyAggregatedSig(
 		return err
 	}
 
-	res := aggSig.FastAggregateVerify(preparedPubKeys, msg)
+	res := aggSig.FastAggregateVerify(prepared.pubKeys, msg)
 	if !res {
 		return crypto.ErrAggSigNotValid
 	}
@@ -122,43 +170,17 @@ func preparePublicKeys(
 	hasher hashing.Hasher,
 	suite crypto.Suite,
 ) ([]bls.PublicKey, error) {
-	var hPk []byte
-	var prepPublicKeyPoint crypto.Point
-	var pubKeyPoint crypto.Point
-	prepPubKeysPoints := make([]bls.PublicKey, len(pubKeys))
-
 	concatPKs, err := concatPubKeys(pubKeys)
 	if err != nil {
 		return nil, err
 	}
 
-	for i, pubKey := range pubKeys {
-		if check.IfNil(pubKey) {
-			return nil, crypto.ErrNilPublicKey
-		}
-
-		pubKeyPoint = pubKey.Point()
-
-		// t_i = H(pk_i, {pk_1, ..., pk_n})
-		hPk, err = hashPublicKeyPoints(hasher, pubKeyPoint, concatPKs)
-		if err != nil {
-			return nil, err
-		}
-
-		// t_i*pubKey_i
-		prepPublicKeyPoint, err = scalarMulPk(suite, hPk, pubKeyPoint)
-		if err != nil {
-			return nil, err
-		}
-
-		prepPubKeyG2, ok := prepPublicKeyPoint.GetUnderlyingObj().(*bls.G2)
-		if !ok {
-			return nil, crypto.ErrInvalidPoint
-		}
-		prepPubKeysPoints[i] = *bls.CastToPublicKey(prepPubKeyG2)
+	prepared, err := computePreparedPubKeys(suite, hasher, PubKeyEncodingHexString, pubKeys, concatPKs)
+	if err != nil {
+		return nil, err
 	}
 
-	return prepPubKeysPoints, nil
+	return prepared.pubKeys, nil
 }
 
 func (bms *BlsMultiSigner) prepareSignatures(
@@ -169,14 +191,17 @@ func (bms *BlsMultiSigner) prepareSignatures(
 	if len(signatures) == 0 {
 		return nil, crypto.ErrNilSignaturesList
 	}
-	concatPKs, err := concatPubKeys(pubKeysSigners)
+
+	prepared, err := bms.getPreparedPubKeys(suite, pubKeysSigners)
 	if err != nil {
 		return nil, err
 	}
+	if len(signatures) > len(pubKeysSigners) {
+		return nil, crypto.ErrInvalidParam
+	}
 
-	var hPk []byte
-	var sPointG1 *mcl.PointG1
-	prepSigs := make([]bls.Sign, 0)
+	var prepSigPoint crypto.Point
+	prepSigs := make([]bls.Sign, 0, len(signatures))
 
 	for i, sig := range signatures {
 		sigBLS := &bls.Sign{}
@@ -193,30 +218,109 @@ func (bms *BlsMultiSigner) prepareSignatures(
 			return nil, crypto.ErrBLSInvalidSignature
 		}
 
-		pubKeyPoint := pubKeysSigners[i].Point()
-		mclPointG2, isPoint := pubKeyPoint.(*mcl.PointG2)
-		if !isPoint || !singlesig.IsPubKeyPointValid(mclPointG2) {
+		sigPoint := &mcl.PointG1{
+			G1: bls.CastFromSign(sigBLS),
+		}
+
+		// t_i*sig_i
+		prepSigPoint, err = sigPoint.Mul(prepared.coefficients[i])
+		if err != nil {
+			return nil, err
+		}
+
+		prepSigG1, ok := prepSigPoint.GetUnderlyingObj().(*bls.G1)
+		if !ok {
+			return nil, crypto.ErrInvalidPoint
+		}
+		prepSigs = append(prepSigs, *bls.CastToSign(prepSigG1))
+	}
+
+	return prepSigs, nil
+}
+
+// getPreparedPubKeys returns the modified BLS coefficients and the pre-multiplied public keys for the ordered set
+// of public keys, from the cache if the signer has one and the set was already prepared
+func (bms *BlsMultiSigner) getPreparedPubKeys(suite crypto.Suite, pubKeys []crypto.PublicKey) (*preparedPubKeys, error) {
+	concatPKs, err := concatPubKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if bms.cache != nil {
+		prepared, found := bms.cache.get(concatPKs)
+		if found {
+			return prepared, nil
+		}
+	}
+
+	prepared, err := computePreparedPubKeys(suite, bms.Hasher, bms.PubKeyEncoding, pubKeys, concatPKs)
+	if err != nil {
+		return nil, err
+	}
+
+	if bms.cache != nil {
+		bms.cache.put(concatPKs, prepared)
+	}
+
+	return prepared, nil
+}
+
+// computePreparedPubKeys computes for each public key the coefficient t_i = H(pk_i, {pk_1, ..., pk_n}) and the
+// pre-multiplied public key t_i*pk_i
+func computePreparedPubKeys(
+	suite crypto.Suite,
+	hasher hashing.Hasher,
+	pubKeyEncoding PubKeyEncoding,
+	pubKeys []crypto.PublicKey,
+	concatPKs []byte,
+) (*preparedPubKeys, error) {
+	prepared := &preparedPubKeys{
+		coefficients: make([]crypto.Scalar, len(pubKeys)),
+		pubKeys:      make([]bls.PublicKey, len(pubKeys)),
+	}
+
+	for i, pubKey := range pubKeys {
+		if check.IfNil(pubKey) {
+			return nil, crypto.ErrNilPublicKey
+		}
+
+		pubKeyPoint, isPoint := pubKey.Point().(*mcl.PointG2)
+		if !isPoint || !singlesig.IsPubKeyPointValid(pubKeyPoint) {
 			return nil, crypto.ErrInvalidPublicKey
 		}
 
-		sigPoint := mcl.NewPointG1()
-		sigPoint.G1 = bls.CastFromSign(sigBLS)
+		// t_i = H(pk_i, {pk_1, ..., pk_n})
+		var hPk []byte
+		var err error
+		switch pubKeyEncoding {
+		case PubKeyEncodingBinary:
+			hPk, err = hashPublicKey(hasher, pubKeyPoint.G2.Serialize(), concatPKs)
+		default:
+			hPk, err = hashPublicKeyPoints(hasher, pubKeyPoint, concatPKs)
+		}
+		if err != nil {
+			return nil, err
+		}
 
-		hPk, err = hashPublicKeyPoints(bms.Hasher, pubKeyPoint, concatPKs)
+		prepared.coefficients[i], err = createScalar(suite, hPk)
 		if err != nil {
 			return nil, err
 		}
-		// H1(pubKey_i)*sig_i
-		sPointG1, err = scalarMulSig(suite, hPk, sigPoint)
+
+		// t_i*pubKey_i
+		prepPublicKeyPoint, err := pubKeyPoint.Mul(prepared.coefficients[i])
 		if err != nil {
 			return nil, err
 		}
 
-		sigBLS = bls.CastToSign(sPointG1.G1)
-		prepSigs = append(prepSigs, *sigBLS)
+		prepPubKeyG2, ok := prepPublicKeyPoint.GetUnderlyingObj().(*bls.G2)
+		if !ok {
+			return nil, crypto.ErrInvalidPoint
+		}
+		prepared.pubKeys[i] = *bls.CastToPublicKey(prepPubKeyG2)
 	}
 
-	return prepSigs, nil
+	return prepared, nil
 }
 
 // concatenatePubKeys concatenates the public keys
@@ -252,8 +356,28 @@ func concatPubKeys(pubKeys []crypto.PublicKey) ([]byte, error) {
 	return result, nil
 }
 
-// hashPublicKeyPoints hashes the concatenation of public keys with the given public key poiint
+// hashPublicKeyPoints hashes the concatenation of public keys with the base 16 string of the given public key point
 func hashPublicKeyPoints(hasher hashing.Hasher, pubKeyPoint crypto.Point, concatPubKeys []byte) ([]byte, error) {
+	if check.IfNil(pubKeyPoint) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	var blsPointString string
+	switch blsPoint := pubKeyPoint.GetUnderlyingObj().(type) {
+	case *bls.G2:
+		blsPointString = blsPoint.GetString(16)
+	case *bls.G1:
+		blsPointString = blsPoint.GetString(16)
+	default:
+		return nil, crypto.ErrInvalidPoint
+	}
+
+	return hashPublicKey(hasher, []byte(blsPointString), concatPubKeys)
+}
+
+// hashPublicKey hashes the concatenation of public keys with the given encoding of a public key, returning the
+// 32 bytes big endian representation of the coefficient
+func hashPublicKey(hasher hashing.Hasher, encodedPubKey []byte, concatPubKeys []byte) ([]byte, error) {
 	if check.IfNil(hasher) {
 		return nil, crypto.ErrNilHasher
 	}
@@ -263,22 +387,18 @@ func hashPublicKeyPoints(hasher hashing.Hasher, pubKeyPoint crypto.Point, concat
 	if hasher.Size() != hasherOutputSize {
 		return nil, crypto.ErrWrongSizeHasher
 	}
-	if check.IfNil(pubKeyPoint) {
-		return nil, crypto.ErrNilPublicKeyPoint
-	}
 
-	blsPoint, ok := pubKeyPoint.GetUnderlyingObj().(*bls.G2)
-	if !ok {
-		return nil, crypto.ErrInvalidPoint
-	}
-	blsPointString := blsPoint.GetString(16)
-	concatPkWithPKs := append([]byte(blsPointString), concatPubKeys...)
+	// the hasher only accepts strings, so the input is built directly as a string to avoid copying it again
+	builder := strings.Builder{}
+	builder.Grow(len(encodedPubKey) + len(concatPubKeys))
+	builder.Write(encodedPubKey)
+	builder.Write(concatPubKeys)
 
 	// H1(pk_i, {pk_1, ..., pk_n})
-	h := hasher.Compute(string(concatPkWithPKs))
+	h := hasher.Compute(builder.String())
 	// accepted length 32, copy the hasherOutputSize bytes and have rest 0
-	h32 := make([]byte, 32)
-	copy(h32[hasherOutputSize:], h)
+	h32 := make([]byte, scalarByteLen)
+	copy(h32[scalarByteLen-hasherOutputSize:], h)
 
 	return h32, nil
 }
@@ -0,0 +1,219 @@
+package multisig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.AggregateSignerBLS = (*BlsAggregateSigner)(nil)
+
+/*
+BlsAggregateSigner aggregates BLS signatures where each signer signed a different message.
+
+The aggregated signature is the sum of the signatures, and it is verified with a single multi-pairing:
+
+	e(aggSig, G2) == prod(e(H(m_i), pk_i))
+
+A rogue key attack is possible if the same message is signed by multiple signers, so in the basic mode the messages
+need to be distinct. In the augmented mode each signer signs its own serialized public key prepended to the message,
+making the signed messages distinct by construction, so the same message can be signed by multiple signers.
+*/
+type BlsAggregateSigner struct {
+	singlesig.BlsSingleSigner
+	augmented bool
+}
+
+// NewBlsAggregateSigner creates an aggregate signer that requires the signed messages to be distinct
+func NewBlsAggregateSigner() *BlsAggregateSigner {
+	return &BlsAggregateSigner{}
+}
+
+// NewBlsAugmentedAggregateSigner creates an aggregate signer that prepends the signer public key to each message,
+// allowing the same message to be signed by multiple signers
+func NewBlsAugmentedAggregateSigner() *BlsAggregateSigner {
+	return &BlsAggregateSigner{
+		augmented: true,
+	}
+}
+
+// SignShare produces a BLS signature over the message, prefixed by the signer public key in the augmented mode
+func (bas *BlsAggregateSigner) SignShare(privKey crypto.PrivateKey, message []byte) ([]byte, error) {
+	if check.IfNil(privKey) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if !bas.augmented {
+		return bas.Sign(privKey, message)
+	}
+	if len(message) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+
+	augmentedMsg, err := augmentMessage(privKey.GeneratePublic(), message)
+	if err != nil {
+		return nil, err
+	}
+
+	return bas.Sign(privKey, augmentedMsg)
+}
+
+// VerifySigShare verifies a BLS signature share over the message, prefixed by the signer public key in the
+// augmented mode
+func (bas *BlsAggregateSigner) VerifySigShare(pubKey crypto.PublicKey, message []byte, sig []byte) error {
+	if !bas.augmented {
+		return bas.Verify(pubKey, message, sig)
+	}
+	if len(message) == 0 {
+		return crypto.ErrNilMessage
+	}
+
+	augmentedMsg, err := augmentMessage(pubKey, message)
+	if err != nil {
+		return err
+	}
+
+	return bas.Verify(pubKey, augmentedMsg, sig)
+}
+
+// AggregateSignatures aggregates BLS signatures over distinct messages
+func (bas *BlsAggregateSigner) AggregateSignatures(suite crypto.Suite, signatures [][]byte) ([]byte, error) {
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+	if len(signatures) == 0 {
+		return nil, crypto.ErrNilSignaturesList
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	if !ok {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	sigs := make([]bls.Sign, 0, len(signatures))
+	for _, sig := range signatures {
+		sigBLS, err := sigBytesToSig(sig)
+		if err != nil {
+			return nil, err
+		}
+
+		sigs = append(sigs, *sigBLS)
+	}
+
+	aggSig := &bls.Sign{}
+	aggSig.Aggregate(sigs)
+
+	return aggSig.Serialize(), nil
+}
+
+// AggregateVerify verifies an aggregated signature where message i was signed by the signer with public key i.
+// In the basic mode the messages are required to be distinct
+func (bas *BlsAggregateSigner) AggregateVerify(
+	suite crypto.Suite,
+	pubKeys []crypto.PublicKey,
+	messages [][]byte,
+	aggSigBytes []byte,
+) error {
+	if check.IfNil(suite) {
+		return crypto.ErrNilSuite
+	}
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(messages) != len(pubKeys) {
+		return crypto.ErrInvalidParam
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	if !ok {
+		return crypto.ErrInvalidSuite
+	}
+
+	aggSig, err := sigBytesToSig(aggSigBytes)
+	if err != nil {
+		return err
+	}
+
+	numSigners := len(pubKeys)
+	g1Points := make([]bls.G1, numSigners+1)
+	g2Points := make([]bls.G2, numSigners+1)
+	signedMessages := make(map[string]struct{}, numSigners)
+	for i := range pubKeys {
+		if len(messages[i]) == 0 {
+			return crypto.ErrNilMessage
+		}
+
+		pubKeyPoint, errPoint := validPubKeyPoint(pubKeys[i])
+		if errPoint != nil {
+			return errPoint
+		}
+
+		msg := messages[i]
+		if bas.augmented {
+			msg, err = augmentMessage(pubKeys[i], msg)
+			if err != nil {
+				return err
+			}
+		}
+
+		_, isDuplicate := signedMessages[string(msg)]
+		if isDuplicate {
+			return crypto.ErrDuplicateMessage
+		}
+		signedMessages[string(msg)] = struct{}{}
+
+		err = g1Points[i].HashAndMapTo(msg)
+		if err != nil {
+			return err
+		}
+		g2Points[i] = *pubKeyPoint.G2
+	}
+
+	bls.G1Neg(&g1Points[numSigners], bls.CastFromSign(aggSig))
+	g2Points[numSigners] = *mcl.NewPointG2().G2
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+	if !gt.IsOne() {
+		return crypto.ErrAggSigNotValid
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bas *BlsAggregateSigner) IsInterfaceNil() bool {
+	return bas == nil
+}
+
+func validPubKeyPoint(pubKey crypto.PublicKey) (*mcl.PointG2, error) {
+	if check.IfNil(pubKey) {
+		return nil, crypto.ErrNilPublicKey
+	}
+
+	point := pubKey.Point()
+	if check.IfNil(point) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG2)
+	if !isPoint || !singlesig.IsPubKeyPointValid(pubKeyPoint) {
+		return nil, crypto.ErrInvalidPublicKey
+	}
+
+	return pubKeyPoint, nil
+}
+
+// augmentMessage prepends the serialized public key to the message
+func augmentMessage(pubKey crypto.PublicKey, message []byte) ([]byte, error) {
+	pubKeyPoint, err := validPubKeyPoint(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pubKeyBytes := pubKeyPoint.G2.Serialize()
+	augmentedMsg := make([]byte, 0, len(pubKeyBytes)+len(message))
+	augmentedMsg = append(augmentedMsg, pubKeyBytes...)
+
+	return append(augmentedMsg, message...), nil
+}
@@ -0,0 +1,183 @@
+package multisig_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createAggregateSigShares(
+	t *testing.T,
+	signer crypto.AggregateSignerBLS,
+	messages [][]byte,
+) (pubKeys []crypto.PublicKey, sigShares [][]byte) {
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+
+	pubKeys = make([]crypto.PublicKey, len(messages))
+	sigShares = make([][]byte, len(messages))
+	for i := range messages {
+		sk, pk := kg.GeneratePair()
+		pubKeys[i] = pk
+
+		sig, err := signer.SignShare(sk, messages[i])
+		require.Nil(t, err)
+		require.Nil(t, signer.VerifySigShare(pk, messages[i], sig))
+		sigShares[i] = sig
+	}
+
+	return pubKeys, sigShares
+}
+
+func distinctMessages(nbMessages int) [][]byte {
+	messages := make([][]byte, nbMessages)
+	for i := range messages {
+		messages[i] = []byte(fmt.Sprintf("miniblock hash %d", i))
+	}
+
+	return messages
+}
+
+func TestBlsAggregateSigner_AggregateVerifyDistinctMessagesOK(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	signer := multisig.NewBlsAggregateSigner()
+	require.False(t, check.IfNil(signer))
+
+	messages := distinctMessages(7)
+	pubKeys, sigShares := createAggregateSigShares(t, signer, messages)
+
+	aggSig, err := signer.AggregateSignatures(suite, sigShares)
+	require.Nil(t, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Nil(t, err)
+}
+
+func TestBlsAggregateSigner_AggregateVerifyWrongMessageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	signer := multisig.NewBlsAggregateSigner()
+	messages := distinctMessages(5)
+	pubKeys, sigShares := createAggregateSigShares(t, signer, messages)
+
+	aggSig, err := signer.AggregateSignatures(suite, sigShares)
+	require.Nil(t, err)
+
+	messages[3] = []byte("another message")
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	// messages signed by other signers
+	messages = distinctMessages(5)
+	messages[1], messages[2] = messages[2], messages[1]
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	// missing signature share
+	aggSig, err = signer.AggregateSignatures(suite, sigShares[1:])
+	require.Nil(t, err)
+	err = signer.AggregateVerify(suite, pubKeys, distinctMessages(5), aggSig)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+}
+
+func TestBlsAggregateSigner_AggregateVerifyDuplicateMessagesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	signer := multisig.NewBlsAggregateSigner()
+	messages := distinctMessages(4)
+	messages[3] = messages[0]
+	pubKeys, sigShares := createAggregateSigShares(t, signer, messages)
+
+	aggSig, err := signer.AggregateSignatures(suite, sigShares)
+	require.Nil(t, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrDuplicateMessage, err)
+}
+
+func TestBlsAggregateSigner_AugmentedAllowsDuplicateMessages(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	signer := multisig.NewBlsAugmentedAggregateSigner()
+	messages := distinctMessages(4)
+	messages[2] = messages[0]
+	messages[3] = messages[0]
+	pubKeys, sigShares := createAggregateSigShares(t, signer, messages)
+
+	aggSig, err := signer.AggregateSignatures(suite, sigShares)
+	require.Nil(t, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Nil(t, err)
+
+	// the augmented signatures are not valid in the basic mode
+	basicSigner := multisig.NewBlsAggregateSigner()
+	err = basicSigner.VerifySigShare(pubKeys[1], messages[1], sigShares[1])
+	require.Equal(t, crypto.ErrSigNotValid, err)
+
+	// the same signer and message included twice is still a duplicate
+	pubKeys = append(pubKeys, pubKeys[0])
+	messages = append(messages, messages[0])
+	err = signer.AggregateVerify(suite, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrDuplicateMessage, err)
+}
+
+func TestBlsAggregateSigner_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	invalidSuite := createMockSuite("invalid suite")
+	signer := multisig.NewBlsAugmentedAggregateSigner()
+	messages := distinctMessages(3)
+	pubKeys, sigShares := createAggregateSigShares(t, signer, messages)
+	aggSig, _ := signer.AggregateSignatures(suite, sigShares)
+
+	_, err := signer.SignShare(nil, messages[0])
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	err = signer.VerifySigShare(nil, messages[0], sigShares[0])
+	require.Equal(t, crypto.ErrNilPublicKey, err)
+
+	_, err = signer.AggregateSignatures(nil, sigShares)
+	require.Equal(t, crypto.ErrNilSuite, err)
+
+	_, err = signer.AggregateSignatures(invalidSuite, sigShares)
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+
+	_, err = signer.AggregateSignatures(suite, nil)
+	require.Equal(t, crypto.ErrNilSignaturesList, err)
+
+	_, err = signer.AggregateSignatures(suite, [][]byte{sigShares[0], []byte("invalid")})
+	require.NotNil(t, err)
+
+	err = signer.AggregateVerify(nil, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrNilSuite, err)
+
+	err = signer.AggregateVerify(invalidSuite, pubKeys, messages, aggSig)
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+
+	err = signer.AggregateVerify(suite, nil, messages, aggSig)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, messages[:2], aggSig)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, messages, nil)
+	require.Equal(t, crypto.ErrNilSignature, err)
+
+	err = signer.AggregateVerify(suite, pubKeys, [][]byte{messages[0], nil, messages[2]}, aggSig)
+	require.Equal(t, crypto.ErrNilMessage, err)
+
+	err = signer.AggregateVerify(suite, []crypto.PublicKey{pubKeys[0], nil, pubKeys[2]}, messages, aggSig)
+	require.Equal(t, crypto.ErrNilPublicKey, err)
+}
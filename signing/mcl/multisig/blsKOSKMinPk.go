@@ -0,0 +1,112 @@
+package multisig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.LowLevelSignerBLS = (*BlsMultiSignerKOSKMinPk)(nil)
+
+// BlsMultiSignerKOSKMinPk provides an implementation of the crypto.LowLevelSignerBLS interface with the public keys
+// on G1 and the signatures on G2, relying on the knowledge of secret key the same way as BlsMultiSignerKOSK
+type BlsMultiSignerKOSKMinPk struct {
+	singlesig.BlsSingleSignerMinPk
+}
+
+// SignShare produces a BLS signature share (single BLS signature) over a given message
+func (bms *BlsMultiSignerKOSKMinPk) SignShare(privKey crypto.PrivateKey, message []byte) ([]byte, error) {
+	return bms.Sign(privKey, message)
+}
+
+// VerifySigShare verifies a BLS signature share (single BLS signature) over a given message
+func (bms *BlsMultiSignerKOSKMinPk) VerifySigShare(pubKey crypto.PublicKey, message []byte, sig []byte) error {
+	return bms.Verify(pubKey, message, sig)
+}
+
+// VerifySigBytes provides an "cheap" integrity check of a signature given as a byte array
+// It does not validate the signature over a message, only verifies that it is a signature
+func (bms *BlsMultiSignerKOSKMinPk) VerifySigBytes(_ crypto.Suite, sig []byte) error {
+	_, err := singlesig.SigBytesToG2(sig)
+
+	return err
+}
+
+// AggregateSignatures produces an aggregation of single BLS signatures over the same message
+func (bms *BlsMultiSignerKOSKMinPk) AggregateSignatures(
+	suite crypto.Suite,
+	signatures [][]byte,
+	pubKeysSigners []crypto.PublicKey,
+) ([]byte, error) {
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+	if len(signatures) == 0 {
+		return nil, crypto.ErrNilSignaturesList
+	}
+	if len(pubKeysSigners) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12MinPk)
+	if !ok {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	aggSig := &bls.G2{}
+	aggSig.Clear()
+	for _, sig := range signatures {
+		sigPoint, err := singlesig.SigBytesToG2(sig)
+		if err != nil {
+			return nil, err
+		}
+
+		bls.G2Add(aggSig, aggSig, sigPoint)
+	}
+
+	return aggSig.Serialize(), nil
+}
+
+// VerifyAggregatedSig verifies if a BLS aggregated signature is valid over a given message
+func (bms *BlsMultiSignerKOSKMinPk) VerifyAggregatedSig(
+	suite crypto.Suite,
+	pubKeys []crypto.PublicKey,
+	aggSigBytes []byte,
+	msg []byte,
+) error {
+	if check.IfNil(suite) {
+		return crypto.ErrNilSuite
+	}
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(aggSigBytes) == 0 {
+		return crypto.ErrNilSignature
+	}
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12MinPk)
+	if !ok {
+		return crypto.ErrInvalidSuite
+	}
+
+	aggPubKey := &bls.G1{}
+	aggPubKey.Clear()
+	for _, pubKey := range pubKeys {
+		pubKeyPoint, err := minPkPubKeyPoint(pubKey)
+		if err != nil {
+			return err
+		}
+
+		bls.G1Add(aggPubKey, aggPubKey, pubKeyPoint.G1)
+	}
+
+	return verifyMinPkAggregatedSig(aggPubKey, aggSigBytes, msg)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bms *BlsMultiSignerKOSKMinPk) IsInterfaceNil() bool {
+	return bms == nil
+}
@@ -0,0 +1,208 @@
+package multisig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-core/hashing"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.LowLevelSignerBLS = (*BlsMultiSignerMinPk)(nil)
+
+// BlsMultiSignerMinPk provides an implementation of the crypto.LowLevelSignerBLS interface with the public keys on G1
+// and the signatures on G2, protected against rogue key attacks the same way as BlsMultiSigner. As for BlsMultiSigner,
+// the coefficients are computed over the base 16 string of the public keys unless PubKeyEncodingBinary is selected
+type BlsMultiSignerMinPk struct {
+	singlesig.BlsSingleSignerMinPk
+	Hasher         hashing.Hasher
+	PubKeyEncoding PubKeyEncoding
+}
+
+// SignShare produces a BLS signature share (single BLS signature) over a given message
+func (bms *BlsMultiSignerMinPk) SignShare(privKey crypto.PrivateKey, message []byte) ([]byte, error) {
+	return bms.Sign(privKey, message)
+}
+
+// VerifySigShare verifies a BLS signature share (single BLS signature) over a given message
+func (bms *BlsMultiSignerMinPk) VerifySigShare(pubKey crypto.PublicKey, message []byte, sig []byte) error {
+	return bms.Verify(pubKey, message, sig)
+}
+
+// VerifySigBytes provides an "cheap" integrity check of a signature given as a byte array
+// It does not validate the signature over a message, only verifies that it is a signature
+func (bms *BlsMultiSignerMinPk) VerifySigBytes(_ crypto.Suite, sig []byte) error {
+	_, err := singlesig.SigBytesToG2(sig)
+
+	return err
+}
+
+// AggregateSignatures produces an aggregation of single BLS signatures over the same message
+func (bms *BlsMultiSignerMinPk) AggregateSignatures(
+	suite crypto.Suite,
+	signatures [][]byte,
+	pubKeysSigners []crypto.PublicKey,
+) ([]byte, error) {
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+	if len(signatures) == 0 {
+		return nil, crypto.ErrNilSignaturesList
+	}
+	if len(pubKeysSigners) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+	if len(pubKeysSigners) != len(signatures) {
+		return nil, crypto.ErrInvalidParam
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12MinPk)
+	if !ok {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	concatPKs, err := concatPubKeys(pubKeysSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	aggSig := &bls.G2{}
+	aggSig.Clear()
+	for i, sig := range signatures {
+		sigPoint, errSig := singlesig.SigBytesToG2(sig)
+		if errSig != nil {
+			return nil, errSig
+		}
+
+		pubKeyPoint, errPk := minPkPubKeyPoint(pubKeysSigners[i])
+		if errPk != nil {
+			return nil, errPk
+		}
+
+		// H1(pubKey_i)*sig_i
+		coefficient, errCoef := pubKeyCoefficient(suite, bms.Hasher, bms.PubKeyEncoding, pubKeyPoint, concatPKs)
+		if errCoef != nil {
+			return nil, errCoef
+		}
+
+		bls.G2Mul(sigPoint, sigPoint, coefficient)
+		bls.G2Add(aggSig, aggSig, sigPoint)
+	}
+
+	return aggSig.Serialize(), nil
+}
+
+// VerifyAggregatedSig verifies if a BLS aggregated signature is valid over a given message
+func (bms *BlsMultiSignerMinPk) VerifyAggregatedSig(
+	suite crypto.Suite,
+	pubKeys []crypto.PublicKey,
+	aggSigBytes []byte,
+	msg []byte,
+) error {
+	if check.IfNil(suite) {
+		return crypto.ErrNilSuite
+	}
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(aggSigBytes) == 0 {
+		return crypto.ErrNilSignature
+	}
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12MinPk)
+	if !ok {
+		return crypto.ErrInvalidSuite
+	}
+
+	concatPKs, err := concatPubKeys(pubKeys)
+	if err != nil {
+		return err
+	}
+
+	aggPubKey := &bls.G1{}
+	aggPubKey.Clear()
+	for _, pubKey := range pubKeys {
+		pubKeyPoint, errPk := minPkPubKeyPoint(pubKey)
+		if errPk != nil {
+			return errPk
+		}
+
+		// t_i = H(pk_i, {pk_1, ..., pk_n})
+		coefficient, errCoef := pubKeyCoefficient(suite, bms.Hasher, bms.PubKeyEncoding, pubKeyPoint, concatPKs)
+		if errCoef != nil {
+			return errCoef
+		}
+
+		prepPubKey := &bls.G1{}
+		bls.G1Mul(prepPubKey, pubKeyPoint.G1, coefficient)
+		bls.G1Add(aggPubKey, aggPubKey, prepPubKey)
+	}
+
+	return verifyMinPkAggregatedSig(aggPubKey, aggSigBytes, msg)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bms *BlsMultiSignerMinPk) IsInterfaceNil() bool {
+	return bms == nil
+}
+
+func pubKeyCoefficient(
+	suite crypto.Suite,
+	hasher hashing.Hasher,
+	pubKeyEncoding PubKeyEncoding,
+	pubKeyPoint *mcl.PointG1,
+	concatPubKeys []byte,
+) (*bls.Fr, error) {
+	var hPk []byte
+	var err error
+	switch pubKeyEncoding {
+	case PubKeyEncodingBinary:
+		hPk, err = hashPublicKey(hasher, pubKeyPoint.G1.Serialize(), concatPubKeys)
+	default:
+		hPk, err = hashPublicKeyPoints(hasher, pubKeyPoint, concatPubKeys)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	scalar, err := createScalar(suite, hPk)
+	if err != nil {
+		return nil, err
+	}
+
+	return scalar.(*mcl.Scalar).Scalar, nil
+}
+
+func minPkPubKeyPoint(pubKey crypto.PublicKey) (*mcl.PointG1, error) {
+	if check.IfNil(pubKey) {
+		return nil, crypto.ErrNilPublicKey
+	}
+
+	point := pubKey.Point()
+	if check.IfNil(point) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG1)
+	if !isPoint || !singlesig.IsMinPkPubKeyPointValid(pubKeyPoint) {
+		return nil, crypto.ErrInvalidPublicKey
+	}
+
+	return pubKeyPoint, nil
+}
+
+func verifyMinPkAggregatedSig(aggPubKey *bls.G1, aggSigBytes []byte, msg []byte) error {
+	aggSig, err := singlesig.SigBytesToG2(aggSigBytes)
+	if err != nil {
+		return err
+	}
+
+	err = singlesig.VerifyMinPkPairing(aggPubKey, msg, aggSig)
+	if err == crypto.ErrSigNotValid {
+		return crypto.ErrAggSigNotValid
+	}
+
+	return err
+}
@@ -0,0 +1,233 @@
+package multisig_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-core/hashing/blake2b"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createSigSharesMinPk(
+	t *testing.T,
+	nbSigs int,
+	message []byte,
+	llSigner crypto.LowLevelSignerBLS,
+) (pubKeys []crypto.PublicKey, sigShares [][]byte) {
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+
+	pubKeys = make([]crypto.PublicKey, nbSigs)
+	sigShares = make([][]byte, nbSigs)
+	for i := 0; i < nbSigs; i++ {
+		sk, pk := kg.GeneratePair()
+		pubKeys[i] = pk
+
+		sig, err := llSigner.SignShare(sk, message)
+		require.Nil(t, err)
+		require.Nil(t, llSigner.VerifySigShare(pk, message, sig))
+		require.Nil(t, llSigner.VerifySigBytes(nil, sig))
+		sigShares[i] = sig
+	}
+
+	return pubKeys, sigShares
+}
+
+func minPkMultiSigners(t *testing.T) map[string]crypto.LowLevelSignerBLS {
+	hasher, err := blake2b.NewBlake2bWithSize(blsHashSize)
+	require.Nil(t, err)
+
+	return map[string]crypto.LowLevelSignerBLS{
+		"modified BLS": &multisig.BlsMultiSignerMinPk{Hasher: hasher},
+		"KOSK":         &multisig.BlsMultiSignerKOSKMinPk{},
+	}
+}
+
+func TestBlsMultiSignerMinPk_AggregateAndVerifyOK(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	msg := []byte(testMessage)
+
+	for name, llSigner := range minPkMultiSigners(t) {
+		require.False(t, check.IfNil(llSigner))
+		pubKeys, sigShares := createSigSharesMinPk(t, 9, msg, llSigner)
+
+		aggSig, err := llSigner.AggregateSignatures(suite, sigShares, pubKeys)
+		require.Nil(t, err, name)
+		require.Len(t, aggSig, 96)
+
+		err = llSigner.VerifyAggregatedSig(suite, pubKeys, aggSig, msg)
+		require.Nil(t, err, name)
+	}
+}
+
+func TestBlsMultiSignerMinPk_VerifyAggregatedSigInvalidShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	msg := []byte(testMessage)
+
+	for name, llSigner := range minPkMultiSigners(t) {
+		pubKeys, sigShares := createSigSharesMinPk(t, 5, msg, llSigner)
+		aggSig, err := llSigner.AggregateSignatures(suite, sigShares, pubKeys)
+		require.Nil(t, err, name)
+
+		err = llSigner.VerifyAggregatedSig(suite, pubKeys, aggSig, []byte("another message"))
+		require.Equal(t, crypto.ErrAggSigNotValid, err, name)
+
+		err = llSigner.VerifyAggregatedSig(suite, pubKeys[1:], aggSig, msg)
+		require.Equal(t, crypto.ErrAggSigNotValid, err, name)
+	}
+}
+
+func TestBlsMultiSignerMinPk_G2SuiteShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	msg := []byte(testMessage)
+
+	for name, llSigner := range minPkMultiSigners(t) {
+		pubKeys, sigShares := createSigSharesMinPk(t, 3, msg, llSigner)
+		aggSig, err := llSigner.AggregateSignatures(suite, sigShares, pubKeys)
+		require.Nil(t, err, name)
+
+		_, err = llSigner.AggregateSignatures(mcl.NewSuiteBLS12(), sigShares, pubKeys)
+		require.Equal(t, crypto.ErrInvalidSuite, err, name)
+
+		err = llSigner.VerifyAggregatedSig(mcl.NewSuiteBLS12(), pubKeys, aggSig, msg)
+		require.Equal(t, crypto.ErrInvalidSuite, err, name)
+	}
+
+	// the signers with the public keys on G2 do not accept the suite with the public keys on G1
+	pubKeys, sigShares := createSigSharesMinPk(t, 3, msg, &multisig.BlsMultiSignerKOSKMinPk{})
+	_, err := (&multisig.BlsMultiSignerKOSK{}).AggregateSignatures(suite, sigShares, pubKeys)
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+}
+
+func TestBlsMultiSignerMinPk_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	msg := []byte(testMessage)
+
+	for name, llSigner := range minPkMultiSigners(t) {
+		pubKeys, sigShares := createSigSharesMinPk(t, 3, msg, llSigner)
+		aggSig, _ := llSigner.AggregateSignatures(suite, sigShares, pubKeys)
+
+		_, err := llSigner.AggregateSignatures(nil, sigShares, pubKeys)
+		require.Equal(t, crypto.ErrNilSuite, err, name)
+		_, err = llSigner.AggregateSignatures(suite, nil, pubKeys)
+		require.Equal(t, crypto.ErrNilSignaturesList, err, name)
+		_, err = llSigner.AggregateSignatures(suite, sigShares, nil)
+		require.Equal(t, crypto.ErrNilPublicKeys, err, name)
+		_, err = llSigner.AggregateSignatures(suite, [][]byte{sigShares[0], nil, sigShares[2]}, pubKeys)
+		require.Equal(t, crypto.ErrNilSignature, err, name)
+
+		err = llSigner.VerifyAggregatedSig(nil, pubKeys, aggSig, msg)
+		require.Equal(t, crypto.ErrNilSuite, err, name)
+		err = llSigner.VerifyAggregatedSig(suite, nil, aggSig, msg)
+		require.Equal(t, crypto.ErrNilPublicKeys, err, name)
+		err = llSigner.VerifyAggregatedSig(suite, pubKeys, nil, msg)
+		require.Equal(t, crypto.ErrNilSignature, err, name)
+		err = llSigner.VerifyAggregatedSig(suite, pubKeys, aggSig, nil)
+		require.Equal(t, crypto.ErrNilMessage, err, name)
+		err = llSigner.VerifyAggregatedSig(suite, []crypto.PublicKey{pubKeys[0], nil}, aggSig, msg)
+		require.Equal(t, crypto.ErrNilPublicKey, err, name)
+
+		err = llSigner.VerifySigBytes(suite, nil)
+		require.Equal(t, crypto.ErrNilSignature, err, name)
+	}
+}
+
+func createGoldenSigSharesMinPk(t *testing.T, llSig crypto.LowLevelSignerBLS) ([]crypto.PublicKey, [][]byte) {
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+	pubKeys := make([]crypto.PublicKey, 4)
+	sigShares := make([][]byte, 4)
+	for i := range pubKeys {
+		scalar := mcl.NewScalar()
+		scalar.SetInt64(int64(1000003 * (i + 1)))
+		scalarBytes, err := scalar.MarshalBinary()
+		require.Nil(t, err)
+
+		privKey, err := kg.PrivateKeyFromByteArray(scalarBytes)
+		require.Nil(t, err)
+		pubKeys[i] = privKey.GeneratePublic()
+
+		sigShares[i], err = llSig.SignShare(privKey, []byte(testMessage))
+		require.Nil(t, err)
+	}
+
+	return pubKeys, sigShares
+}
+
+func TestBlsMultiSignerMinPk_HexStringEncodingGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	// vectors created when the signers with the public keys on G1 were introduced, which must be reproduced byte for
+	// byte by the default encoding
+	expectedHashes := []string{
+		"00000000000000000000000000000000c21d9144ec6209ad56821a02018d140e",
+		"000000000000000000000000000000001c7375c4221f8b1245a5d260a31110a3",
+		"0000000000000000000000000000000024bd9a8b147a2f54da215174c55ae7fe",
+		"00000000000000000000000000000000b9b42debfee14b4a23153921e34dac51",
+	}
+	expectedAggSig := "5b1944cefcb25a08987d21a3dc8a2400c40ce5354581b10058f626940adcdd479fbf876287d63f0092b0e8a3f9b78b11" +
+		"d87e277d17a9f589fe9626cd7d37c57dc8396ceb25b61fc468a2b4c5b0ec1c53d59ddf899ab9b43f5c0fd0b641431e0a"
+
+	hasher := &mock.HasherSpongeMock{}
+	llSigners := []*multisig.BlsMultiSignerMinPk{
+		{Hasher: hasher},
+		{Hasher: hasher, PubKeyEncoding: multisig.PubKeyEncodingHexString},
+	}
+
+	for _, llSig := range llSigners {
+		pubKeys, sigShares := createGoldenSigSharesMinPk(t, llSig)
+		concatPubKeys, err := multisig.ConcatPubKeys(pubKeys)
+		require.Nil(t, err)
+		for i, pubKey := range pubKeys {
+			hash, err := multisig.HashPublicKeyPoints(hasher, pubKey.Point(), concatPubKeys)
+			require.Nil(t, err)
+			require.Equal(t, expectedHashes[i], hex.EncodeToString(hash))
+		}
+
+		aggSig, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+		require.Nil(t, err)
+		require.Equal(t, expectedAggSig, hex.EncodeToString(aggSig))
+
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, aggSig, []byte(testMessage))
+		require.Nil(t, err)
+	}
+}
+
+func TestBlsMultiSignerMinPk_BinaryEncodingGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	expectedAggSig := "b96fbe71b1d7aaecbbc29d1eb3f40e82b75db60d8db44ddd79ced33bd1952b9810a09416a1407bc5ec7ff583f0c7860c" +
+		"e62851287133b9f8b4f0725b4cc34a282d9de341f9be5e0068c1806a151849bcc6f79b606010ec82eb2462c27b99cb03"
+	expectedLegacyAggSig := "5b1944cefcb25a08987d21a3dc8a2400c40ce5354581b10058f626940adcdd479fbf876287d63f0092b0e8a3f9b7" +
+		"8b11d87e277d17a9f589fe9626cd7d37c57dc8396ceb25b61fc468a2b4c5b0ec1c53d59ddf899ab9b43f5c0fd0b641431e0a"
+
+	llSig := &multisig.BlsMultiSignerMinPk{
+		Hasher:         &mock.HasherSpongeMock{},
+		PubKeyEncoding: multisig.PubKeyEncodingBinary,
+	}
+	pubKeys, sigShares := createGoldenSigSharesMinPk(t, llSig)
+
+	aggSig, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+	require.Nil(t, err)
+	require.Equal(t, expectedAggSig, hex.EncodeToString(aggSig))
+
+	err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, aggSig, []byte(testMessage))
+	require.Nil(t, err)
+
+	// the aggregated signatures of the two encodings are not interchangeable
+	legacyAggSig, _ := hex.DecodeString(expectedLegacyAggSig)
+	err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, legacyAggSig, []byte(testMessage))
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+}
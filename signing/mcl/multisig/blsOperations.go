@@ -1,8 +1,6 @@
 package multisig
 
 import (
-	"encoding/hex"
-
 	"github.com/ME-MotherEarth/me-core/core/check"
 	crypto "github.com/ME-MotherEarth/me-crypto"
 	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
@@ -42,7 +40,7 @@ func scalarMulSig(suite crypto.Suite, scalarBytes []byte, sigPoint *mcl.PointG1)
 		return nil, crypto.ErrInvalidScalar
 	}
 
-	err := sc.Scalar.SetString(hex.EncodeToString(scalarBytes), 16)
+	err := setScalarBigEndian(sc.Scalar, scalarBytes)
 	if err != nil {
 		return nil, crypto.ErrInvalidScalar
 	}
@@ -67,10 +65,9 @@ func sigBytesToPoint(sig []byte) (crypto.Point, error) {
 		return nil, err
 	}
 
-	pG1 := mcl.NewPointG1()
-	pG1.G1 = bls.CastFromSign(sigBLS)
-
-	return pG1, nil
+	return &mcl.PointG1{
+		G1: bls.CastFromSign(sigBLS),
+	}, nil
 }
 
 func sigBytesToSig(sig []byte) (*bls.Sign, error) {
@@ -123,12 +120,30 @@ func createScalar(suite crypto.Suite, scalarBytes []byte) (crypto.Scalar, error)
 	}
 
 	scalar := suite.CreateScalar()
-	sc, _ := scalar.(*mcl.Scalar)
+	sc, ok := scalar.(*mcl.Scalar)
+	if !ok {
+		return nil, crypto.ErrInvalidScalar
+	}
 
-	err := sc.Scalar.SetString(hex.EncodeToString(scalarBytes), 16)
+	err := setScalarBigEndian(sc.Scalar, scalarBytes)
 	if err != nil {
 		return nil, err
 	}
 
 	return scalar, nil
 }
+
+// setScalarBigEndian sets the scalar from its big endian representation of at most 32 bytes. Like parsing the hex
+// string of the bytes, it fails for values not lower than the group order instead of reducing them
+func setScalarBigEndian(scalar *bls.Fr, scalarBytes []byte) error {
+	if len(scalarBytes) == 0 || len(scalarBytes) > scalarByteLen {
+		return crypto.ErrInvalidScalar
+	}
+
+	littleEndian := make([]byte, scalarByteLen)
+	for i, b := range scalarBytes {
+		littleEndian[len(scalarBytes)-1-i] = b
+	}
+
+	return scalar.Deserialize(littleEndian)
+}
@@ -329,3 +329,62 @@ func Test_PubKeysCryptoToBLS(t *testing.T) {
 		require.Len(t, pubKeysBLS, 2)
 	})
 }
+
+func Test_CreateScalarShouldMatchHexStringParsing(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+	inputs := [][]byte{
+		{1},
+		make([]byte, 32),
+		// the group order, which is not a valid scalar
+		mustDecodeHex(t, "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001"),
+		// the group order - 1
+		mustDecodeHex(t, "73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000000"),
+		mustDecodeHex(t, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+	}
+	for i := 0; i < 20; i++ {
+		scalar, _ := suite.CreateScalar().Pick()
+		scalarHexStr := scalar.(*mcl.Scalar).Scalar.GetString(16)
+		if len(scalarHexStr)%2 != 0 {
+			scalarHexStr = "0" + scalarHexStr
+		}
+		inputs = append(inputs, mustDecodeHex(t, scalarHexStr))
+	}
+
+	for _, input := range inputs {
+		expected := &bls.Fr{}
+		errExpected := expected.SetString(hex.EncodeToString(input), 16)
+
+		scalar, err := multisig.CreateScalar(suite, input)
+		if errExpected != nil {
+			require.NotNil(t, err)
+			require.Nil(t, scalar)
+			continue
+		}
+
+		require.Nil(t, err)
+		require.True(t, expected.IsEqual(scalar.(*mcl.Scalar).Scalar))
+	}
+}
+
+func Test_CreateScalarInvalidLengthShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12()
+
+	scalar, err := multisig.CreateScalar(suite, nil)
+	require.Equal(t, crypto.ErrInvalidScalar, err)
+	require.Nil(t, scalar)
+
+	scalar, err = multisig.CreateScalar(suite, make([]byte, 33))
+	require.Equal(t, crypto.ErrInvalidScalar, err)
+	require.Nil(t, scalar)
+}
+
+func mustDecodeHex(t *testing.T, hexStr string) []byte {
+	buff, err := hex.DecodeString(hexStr)
+	require.Nil(t, err)
+
+	return buff
+}
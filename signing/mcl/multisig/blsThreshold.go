@@ -0,0 +1,383 @@
+package multisig
+
+import (
+	"fmt"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+)
+
+var _ crypto.LowLevelSignerBLS = (*BlsThresholdSigner)(nil)
+
+/*
+BlsThresholdSigner implements t-of-n threshold BLS signatures.
+
+A group secret s is shared with a polynomial f of degree t-1 having f(0) = s, the share with index i (1-based) being
+s_i = f(i) and its public key pk_i = s_i*G2. Each signature share is a standard BLS signature sig_i = s_i*H(m).
+Any t signature shares are combined by Lagrange interpolation in the exponent:
+
+	sig = sum(lambda_i * sig_i), lambda_i = prod_{j != i}(x_j / (x_j - x_i))
+
+so that sig = s*H(m), which verifies under the single group public key s*G2 regardless of which t shares were used.
+*/
+type BlsThresholdSigner struct {
+	singlesig.BlsSingleSigner
+	threshold    uint16
+	shareIndexes map[string]uint16
+}
+
+// NewBlsThresholdSigner creates a threshold signer for the ordered list of share public keys. The share public key
+// at position i in the list corresponds to the secret share evaluated at i+1, so the keys need to be valid G2 points
+// and distinct
+func NewBlsThresholdSigner(threshold uint16, sharePubKeys []crypto.PublicKey) (*BlsThresholdSigner, error) {
+	if len(sharePubKeys) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+	if threshold == 0 || int(threshold) > len(sharePubKeys) {
+		return nil, crypto.ErrInvalidThreshold
+	}
+
+	shareIndexes := make(map[string]uint16, len(sharePubKeys))
+	for i, pubKey := range sharePubKeys {
+		if check.IfNil(pubKey) {
+			return nil, crypto.ErrNilPublicKey
+		}
+
+		pubKeyPoint, isPoint := pubKey.Point().(*mcl.PointG2)
+		if !isPoint || !singlesig.IsPubKeyPointValid(pubKeyPoint) {
+			return nil, fmt.Errorf("%w: share public key at index %d", crypto.ErrInvalidPublicKey, i)
+		}
+
+		pubKeyBytes, err := pubKey.ToByteArray()
+		if err != nil {
+			return nil, err
+		}
+
+		_, exists := shareIndexes[string(pubKeyBytes)]
+		if exists {
+			return nil, fmt.Errorf("%w: share public key at index %d", crypto.ErrDuplicateIndex, i)
+		}
+
+		shareIndexes[string(pubKeyBytes)] = uint16(i + 1)
+	}
+
+	return &BlsThresholdSigner{
+		threshold:    threshold,
+		shareIndexes: shareIndexes,
+	}, nil
+}
+
+// SignShare produces a BLS signature share with the given secret key share
+func (bts *BlsThresholdSigner) SignShare(privKey crypto.PrivateKey, message []byte) ([]byte, error) {
+	return bts.Sign(privKey, message)
+}
+
+// VerifySigShare verifies a BLS signature share against the public key of the secret key share
+func (bts *BlsThresholdSigner) VerifySigShare(pubKey crypto.PublicKey, message []byte, sig []byte) error {
+	return bts.Verify(pubKey, message, sig)
+}
+
+// VerifySigBytes provides an "cheap" integrity check of a signature given as a byte array
+// It does not validate the signature over a message, only verifies that it is a signature
+func (bts *BlsThresholdSigner) VerifySigBytes(_ crypto.Suite, sig []byte) error {
+	if len(sig) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	_, err := sigBytesToPoint(sig)
+
+	return err
+}
+
+// AggregateSignatures recovers the group signature from the signature shares. The signers are identified by the
+// share public keys, and only the first threshold distinct signers are used for the recovery.
+// The shares are not verified, so a single invalid share silently yields an invalid group signature: callers must
+// either check every share with VerifySigShare beforehand or use AggregateVerifiedSignatures instead
+func (bts *BlsThresholdSigner) AggregateSignatures(
+	suite crypto.Suite,
+	signatures [][]byte,
+	pubKeysSigners []crypto.PublicKey,
+) ([]byte, error) {
+	return bts.aggregateShares(suite, nil, signatures, pubKeysSigners)
+}
+
+// AggregateVerifiedSignatures recovers the group signature over the message from the signature shares, verifying
+// every share against its share public key before using it. Invalid shares are skipped, so the recovery succeeds
+// as long as at least threshold distinct signers provided valid shares
+func (bts *BlsThresholdSigner) AggregateVerifiedSignatures(
+	suite crypto.Suite,
+	message []byte,
+	signatures [][]byte,
+	pubKeysSigners []crypto.PublicKey,
+) ([]byte, error) {
+	if len(message) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+
+	return bts.aggregateShares(suite, message, signatures, pubKeysSigners)
+}
+
+// aggregateShares interpolates the first threshold distinct shares. If the message is provided, shares that do not
+// verify over it are skipped
+func (bts *BlsThresholdSigner) aggregateShares(
+	suite crypto.Suite,
+	message []byte,
+	signatures [][]byte,
+	pubKeysSigners []crypto.PublicKey,
+) ([]byte, error) {
+	if check.IfNil(suite) {
+		return nil, crypto.ErrNilSuite
+	}
+	if len(signatures) == 0 {
+		return nil, crypto.ErrNilSignaturesList
+	}
+	if len(pubKeysSigners) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+	if len(pubKeysSigners) != len(signatures) {
+		return nil, crypto.ErrInvalidParam
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	if !ok {
+		return nil, crypto.ErrInvalidSuite
+	}
+
+	ids := make([]uint16, 0, bts.threshold)
+	sigPoints := make([]crypto.Point, 0, bts.threshold)
+	usedIds := make(map[uint16]struct{}, bts.threshold)
+	for i := 0; i < len(signatures) && len(ids) < int(bts.threshold); i++ {
+		id, err := bts.shareIndex(pubKeysSigners[i])
+		if err != nil {
+			return nil, err
+		}
+
+		_, alreadyUsed := usedIds[id]
+		if alreadyUsed {
+			continue
+		}
+
+		if len(message) > 0 && bts.VerifySigShare(pubKeysSigners[i], message, signatures[i]) != nil {
+			continue
+		}
+
+		sigPoint, err := sigBytesToPoint(signatures[i])
+		if err != nil {
+			return nil, err
+		}
+
+		usedIds[id] = struct{}{}
+		ids = append(ids, id)
+		sigPoints = append(sigPoints, sigPoint)
+	}
+
+	if len(ids) < int(bts.threshold) {
+		return nil, crypto.ErrNotEnoughSignatureShares
+	}
+
+	coefficients, err := LagrangeCoefficients(ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var aggSig crypto.Point = mcl.NewPointG1().Null()
+	for i := range sigPoints {
+		weightedSig, errMul := sigPoints[i].Mul(coefficients[i])
+		if errMul != nil {
+			return nil, errMul
+		}
+
+		aggSig, err = aggSig.Add(weightedSig)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return aggSig.MarshalBinary()
+}
+
+// VerifyAggregatedSig verifies the recovered group signature. The public keys list must contain only the group
+// public key, as the verifier does not need to know which shares were used for the recovery
+func (bts *BlsThresholdSigner) VerifyAggregatedSig(
+	suite crypto.Suite,
+	pubKeys []crypto.PublicKey,
+	aggSigBytes []byte,
+	msg []byte,
+) error {
+	if check.IfNil(suite) {
+		return crypto.ErrNilSuite
+	}
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(pubKeys) != 1 {
+		return crypto.ErrInvalidParam
+	}
+	if len(aggSigBytes) == 0 {
+		return crypto.ErrNilSignature
+	}
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	if !ok {
+		return crypto.ErrInvalidSuite
+	}
+
+	err := bts.Verify(pubKeys[0], msg, aggSigBytes)
+	if err == crypto.ErrSigNotValid {
+		return crypto.ErrAggSigNotValid
+	}
+
+	return err
+}
+
+func (bts *BlsThresholdSigner) shareIndex(pubKey crypto.PublicKey) (uint16, error) {
+	if check.IfNil(pubKey) {
+		return 0, crypto.ErrNilPublicKey
+	}
+
+	pubKeyBytes, err := pubKey.ToByteArray()
+	if err != nil {
+		return 0, err
+	}
+
+	id, ok := bts.shareIndexes[string(pubKeyBytes)]
+	if !ok {
+		return 0, crypto.ErrInvalidPublicKey
+	}
+
+	return id, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bts *BlsThresholdSigner) IsInterfaceNil() bool {
+	return bts == nil
+}
+
+// LagrangeCoefficients computes the Lagrange coefficients for interpolating in 0 a polynomial evaluated in the
+// given distinct, non-zero indexes
+func LagrangeCoefficients(ids []uint16) ([]crypto.Scalar, error) {
+	if len(ids) == 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+
+	xs := make([]crypto.Scalar, len(ids))
+	seenIds := make(map[uint16]struct{}, len(ids))
+	for i, id := range ids {
+		if id == 0 {
+			return nil, crypto.ErrInvalidParam
+		}
+		_, seen := seenIds[id]
+		if seen {
+			return nil, crypto.ErrDuplicateIndex
+		}
+		seenIds[id] = struct{}{}
+
+		x := mcl.NewScalar()
+		x.SetInt64(int64(id))
+		xs[i] = x
+	}
+
+	coefficients := make([]crypto.Scalar, len(ids))
+	for i := range xs {
+		numerator := xs[i].One()
+		denominator := xs[i].One()
+		for j := range xs {
+			if i == j {
+				continue
+			}
+
+			diff, err := xs[j].Sub(xs[i])
+			if err != nil {
+				return nil, err
+			}
+			numerator, err = numerator.Mul(xs[j])
+			if err != nil {
+				return nil, err
+			}
+			denominator, err = denominator.Mul(diff)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		coefficient, err := numerator.Div(denominator)
+		if err != nil {
+			return nil, err
+		}
+
+		coefficients[i] = coefficient
+	}
+
+	return coefficients, nil
+}
+
+// SplitSecret shares the given secret among numShares participants such that any threshold of them can recover it.
+// The share at position i in the returned list is the evaluation in i+1 of a random polynomial of degree threshold-1
+// having the secret as free coefficient
+func SplitSecret(secret crypto.Scalar, threshold uint16, numShares uint16) ([]crypto.Scalar, error) {
+	if check.IfNil(secret) {
+		return nil, crypto.ErrNilParam
+	}
+	_, ok := secret.(*mcl.Scalar)
+	if !ok {
+		return nil, crypto.ErrInvalidScalar
+	}
+	if threshold == 0 || threshold > numShares {
+		return nil, crypto.ErrInvalidThreshold
+	}
+
+	var err error
+	coefficients := make([]crypto.Scalar, threshold)
+	coefficients[0] = secret.Clone()
+	for k := 1; k < int(threshold); k++ {
+		coefficients[k], err = secret.Pick()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	shares := make([]crypto.Scalar, numShares)
+	for i := range shares {
+		shares[i], err = EvaluatePolynomial(coefficients, uint16(i+1))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return shares, nil
+}
+
+// EvaluatePolynomial evaluates with Horner's method the polynomial with the given coefficients in x. The free
+// coefficient comes first and the list must not be empty
+func EvaluatePolynomial(coefficients []crypto.Scalar, x uint16) (crypto.Scalar, error) {
+	if len(coefficients) == 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+	for _, coefficient := range coefficients {
+		if check.IfNil(coefficient) {
+			return nil, crypto.ErrInvalidParam
+		}
+	}
+
+	xScalar := coefficients[0].Clone()
+	xScalar.SetInt64(int64(x))
+
+	var err error
+	result := coefficients[len(coefficients)-1].Clone()
+	for k := len(coefficients) - 2; k >= 0; k-- {
+		result, err = result.Mul(xScalar)
+		if err != nil {
+			return nil, err
+		}
+
+		result, err = result.Add(coefficients[k])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,299 @@
+package multisig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createThresholdKeys(t *testing.T, threshold uint16, numShares uint16) (
+	groupPubKey crypto.PublicKey,
+	sharePrivKeys []crypto.PrivateKey,
+	sharePubKeys []crypto.PublicKey,
+) {
+	suite := mcl.NewSuiteBLS12()
+	kg := signing.NewKeyGenerator(suite)
+	groupPrivKey, groupPubKey := kg.GeneratePair()
+
+	shares, err := multisig.SplitSecret(groupPrivKey.Scalar(), threshold, numShares)
+	require.Nil(t, err)
+
+	sharePrivKeys = make([]crypto.PrivateKey, numShares)
+	sharePubKeys = make([]crypto.PublicKey, numShares)
+	for i, share := range shares {
+		shareBytes, _ := share.MarshalBinary()
+		sharePrivKeys[i], err = kg.PrivateKeyFromByteArray(shareBytes)
+		require.Nil(t, err)
+		sharePubKeys[i] = sharePrivKeys[i].GeneratePublic()
+	}
+
+	return groupPubKey, sharePrivKeys, sharePubKeys
+}
+
+func signThresholdShares(
+	t *testing.T,
+	signer *multisig.BlsThresholdSigner,
+	sharePrivKeys []crypto.PrivateKey,
+	sharePubKeys []crypto.PublicKey,
+	indexes []int,
+	msg []byte,
+) ([][]byte, []crypto.PublicKey) {
+	sigs := make([][]byte, 0, len(indexes))
+	pubKeys := make([]crypto.PublicKey, 0, len(indexes))
+	for _, idx := range indexes {
+		sig, err := signer.SignShare(sharePrivKeys[idx], msg)
+		require.Nil(t, err)
+		require.Nil(t, signer.VerifySigShare(sharePubKeys[idx], msg, sig))
+
+		sigs = append(sigs, sig)
+		pubKeys = append(pubKeys, sharePubKeys[idx])
+	}
+
+	return sigs, pubKeys
+}
+
+func TestNewBlsThresholdSigner_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, _, sharePubKeys := createThresholdKeys(t, 2, 3)
+
+	signer, err := multisig.NewBlsThresholdSigner(2, nil)
+	require.True(t, check.IfNil(signer))
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+
+	signer, err = multisig.NewBlsThresholdSigner(0, sharePubKeys)
+	require.True(t, check.IfNil(signer))
+	require.Equal(t, crypto.ErrInvalidThreshold, err)
+
+	signer, err = multisig.NewBlsThresholdSigner(4, sharePubKeys)
+	require.True(t, check.IfNil(signer))
+	require.Equal(t, crypto.ErrInvalidThreshold, err)
+
+	signer, err = multisig.NewBlsThresholdSigner(2, []crypto.PublicKey{sharePubKeys[0], nil})
+	require.True(t, check.IfNil(signer))
+	require.Equal(t, crypto.ErrNilPublicKey, err)
+
+	invalidPubKey := &mock.PublicKeyStub{
+		PointStub: func() crypto.Point {
+			return &mock.PointMock{}
+		},
+	}
+	signer, err = multisig.NewBlsThresholdSigner(2, []crypto.PublicKey{sharePubKeys[0], invalidPubKey})
+	require.True(t, check.IfNil(signer))
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+
+	signer, err = multisig.NewBlsThresholdSigner(2, []crypto.PublicKey{sharePubKeys[0], sharePubKeys[1], sharePubKeys[0]})
+	require.True(t, check.IfNil(signer))
+	require.True(t, errors.Is(err, crypto.ErrDuplicateIndex))
+}
+
+func TestBlsThresholdSigner_AnySubsetOfThresholdSharesShouldVerify(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 3, 5)
+	signer, err := multisig.NewBlsThresholdSigner(3, sharePubKeys)
+	require.Nil(t, err)
+	suite := groupPubKey.Suite()
+
+	subsets := [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}}
+	var previousAggSig []byte
+	for _, subset := range subsets {
+		sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, subset, msg)
+
+		aggSig, errAgg := signer.AggregateSignatures(suite, sigs, pubKeys)
+		require.Nil(t, errAgg)
+
+		errVerify := signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey}, aggSig, msg)
+		require.Nil(t, errVerify)
+
+		// the recovered signature is unique, as it is the group secret signature over the message
+		if previousAggSig != nil {
+			require.Equal(t, previousAggSig, aggSig)
+		}
+		previousAggSig = aggSig
+	}
+}
+
+func TestBlsThresholdSigner_AggregateSignaturesNotEnoughSharesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 3, 5)
+	signer, _ := multisig.NewBlsThresholdSigner(3, sharePubKeys)
+
+	sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, []int{0, 1}, msg)
+	aggSig, err := signer.AggregateSignatures(groupPubKey.Suite(), sigs, pubKeys)
+	require.Nil(t, aggSig)
+	require.Equal(t, crypto.ErrNotEnoughSignatureShares, err)
+
+	// duplicated shares are counted once
+	sigs = append(sigs, sigs[0])
+	pubKeys = append(pubKeys, pubKeys[0])
+	aggSig, err = signer.AggregateSignatures(groupPubKey.Suite(), sigs, pubKeys)
+	require.Nil(t, aggSig)
+	require.Equal(t, crypto.ErrNotEnoughSignatureShares, err)
+}
+
+func TestBlsThresholdSigner_AggregateSignaturesUnknownSignerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 2, 3)
+	signer, _ := multisig.NewBlsThresholdSigner(2, sharePubKeys)
+
+	sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, []int{0, 1}, msg)
+	pubKeys[1] = groupPubKey
+	aggSig, err := signer.AggregateSignatures(groupPubKey.Suite(), sigs, pubKeys)
+	require.Nil(t, aggSig)
+	require.Equal(t, crypto.ErrInvalidPublicKey, err)
+}
+
+func TestBlsThresholdSigner_AggregateSignaturesInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 2, 3)
+	signer, _ := multisig.NewBlsThresholdSigner(2, sharePubKeys)
+	sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, []int{0, 1}, msg)
+	suite := groupPubKey.Suite()
+
+	_, err := signer.AggregateSignatures(nil, sigs, pubKeys)
+	require.Equal(t, crypto.ErrNilSuite, err)
+	_, err = signer.AggregateSignatures(suite, nil, pubKeys)
+	require.Equal(t, crypto.ErrNilSignaturesList, err)
+	_, err = signer.AggregateSignatures(suite, sigs, nil)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+	_, err = signer.AggregateSignatures(suite, sigs, pubKeys[:1])
+	require.Equal(t, crypto.ErrInvalidParam, err)
+	_, err = signer.AggregateSignatures(createMockSuite("invalid suite"), sigs, pubKeys)
+	require.Equal(t, crypto.ErrInvalidSuite, err)
+}
+
+func TestBlsThresholdSigner_VerifyAggregatedSigWrongKeyOrMessageShouldErr(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 2, 3)
+	signer, _ := multisig.NewBlsThresholdSigner(2, sharePubKeys)
+	suite := groupPubKey.Suite()
+	sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, []int{1, 2}, msg)
+	aggSig, err := signer.AggregateSignatures(suite, sigs, pubKeys)
+	require.Nil(t, err)
+
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{sharePubKeys[0]}, aggSig, msg)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey}, aggSig, []byte("other message"))
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey, groupPubKey}, aggSig, msg)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+}
+
+func TestBlsThresholdSigner_AggregateVerifiedSignaturesShouldSkipInvalidShares(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	groupPubKey, sharePrivKeys, sharePubKeys := createThresholdKeys(t, 2, 3)
+	signer, _ := multisig.NewBlsThresholdSigner(2, sharePubKeys)
+	suite := groupPubKey.Suite()
+	sigs, pubKeys := signThresholdShares(t, signer, sharePrivKeys, sharePubKeys, []int{0, 1, 2}, msg)
+
+	// the share of the first signer is replaced by a valid signature over another message
+	sigs[0], _ = signer.SignShare(sharePrivKeys[0], []byte("other message"))
+	require.NotNil(t, signer.VerifySigShare(pubKeys[0], msg, sigs[0]))
+
+	aggSig, err := signer.AggregateSignatures(suite, sigs, pubKeys)
+	require.Nil(t, err)
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey}, aggSig, msg)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	aggSig, err = signer.AggregateVerifiedSignatures(suite, msg, sigs, pubKeys)
+	require.Nil(t, err)
+	err = signer.VerifyAggregatedSig(suite, []crypto.PublicKey{groupPubKey}, aggSig, msg)
+	require.Nil(t, err)
+
+	aggSig, err = signer.AggregateVerifiedSignatures(suite, msg, sigs[:2], pubKeys[:2])
+	require.Nil(t, aggSig)
+	require.Equal(t, crypto.ErrNotEnoughSignatureShares, err)
+
+	aggSig, err = signer.AggregateVerifiedSignatures(suite, nil, sigs, pubKeys)
+	require.Nil(t, aggSig)
+	require.Equal(t, crypto.ErrNilMessage, err)
+}
+
+func TestSplitSecret_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	secret := mcl.NewScalar()
+
+	shares, err := multisig.SplitSecret(nil, 2, 3)
+	require.Nil(t, shares)
+	require.Equal(t, crypto.ErrNilParam, err)
+
+	shares, err = multisig.SplitSecret(secret, 0, 3)
+	require.Nil(t, shares)
+	require.Equal(t, crypto.ErrInvalidThreshold, err)
+
+	shares, err = multisig.SplitSecret(secret, 4, 3)
+	require.Nil(t, shares)
+	require.Equal(t, crypto.ErrInvalidThreshold, err)
+}
+
+func TestEvaluatePolynomial_InvalidCoefficientsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	value, err := multisig.EvaluatePolynomial(nil, 1)
+	require.Nil(t, value)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	value, err = multisig.EvaluatePolynomial([]crypto.Scalar{}, 1)
+	require.Nil(t, value)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	value, err = multisig.EvaluatePolynomial([]crypto.Scalar{mcl.NewScalar(), nil}, 1)
+	require.Nil(t, value)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+}
+
+func TestLagrangeCoefficients_ShouldRecoverSecret(t *testing.T) {
+	t.Parallel()
+
+	secret := mcl.NewScalar()
+	shares, err := multisig.SplitSecret(secret, 3, 6)
+	require.Nil(t, err)
+
+	ids := []uint16{2, 5, 6}
+	coefficients, err := multisig.LagrangeCoefficients(ids)
+	require.Nil(t, err)
+
+	recovered := secret.Zero()
+	for i, id := range ids {
+		term, _ := coefficients[i].Mul(shares[id-1])
+		recovered, _ = recovered.Add(term)
+	}
+
+	equal, _ := recovered.Equal(secret)
+	require.True(t, equal)
+}
+
+func TestLagrangeCoefficients_InvalidIdsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := multisig.LagrangeCoefficients(nil)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	_, err = multisig.LagrangeCoefficients([]uint16{1, 0})
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	_, err = multisig.LagrangeCoefficients([]uint16{1, 2, 1})
+	require.Equal(t, crypto.ErrDuplicateIndex, err)
+}
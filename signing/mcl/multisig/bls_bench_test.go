@@ -198,3 +198,121 @@ func benchmarkHashPublicKeyPoints(nPubKeys int, b *testing.B) {
 		require.NotNil(b, hash)
 	}
 }
+
+func Benchmark_AggregatedSigWithCache63(b *testing.B) {
+	benchmarkAggregatedSigWithCache(63, b)
+}
+
+func Benchmark_AggregatedSigWithCache400(b *testing.B) {
+	benchmarkAggregatedSigWithCache(400, b)
+}
+
+func benchmarkAggregatedSigWithCache(nPubKeys uint16, b *testing.B) {
+	msg := []byte(testMessage)
+
+	hasher, err := blake2b.NewBlake2bWithSize(blsHashSize)
+	require.Nil(b, err)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(b, err)
+	pubKeys, sigShares := createSigSharesBLS(nPubKeys, msg, llSig)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+		require.Nil(b, err)
+	}
+}
+
+func Benchmark_VerifyAggregatedSigWithCache63(b *testing.B) {
+	benchmarkVerifyAggregatedSigWithCache(63, b)
+}
+
+func Benchmark_VerifyAggregatedSigWithCache400(b *testing.B) {
+	benchmarkVerifyAggregatedSigWithCache(400, b)
+}
+
+func benchmarkVerifyAggregatedSigWithCache(nPubKeys uint16, b *testing.B) {
+	msg := []byte(testMessage)
+
+	hasher, err := blake2b.NewBlake2bWithSize(blsHashSize)
+	require.Nil(b, err)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(b, err)
+	pubKeys, sigShares := createSigSharesBLS(nPubKeys, msg, llSig)
+	aggSigBytes, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+	require.Nil(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, aggSigBytes, msg)
+		require.Nil(b, err)
+	}
+}
+
+func Benchmark_HashPublicKeyBinary63(b *testing.B) {
+	benchmarkHashPublicKeyBinary(63, b)
+}
+
+func Benchmark_HashPublicKeyBinary400(b *testing.B) {
+	benchmarkHashPublicKeyBinary(400, b)
+}
+
+func benchmarkHashPublicKeyBinary(nPubKeys int, b *testing.B) {
+	hasher, err := blake2b.NewBlake2bWithSize(blsHashSize)
+	require.Nil(b, err)
+
+	pubKeys := createBLSPubKeys(nPubKeys)
+	concatPubKeys, err := multisig.ConcatPubKeys(pubKeys)
+	require.Nil(b, err)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pubKeyBytes, err := pubKeys[0].Point().MarshalBinary()
+		require.Nil(b, err)
+		hash, err := multisig.HashPublicKey(hasher, pubKeyBytes, concatPubKeys)
+		require.Nil(b, err)
+		require.NotNil(b, hash)
+	}
+}
+
+func Benchmark_AggregatedSigWithCacheVaryingBitmaps63(b *testing.B) {
+	benchmarkAggregatedSigWithCacheVaryingBitmaps(63, b)
+}
+
+func Benchmark_AggregatedSigWithCacheVaryingBitmaps400(b *testing.B) {
+	benchmarkAggregatedSigWithCacheVaryingBitmaps(400, b)
+}
+
+// benchmarkAggregatedSigWithCacheVaryingBitmaps aggregates each time for another signers set, one signer missing
+// from the group in turn, so the cache holding 2 sets misses on every aggregation
+func benchmarkAggregatedSigWithCacheVaryingBitmaps(nPubKeys uint16, b *testing.B) {
+	msg := []byte(testMessage)
+
+	hasher, err := blake2b.NewBlake2bWithSize(blsHashSize)
+	require.Nil(b, err)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(b, err)
+	pubKeys, sigShares := createSigSharesBLS(nPubKeys, msg, llSig)
+
+	signersPubKeys := make([][]crypto.PublicKey, nPubKeys)
+	signersSigShares := make([][][]byte, nPubKeys)
+	for missing := range pubKeys {
+		signersPubKeys[missing] = make([]crypto.PublicKey, 0, len(pubKeys)-1)
+		signersSigShares[missing] = make([][]byte, 0, len(pubKeys)-1)
+		for i := range pubKeys {
+			if i == missing {
+				continue
+			}
+
+			signersPubKeys[missing] = append(signersPubKeys[missing], pubKeys[i])
+			signersSigShares[missing] = append(signersSigShares[missing], sigShares[i])
+		}
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		missing := i % int(nPubKeys)
+		_, err := llSig.AggregateSignatures(pubKeys[0].Suite(), signersSigShares[missing], signersPubKeys[missing])
+		require.Nil(b, err)
+	}
+}
@@ -1,6 +1,7 @@
 package multisig_test
 
 import (
+	"encoding/hex"
 	"testing"
 
 	"github.com/ME-MotherEarth/me-core/core/check"
@@ -583,3 +584,95 @@ func TestBlsMultiSigner_IsInterfaceNil(t *testing.T) {
 
 	require.False(t, check.IfNil(llSig))
 }
+
+func createGoldenSigSharesBLS(t *testing.T, llSig crypto.LowLevelSignerBLS) ([]crypto.PublicKey, [][]byte) {
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	pubKeys := make([]crypto.PublicKey, 4)
+	sigShares := make([][]byte, 4)
+	for i := range pubKeys {
+		scalar := mcl.NewScalar()
+		scalar.SetInt64(int64(1000003 * (i + 1)))
+		scalarBytes, err := scalar.MarshalBinary()
+		require.Nil(t, err)
+
+		privKey, err := kg.PrivateKeyFromByteArray(scalarBytes)
+		require.Nil(t, err)
+		pubKeys[i] = privKey.GeneratePublic()
+
+		sigShares[i], err = llSig.SignShare(privKey, []byte(testMessage))
+		require.Nil(t, err)
+	}
+
+	return pubKeys, sigShares
+}
+
+func TestBlsMultiSigner_HexStringEncodingGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	// vectors created before the binary encoding was introduced, which must be reproduced byte for byte
+	expectedHashes := []string{
+		"00000000000000000000000000000000904ee55621334cad42695a8c1028831b",
+		"0000000000000000000000000000000043a30a1a43ec335ca4f0d0edbc072642",
+		"00000000000000000000000000000000122edf43dbb3bf81c1319e5c51fa3aef",
+		"0000000000000000000000000000000008d718ab88b4378b98b312b18ddb2a11",
+	}
+	expectedAggSig := "a685d4cbdef73ef695fa16564fbd141c32757517069a9aae3631f5179cb0e7ba2ecbd06bf16746b79cf4f303c1a18b92"
+
+	hasher := &mock.HasherSpongeMock{}
+	cachedLlSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingHexString, 1)
+	require.Nil(t, err)
+	llSigners := []*multisig.BlsMultiSigner{
+		{Hasher: hasher},
+		{Hasher: hasher, PubKeyEncoding: multisig.PubKeyEncodingHexString},
+		cachedLlSig,
+	}
+
+	for _, llSig := range llSigners {
+		pubKeys, sigShares := createGoldenSigSharesBLS(t, llSig)
+		concatPubKeys, err := multisig.ConcatPubKeys(pubKeys)
+		require.Nil(t, err)
+		for i, pubKey := range pubKeys {
+			hash, err := multisig.HashPublicKeyPoints(hasher, pubKey.Point(), concatPubKeys)
+			require.Nil(t, err)
+			require.Equal(t, expectedHashes[i], hex.EncodeToString(hash))
+		}
+
+		aggSig, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+		require.Nil(t, err)
+		require.Equal(t, expectedAggSig, hex.EncodeToString(aggSig))
+
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, aggSig, []byte(testMessage))
+		require.Nil(t, err)
+	}
+}
+
+func TestBlsMultiSigner_BinaryEncodingGoldenVectors(t *testing.T) {
+	t.Parallel()
+
+	expectedAggSig := "ac3022613c3fc3593c8249ff81ac6637e762256698ac9c3d450f33ff697b6e6cd826eeed1bb754a0fd99b3b879e78e93"
+	expectedLegacyAggSig := "a685d4cbdef73ef695fa16564fbd141c32757517069a9aae3631f5179cb0e7ba2ecbd06bf16746b79cf4f303c1a18b92"
+
+	hasher := &mock.HasherSpongeMock{}
+	cachedLlSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingBinary, 1)
+	require.Nil(t, err)
+	llSigners := []*multisig.BlsMultiSigner{
+		{Hasher: hasher, PubKeyEncoding: multisig.PubKeyEncodingBinary},
+		cachedLlSig,
+	}
+
+	for _, llSig := range llSigners {
+		pubKeys, sigShares := createGoldenSigSharesBLS(t, llSig)
+
+		aggSig, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+		require.Nil(t, err)
+		require.Equal(t, expectedAggSig, hex.EncodeToString(aggSig))
+
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, aggSig, []byte(testMessage))
+		require.Nil(t, err)
+
+		// the aggregated signatures of the two encodings are not interchangeable
+		legacyAggSig, _ := hex.DecodeString(expectedLegacyAggSig)
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), pubKeys, legacyAggSig, []byte(testMessage))
+		require.Equal(t, crypto.ErrAggSigNotValid, err)
+	}
+}
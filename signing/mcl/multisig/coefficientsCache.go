@@ -0,0 +1,105 @@
+package multisig
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// preparedPubKeys holds the modified BLS coefficients t_i = H(pk_i, {pk_1, ..., pk_n}) computed for an ordered set
+// of public keys, together with the pre-multiplied public keys t_i*pk_i. Once created it is only read
+type preparedPubKeys struct {
+	coefficients []crypto.Scalar
+	pubKeys      []bls.PublicKey
+}
+
+type coefficientsEntry struct {
+	key      [sha256.Size]byte
+	prepared *preparedPubKeys
+}
+
+/*
+coefficientsCache is a bounded, concurrent safe, least recently used cache of prepared public key sets, keyed by the
+hash of the ordered public keys.
+
+The coefficients depend on the whole set of the signers, not only on the consensus group, so an entry can not be
+reused for another signers bitmap. The cache hits when the same signers set is used more than once, as when the
+aggregated signature is verified by the node that aggregated it, when the whole group signs, or when the signers
+set repeats across the rounds of a stable validator set. Bitmaps varying from one round to the other mostly miss,
+each miss costing only the hashing of the concatenated public keys on top of computing the coefficients, as shown by
+Benchmark_AggregatedSigWithCacheVaryingBitmaps
+*/
+type coefficientsCache struct {
+	mut      sync.Mutex
+	capacity int
+	items    map[[sha256.Size]byte]*list.Element
+	order    *list.List
+}
+
+func newCoefficientsCache(capacity int) (*coefficientsCache, error) {
+	if capacity <= 0 {
+		return nil, crypto.ErrInvalidCacheCapacity
+	}
+
+	return &coefficientsCache{
+		capacity: capacity,
+		items:    make(map[[sha256.Size]byte]*list.Element, capacity),
+		order:    list.New(),
+	}, nil
+}
+
+// get returns the prepared public keys for the given concatenation of public keys, marking them as the most
+// recently used
+func (cc *coefficientsCache) get(concatPKs []byte) (*preparedPubKeys, bool) {
+	key := sha256.Sum256(concatPKs)
+
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	element, exists := cc.items[key]
+	if !exists {
+		return nil, false
+	}
+
+	cc.order.MoveToFront(element)
+
+	return element.Value.(*coefficientsEntry).prepared, true
+}
+
+// put adds the prepared public keys for the given concatenation of public keys, evicting the least recently used
+// entry if the capacity is exceeded
+func (cc *coefficientsCache) put(concatPKs []byte, prepared *preparedPubKeys) {
+	key := sha256.Sum256(concatPKs)
+
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	element, exists := cc.items[key]
+	if exists {
+		element.Value.(*coefficientsEntry).prepared = prepared
+		cc.order.MoveToFront(element)
+		return
+	}
+
+	cc.items[key] = cc.order.PushFront(&coefficientsEntry{
+		key:      key,
+		prepared: prepared,
+	})
+	if cc.order.Len() <= cc.capacity {
+		return
+	}
+
+	oldest := cc.order.Back()
+	cc.order.Remove(oldest)
+	delete(cc.items, oldest.Value.(*coefficientsEntry).key)
+}
+
+func (cc *coefficientsCache) len() int {
+	cc.mut.Lock()
+	defer cc.mut.Unlock()
+
+	return cc.order.Len()
+}
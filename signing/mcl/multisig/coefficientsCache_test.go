@@ -0,0 +1,196 @@
+package multisig_test
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+type countingHasher struct {
+	mock.HasherSpongeMock
+	numCalls uint32
+}
+
+func (ch *countingHasher) Compute(s string) []byte {
+	atomic.AddUint32(&ch.numCalls, 1)
+
+	return ch.HasherSpongeMock.Compute(s)
+}
+
+func TestNewBlsMultiSignerWithCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil hasher should err", func(t *testing.T) {
+		llSig, err := multisig.NewBlsMultiSignerWithCache(nil, multisig.PubKeyEncodingHexString, 2)
+		require.Nil(t, llSig)
+		require.Equal(t, crypto.ErrNilHasher, err)
+	})
+	t.Run("wrong size hasher should err", func(t *testing.T) {
+		llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherMock{}, multisig.PubKeyEncodingHexString, 2)
+		require.Nil(t, llSig)
+		require.Equal(t, crypto.ErrWrongSizeHasher, err)
+	})
+	t.Run("invalid encoding should err", func(t *testing.T) {
+		llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingBinary+1, 2)
+		require.Nil(t, llSig)
+		require.Equal(t, crypto.ErrInvalidParam, err)
+	})
+	t.Run("invalid capacity should err", func(t *testing.T) {
+		llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 0)
+		require.Nil(t, llSig)
+		require.Equal(t, crypto.ErrInvalidCacheCapacity, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 2)
+		require.Nil(t, err)
+		require.False(t, check.IfNil(llSig))
+		require.Equal(t, 0, llSig.CachedPubKeySetsLen())
+	})
+}
+
+func TestBlsMultiSignerWithCache_ShouldReuseCoefficients(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	hasher := &countingHasher{}
+	llSig, err := multisig.NewBlsMultiSignerWithCache(hasher, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(t, err)
+	pubKeys, sigShares := createSigSharesBLS(20, msg, llSig)
+	suite := pubKeys[0].Suite()
+
+	aggSig, err := llSig.AggregateSignatures(suite, sigShares, pubKeys)
+	require.Nil(t, err)
+	require.Equal(t, uint32(len(pubKeys)), atomic.LoadUint32(&hasher.numCalls))
+	require.True(t, llSig.IsPubKeySetCached(pubKeys))
+
+	err = llSig.VerifyAggregatedSig(suite, pubKeys, aggSig, msg)
+	require.Nil(t, err)
+	aggSig2, err := llSig.AggregateSignatures(suite, sigShares, pubKeys)
+	require.Nil(t, err)
+	require.Equal(t, aggSig, aggSig2)
+	require.Equal(t, uint32(len(pubKeys)), atomic.LoadUint32(&hasher.numCalls))
+
+	err = llSig.VerifyAggregatedSig(suite, pubKeys, aggSig, []byte("message2"))
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+
+	// the results should match the ones of the signer without cache
+	noCacheLlSig := &multisig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	aggSigNoCache, err := noCacheLlSig.AggregateSignatures(suite, sigShares, pubKeys)
+	require.Nil(t, err)
+	require.Equal(t, aggSigNoCache, aggSig)
+	require.Equal(t, 0, noCacheLlSig.CachedPubKeySetsLen())
+}
+
+func TestBlsMultiSignerWithCache_DifferentOrderShouldBeDifferentSet(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(t, err)
+	pubKeys, sigShares := createSigSharesBLS(5, msg, llSig)
+	suite := pubKeys[0].Suite()
+
+	aggSig, err := llSig.AggregateSignatures(suite, sigShares, pubKeys)
+	require.Nil(t, err)
+
+	pubKeys[0], pubKeys[1] = pubKeys[1], pubKeys[0]
+	require.False(t, llSig.IsPubKeySetCached(pubKeys))
+
+	err = llSig.VerifyAggregatedSig(suite, pubKeys, aggSig, msg)
+	require.Equal(t, crypto.ErrAggSigNotValid, err)
+	require.Equal(t, 2, llSig.CachedPubKeySetsLen())
+}
+
+func TestBlsMultiSignerWithCache_ShouldEvictLeastRecentlyUsedSet(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(t, err)
+
+	pubKeys1, sigShares1 := createSigSharesBLS(5, msg, llSig)
+	pubKeys2, sigShares2 := createSigSharesBLS(5, msg, llSig)
+	pubKeys3, sigShares3 := createSigSharesBLS(5, msg, llSig)
+	suite := pubKeys1[0].Suite()
+
+	_, err = llSig.AggregateSignatures(suite, sigShares1, pubKeys1)
+	require.Nil(t, err)
+	_, err = llSig.AggregateSignatures(suite, sigShares2, pubKeys2)
+	require.Nil(t, err)
+	// set 1 becomes the most recently used one
+	_, err = llSig.AggregateSignatures(suite, sigShares1, pubKeys1)
+	require.Nil(t, err)
+	_, err = llSig.AggregateSignatures(suite, sigShares3, pubKeys3)
+	require.Nil(t, err)
+
+	require.Equal(t, 2, llSig.CachedPubKeySetsLen())
+	require.True(t, llSig.IsPubKeySetCached(pubKeys1))
+	require.False(t, llSig.IsPubKeySetCached(pubKeys2))
+	require.True(t, llSig.IsPubKeySetCached(pubKeys3))
+}
+
+func TestBlsMultiSignerWithCache_InvalidSetShouldNotBeCached(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 2)
+	require.Nil(t, err)
+	pubKeys, sigShares := createSigSharesBLS(5, msg, llSig)
+	aggSig, err := llSig.AggregateSignatures(pubKeys[0].Suite(), sigShares, pubKeys)
+	require.Nil(t, err)
+
+	otherPubKeys, _ := createSigSharesBLS(5, msg, llSig)
+	otherPubKeys[2] = &mock.PublicKeyStub{
+		PointStub: func() crypto.Point {
+			return &mock.PointMock{
+				MarshalBinaryStub: func(x, y int) (bytes []byte, err error) {
+					return []byte("invalid key"), nil
+				},
+			}
+		},
+	}
+
+	for i := 0; i < 2; i++ {
+		err = llSig.VerifyAggregatedSig(pubKeys[0].Suite(), otherPubKeys, aggSig, msg)
+		require.Equal(t, crypto.ErrInvalidPublicKey, err)
+	}
+	require.Equal(t, 1, llSig.CachedPubKeySetsLen())
+}
+
+func TestBlsMultiSignerWithCache_ConcurrentAccess(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte(testMessage)
+	llSig, err := multisig.NewBlsMultiSignerWithCache(&mock.HasherSpongeMock{}, multisig.PubKeyEncodingHexString, 1)
+	require.Nil(t, err)
+	pubKeys1, sigShares1 := createSigSharesBLS(5, msg, llSig)
+	pubKeys2, sigShares2 := createSigSharesBLS(5, msg, llSig)
+	suite := pubKeys1[0].Suite()
+
+	numCalls := 20
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			pubKeys, sigShares := pubKeys1, sigShares1
+			if idx%2 == 0 {
+				pubKeys, sigShares = pubKeys2, sigShares2
+			}
+
+			aggSig, errAgg := llSig.AggregateSignatures(suite, sigShares, pubKeys)
+			require.Nil(t, errAgg)
+			require.Nil(t, llSig.VerifyAggregatedSig(suite, pubKeys, aggSig, msg))
+		}(i)
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, llSig.CachedPubKeySetsLen())
+}
@@ -46,3 +46,30 @@ func PubKeyCryptoToBLS(pubKey crypto.PublicKey) (*bls.PublicKey, error) {
 func PubKeysCryptoToBLS(pubKeys []crypto.PublicKey) ([]bls.PublicKey, error) {
 	return pubKeysCryptoToBLS(pubKeys)
 }
+
+func (bms *BlsMultiSigner) CachedPubKeySetsLen() int {
+	if bms.cache == nil {
+		return 0
+	}
+
+	return bms.cache.len()
+}
+
+func (bms *BlsMultiSigner) IsPubKeySetCached(pubKeys []crypto.PublicKey) bool {
+	concatPKs, err := concatPubKeys(pubKeys)
+	if err != nil || bms.cache == nil {
+		return false
+	}
+
+	_, found := bms.cache.get(concatPKs)
+
+	return found
+}
+
+func CreateScalar(suite crypto.Suite, scalarBytes []byte) (crypto.Scalar, error) {
+	return createScalar(suite, scalarBytes)
+}
+
+func HashPublicKey(hasher hashing.Hasher, encodedPubKey []byte, concatPubKeys []byte) ([]byte, error) {
+	return hashPublicKey(hasher, encodedPubKey, concatPubKeys)
+}
@@ -48,12 +48,7 @@ func (po *PointG1) Clone() crypto.Point {
 	po2 := PointG1{
 		G1: &bls.G1{},
 	}
-
-	strPo := po.G1.GetString(16)
-	err := po2.G1.SetString(strPo, 16)
-	if err != nil {
-		log.Error("PointG1 Clone", "error", err.Error())
-	}
+	*po2.G1 = *po.G1
 
 	return &po2
 }
@@ -80,8 +75,9 @@ func (po *PointG1) Set(p crypto.Point) error {
 		return crypto.ErrInvalidParam
 	}
 
-	strPo := po1.G1.GetString(16)
-	return po.G1.SetString(strPo, 16)
+	*po.G1 = *po1.G1
+
+	return nil
 }
 
 // Add returns the result of adding receiver with Point p given as parameter,
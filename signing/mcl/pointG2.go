@@ -48,12 +48,7 @@ func (po *PointG2) Clone() crypto.Point {
 	po2 := PointG2{
 		G2: &bls.G2{},
 	}
-
-	strPo := po.G2.GetString(16)
-	err := po2.G2.SetString(strPo, 16)
-	if err != nil {
-		log.Error("PointG2 Clone", "error", err.Error())
-	}
+	*po2.G2 = *po.G2
 
 	return &po2
 }
@@ -80,8 +75,9 @@ func (po *PointG2) Set(p crypto.Point) error {
 		return crypto.ErrInvalidParam
 	}
 
-	strPo := po1.G2.GetString(16)
-	return po.G2.SetString(strPo, 16)
+	*po.G2 = *po1.G2
+
+	return nil
 }
 
 // Add returns the result of adding receiver with Point p given as parameter,
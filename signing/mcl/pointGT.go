@@ -40,12 +40,7 @@ func (po *PointGT) Clone() crypto.Point {
 	po2 := PointGT{
 		GT: &bls.GT{},
 	}
-
-	strPo := po.GT.GetString(16)
-	err := po2.SetString(strPo, 16)
-	if err != nil {
-		log.Error("PointGT Clone", "error", err.Error())
-	}
+	*po2.GT = *po.GT
 
 	return &po2
 }
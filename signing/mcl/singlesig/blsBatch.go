@@ -0,0 +1,151 @@
+package singlesig
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+type batchEntry struct {
+	index   int
+	pubKey  bls.G2
+	msgHash bls.G1
+	sig     bls.G1
+}
+
+/*
+VerifyBatch verifies many independent (public key, message, signature) triples at once.
+
+Instead of checking e(sig_i, G2) == e(H(m_i), pk_i) for every triple, which costs two pairings each, the triples are
+combined with random coefficients r_i into a single multi-pairing:
+
+	e(-sum(r_i * sig_i), G2) * prod(e(r_i * H(m_i), pk_i)) == 1
+
+The random coefficients prevent invalid signatures from canceling each other out. If the combined check fails, the
+set is split in halves which are checked recursively, so that the offending triples are located with a number of
+multi-pairings logarithmic in the size of the set for each invalid signature.
+
+The returned error is a *crypto.InvalidSignaturesError wrapping crypto.ErrSigNotValid if any of the signatures is
+malformed or invalid, holding the positions of all the offending signatures
+*/
+func (s *BlsSingleSigner) VerifyBatch(pubKeys []crypto.PublicKey, msgs [][]byte, sigs [][]byte) error {
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(msgs) != len(pubKeys) || len(sigs) != len(pubKeys) {
+		return crypto.ErrInvalidParam
+	}
+
+	entries := make([]*batchEntry, 0, len(pubKeys))
+	invalidIndexes := make([]int, 0)
+	for i := range pubKeys {
+		entry, err := createBatchEntry(i, pubKeys[i], msgs[i], sigs[i])
+		if err == crypto.ErrBLSInvalidSignature {
+			invalidIndexes = append(invalidIndexes, i)
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("%w at index %d", err, i)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	invalidIndexes = append(invalidIndexes, findInvalidEntries(entries)...)
+	if len(invalidIndexes) == 0 {
+		return nil
+	}
+
+	sort.Ints(invalidIndexes)
+
+	return &crypto.InvalidSignaturesError{
+		Indexes: invalidIndexes,
+		Err:     crypto.ErrSigNotValid,
+	}
+}
+
+func createBatchEntry(index int, public crypto.PublicKey, msg []byte, sig []byte) (*batchEntry, error) {
+	if check.IfNil(public) {
+		return nil, crypto.ErrNilPublicKey
+	}
+	if len(msg) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+	if len(sig) == 0 {
+		return nil, crypto.ErrNilSignature
+	}
+
+	point := public.Point()
+	if check.IfNil(point) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG2)
+	if !isPoint || !IsPubKeyPointValid(pubKeyPoint) {
+		return nil, crypto.ErrInvalidPublicKey
+	}
+
+	signature := &bls.Sign{}
+	err := signature.Deserialize(sig)
+	if err != nil || !IsSigValidPoint(signature) {
+		return nil, crypto.ErrBLSInvalidSignature
+	}
+
+	entry := &batchEntry{
+		index:  index,
+		pubKey: *pubKeyPoint.G2,
+		sig:    *bls.CastFromSign(signature),
+	}
+
+	err = entry.msgHash.HashAndMapTo(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// findInvalidEntries returns the indexes of the invalid entries, bisecting the set until they are isolated
+func findInvalidEntries(entries []*batchEntry) []int {
+	if len(entries) == 0 || verifyBatchEntries(entries) {
+		return nil
+	}
+	if len(entries) == 1 {
+		return []int{entries[0].index}
+	}
+
+	half := len(entries) / 2
+	invalidIndexes := findInvalidEntries(entries[:half])
+
+	return append(invalidIndexes, findInvalidEntries(entries[half:])...)
+}
+
+func verifyBatchEntries(entries []*batchEntry) bool {
+	numEntries := len(entries)
+	g1Points := make([]bls.G1, numEntries+1)
+	g2Points := make([]bls.G2, numEntries+1)
+	sigPoints := make([]bls.G1, numEntries)
+	coefficients := make([]bls.Fr, numEntries)
+
+	for i, entry := range entries {
+		coefficients[i].SetByCSPRNG()
+		bls.G1Mul(&g1Points[i], &entry.msgHash, &coefficients[i])
+		g2Points[i] = entry.pubKey
+		sigPoints[i] = entry.sig
+	}
+
+	aggSig := &bls.G1{}
+	bls.G1MulVec(aggSig, sigPoints, coefficients)
+	bls.G1Neg(&g1Points[numEntries], aggSig)
+	g2Points[numEntries] = *mcl.NewPointG2().G2
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+
+	return gt.IsOne()
+}
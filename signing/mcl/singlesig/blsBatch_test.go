@@ -0,0 +1,138 @@
+package singlesig_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/herumi/bls-go-binary/bls"
+	"github.com/stretchr/testify/require"
+)
+
+func createBatch(t testing.TB, size int) ([]crypto.PublicKey, [][]byte, [][]byte) {
+	signer := singlesig.NewBlsSigner()
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+
+	pubKeys := make([]crypto.PublicKey, size)
+	msgs := make([][]byte, size)
+	sigs := make([][]byte, size)
+	for i := 0; i < size; i++ {
+		privKey, pubKey := kg.GeneratePair()
+		pubKeys[i] = pubKey
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+
+		sig, err := signer.Sign(privKey, msgs[i])
+		require.Nil(t, err)
+		sigs[i] = sig
+	}
+
+	return pubKeys, msgs, sigs
+}
+
+func requireInvalidIndexes(t *testing.T, err error, expectedIndexes []int) {
+	require.True(t, errors.Is(err, crypto.ErrSigNotValid))
+
+	invalidSigsErr := &crypto.InvalidSignaturesError{}
+	require.True(t, errors.As(err, &invalidSigsErr))
+	require.Equal(t, expectedIndexes, invalidSigsErr.Indexes)
+}
+
+func TestBLSSigner_VerifyBatchInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(t, 3)
+
+	err := signer.VerifyBatch(nil, msgs, sigs)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+
+	err = signer.VerifyBatch(pubKeys, msgs[:2], sigs)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	err = signer.VerifyBatch(pubKeys, msgs, sigs[:2])
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	err = signer.VerifyBatch([]crypto.PublicKey{pubKeys[0], nil, pubKeys[2]}, msgs, sigs)
+	require.True(t, errors.Is(err, crypto.ErrNilPublicKey))
+	require.Contains(t, err.Error(), "index 1")
+
+	err = signer.VerifyBatch(pubKeys, [][]byte{msgs[0], msgs[1], nil}, sigs)
+	require.True(t, errors.Is(err, crypto.ErrNilMessage))
+	require.Contains(t, err.Error(), "index 2")
+
+	err = signer.VerifyBatch(pubKeys, msgs, [][]byte{nil, sigs[1], sigs[2]})
+	require.True(t, errors.Is(err, crypto.ErrNilSignature))
+	require.Contains(t, err.Error(), "index 0")
+}
+
+func TestBLSSigner_VerifyBatchOK(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+
+	pubKeys, msgs, sigs := createBatch(t, 1)
+	err := signer.VerifyBatch(pubKeys, msgs, sigs)
+	require.Nil(t, err)
+
+	pubKeys, msgs, sigs = createBatch(t, 17)
+	err = signer.VerifyBatch(pubKeys, msgs, sigs)
+	require.Nil(t, err)
+}
+
+func TestBLSSigner_VerifyBatchInvalidSignaturesShouldReturnIndexes(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(t, 16)
+
+	// signature of another message
+	sigs[3] = sigs[4]
+	// signature under another key
+	pubKeys[9], pubKeys[10] = pubKeys[10], pubKeys[9]
+	// message altered after signing
+	msgs[15] = []byte("altered message")
+
+	err := signer.VerifyBatch(pubKeys, msgs, sigs)
+	requireInvalidIndexes(t, err, []int{3, 9, 10, 15})
+}
+
+func TestBLSSigner_VerifyBatchMalformedSignaturesShouldReturnIndexes(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(t, 8)
+
+	sigs[6] = []byte("malformed signature")
+	msgs[2] = []byte("altered message")
+
+	err := signer.VerifyBatch(pubKeys, msgs, sigs)
+	requireInvalidIndexes(t, err, []int{2, 6})
+}
+
+func TestBLSSigner_VerifyBatchCancelingSignaturesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(t, 4)
+
+	// shift both signatures by the same offset in opposite directions, so that their sum stays the same
+	offset := &bls.G1{}
+	err := offset.HashAndMapTo([]byte("offset"))
+	require.Nil(t, err)
+
+	sig0 := &bls.G1{}
+	sig1 := &bls.G1{}
+	require.Nil(t, sig0.Deserialize(sigs[0]))
+	require.Nil(t, sig1.Deserialize(sigs[1]))
+	bls.G1Add(sig0, sig0, offset)
+	bls.G1Sub(sig1, sig1, offset)
+	sigs[0] = sig0.Serialize()
+	sigs[1] = sig1.Serialize()
+
+	err = signer.VerifyBatch(pubKeys, msgs, sigs)
+	requireInvalidIndexes(t, err, []int{0, 1})
+}
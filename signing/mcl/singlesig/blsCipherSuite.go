@@ -0,0 +1,248 @@
+package singlesig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// Ciphersuite identifiers, used as domain separation tags for hashing the messages, as defined in
+// draft-irtf-cfrg-bls-signature for the signatures on G1 variant. The POP ciphersuite with the signatures on G2, used
+// by Ethereum, is provided by BlsPopCipherSuiteSignerMinPk
+const (
+	// BasicCipherSuiteID is the ciphersuite where the messages are signed as they are
+	BasicCipherSuiteID = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_NUL_"
+	// AugCipherSuiteID is the ciphersuite where the signer public key is prepended to the messages
+	AugCipherSuiteID = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_AUG_"
+	// PopCipherSuiteID is the ciphersuite where the signers need to provide proofs of possession for their keys
+	PopCipherSuiteID = "BLS_SIG_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+	// PopProofDST is the domain separation tag used for the proofs of possession in the POP ciphersuite
+	PopProofDST = "BLS_POP_BLS12381G1_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var _ crypto.SingleSigner = (*BlsCipherSuiteSigner)(nil)
+var _ crypto.ProofOfPossessionSigner = (*BlsPopCipherSuiteSigner)(nil)
+
+/*
+BlsCipherSuiteSigner is a SingleSigner implementation of the BLS signature ciphersuites standardized in
+draft-irtf-cfrg-bls-signature, with signatures on G1 and public keys on G2. Only the signatures on G1 variant of the
+ciphersuites is implemented here, BlsPopCipherSuiteSignerMinPk providing the POP ciphersuite with signatures on G2.
+
+Unlike BlsSingleSigner, which relies on the hashing configured globally in the mcl library, the messages are hashed
+to G1 with hash_to_curve using the BLS12381G1_XMD:SHA-256_SSWU_RO_ suite and the ciphersuite identifier as the
+explicit domain separation tag. The signatures use the standard compressed serialization, and the public keys need
+to be derived with the standard G2 generator, as done by mcl.SuiteBLS12IETF, so that the signatures interoperate
+with other implementations of the standard.
+*/
+type BlsCipherSuiteSigner struct {
+	cipherSuiteID string
+	augmented     bool
+}
+
+// BlsPopCipherSuiteSigner is the signer for the POP ciphersuite, also able to create and verify proofs of possession
+type BlsPopCipherSuiteSigner struct {
+	BlsCipherSuiteSigner
+}
+
+// NewBlsBasicSigner creates a signer for the BASIC ciphersuite
+func NewBlsBasicSigner() *BlsCipherSuiteSigner {
+	return &BlsCipherSuiteSigner{
+		cipherSuiteID: BasicCipherSuiteID,
+	}
+}
+
+// NewBlsAugSigner creates a signer for the AUG ciphersuite
+func NewBlsAugSigner() *BlsCipherSuiteSigner {
+	return &BlsCipherSuiteSigner{
+		cipherSuiteID: AugCipherSuiteID,
+		augmented:     true,
+	}
+}
+
+// NewBlsPopSigner creates a signer for the POP ciphersuite
+func NewBlsPopSigner() *BlsPopCipherSuiteSigner {
+	return &BlsPopCipherSuiteSigner{
+		BlsCipherSuiteSigner: BlsCipherSuiteSigner{
+			cipherSuiteID: PopCipherSuiteID,
+		},
+	}
+}
+
+// CipherSuiteID returns the ciphersuite identifier, which is also the domain separation tag of the signatures
+func (s *BlsCipherSuiteSigner) CipherSuiteID() string {
+	return s.cipherSuiteID
+}
+
+// Sign signs a message with the ciphersuite, returning the compressed serialization of the signature
+func (s *BlsCipherSuiteSigner) Sign(private crypto.PrivateKey, msg []byte) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if len(msg) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+
+	if s.augmented {
+		augmentedMsg, err := s.augmentMessage(private.GeneratePublic(), msg)
+		if err != nil {
+			return nil, err
+		}
+		msg = augmentedMsg
+	}
+
+	return signWithDST(private, msg, []byte(s.cipherSuiteID))
+}
+
+// Verify verifies a signature created with the ciphersuite
+func (s *BlsCipherSuiteSigner) Verify(public crypto.PublicKey, msg []byte, sig []byte) error {
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+
+	if s.augmented {
+		augmentedMsg, err := s.augmentMessage(public, msg)
+		if err != nil {
+			return err
+		}
+		msg = augmentedMsg
+	}
+
+	return verifyWithDST(public, msg, sig, []byte(s.cipherSuiteID))
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *BlsCipherSuiteSigner) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// CreateProofOfPossession creates a proof of possession for the public key corresponding to the given private key,
+// by signing the compressed serialization of the public key with the proof of possession domain separation tag
+func (s *BlsPopCipherSuiteSigner) CreateProofOfPossession(private crypto.PrivateKey) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+
+	pubKeyBytes, err := compressedPubKey(private.GeneratePublic())
+	if err != nil {
+		return nil, err
+	}
+
+	return signWithDST(private, pubKeyBytes, []byte(PopProofDST))
+}
+
+// VerifyProofOfPossession verifies the proof of possession for the given public key
+func (s *BlsPopCipherSuiteSigner) VerifyProofOfPossession(public crypto.PublicKey, pop []byte) error {
+	pubKeyBytes, err := compressedPubKey(public)
+	if err != nil {
+		return err
+	}
+
+	err = verifyWithDST(public, pubKeyBytes, pop, []byte(PopProofDST))
+	if err == crypto.ErrSigNotValid {
+		return crypto.ErrPoPNotValid
+	}
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *BlsPopCipherSuiteSigner) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// augmentMessage prepends the compressed serialization of the public key to the message
+func (s *BlsCipherSuiteSigner) augmentMessage(public crypto.PublicKey, msg []byte) ([]byte, error) {
+	pubKeyBytes, err := compressedPubKey(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(pubKeyBytes, msg...), nil
+}
+
+func signWithDST(private crypto.PrivateKey, msg []byte, dst []byte) ([]byte, error) {
+	scalar := private.Scalar()
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+
+	mclScalar, ok := scalar.(*mcl.Scalar)
+	if !ok || !IsSecretKeyValid(mclScalar) {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	msgPoint, err := mcl.HashToG1(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &bls.G1{}
+	bls.G1Mul(sig, msgPoint.G1, mclScalar.Scalar)
+
+	return mcl.SerializeG1Compressed(sig), nil
+}
+
+// verifyWithDST checks that e(sig, G2) == e(H(msg), pk), with G2 being the standard generator
+func verifyWithDST(public crypto.PublicKey, msg []byte, sig []byte, dst []byte) error {
+	pubKeyPoint, err := validPubKeyPoint(public)
+	if err != nil {
+		return err
+	}
+	if len(sig) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	sigPoint, err := mcl.DeserializeG1Compressed(sig)
+	if err != nil || sigPoint.IsZero() || !sigPoint.IsValidOrder() {
+		return crypto.ErrBLSInvalidSignature
+	}
+
+	msgPoint, err := mcl.HashToG1(msg, dst)
+	if err != nil {
+		return err
+	}
+
+	g1Points := make([]bls.G1, 2)
+	g2Points := make([]bls.G2, 2)
+	bls.G1Neg(&g1Points[0], sigPoint)
+	g2Points[0] = *mcl.GeneratorG2IETF().G2
+	g1Points[1] = *msgPoint.G1
+	g2Points[1] = *pubKeyPoint.G2
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+	if !gt.IsOne() {
+		return crypto.ErrSigNotValid
+	}
+
+	return nil
+}
+
+func compressedPubKey(public crypto.PublicKey) ([]byte, error) {
+	pubKeyPoint, err := validPubKeyPoint(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcl.SerializeG2Compressed(pubKeyPoint.G2), nil
+}
+
+func validPubKeyPoint(public crypto.PublicKey) (*mcl.PointG2, error) {
+	if check.IfNil(public) {
+		return nil, crypto.ErrNilPublicKey
+	}
+
+	point := public.Point()
+	if check.IfNil(point) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG2)
+	if !isPoint || !IsPubKeyPointValid(pubKeyPoint) {
+		return nil, crypto.ErrInvalidPublicKey
+	}
+
+	return pubKeyPoint, nil
+}
@@ -0,0 +1,180 @@
+package singlesig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// Ciphersuite identifiers for the signatures on G2 variant of draft-irtf-cfrg-bls-signature
+const (
+	// PopMinPkCipherSuiteID is the POP ciphersuite with the public keys on G1 and the signatures on G2, as used by
+	// the Ethereum consensus specification
+	PopMinPkCipherSuiteID = "BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+	// PopMinPkProofDST is the domain separation tag used for the proofs of possession in the POP ciphersuite with
+	// the signatures on G2
+	PopMinPkProofDST = "BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_"
+)
+
+var _ crypto.SingleSigner = (*BlsPopCipherSuiteSignerMinPk)(nil)
+var _ crypto.ProofOfPossessionSigner = (*BlsPopCipherSuiteSignerMinPk)(nil)
+
+// BlsPopCipherSuiteSignerMinPk is the signer for the POP ciphersuite with the public keys on G1 and the signatures
+// on G2, to be used with the keys of mcl.SuiteBLS12MinPk. The messages are hashed to G2 with hash_to_curve using the
+// BLS12381G2_XMD:SHA-256_SSWU_RO_ suite, and both the public keys and the signatures use the standard compressed
+// serialization, so the signatures interoperate with the Ethereum consensus tooling
+type BlsPopCipherSuiteSignerMinPk struct {
+}
+
+// NewBlsPopSignerMinPk creates a signer for the POP ciphersuite with the signatures on G2
+func NewBlsPopSignerMinPk() *BlsPopCipherSuiteSignerMinPk {
+	return &BlsPopCipherSuiteSignerMinPk{}
+}
+
+// CipherSuiteID returns the ciphersuite identifier, which is also the domain separation tag of the signatures
+func (s *BlsPopCipherSuiteSignerMinPk) CipherSuiteID() string {
+	return PopMinPkCipherSuiteID
+}
+
+// Sign signs a message with the ciphersuite, returning the compressed serialization of the signature
+func (s *BlsPopCipherSuiteSignerMinPk) Sign(private crypto.PrivateKey, msg []byte) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if len(msg) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+
+	return signOnG2WithDST(private, msg, []byte(PopMinPkCipherSuiteID))
+}
+
+// Verify verifies a signature created with the ciphersuite
+func (s *BlsPopCipherSuiteSignerMinPk) Verify(public crypto.PublicKey, msg []byte, sig []byte) error {
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+
+	return verifyOnG2WithDST(public, msg, sig, []byte(PopMinPkCipherSuiteID))
+}
+
+// CreateProofOfPossession creates a proof of possession for the public key corresponding to the given private key,
+// by signing the compressed serialization of the public key with the proof of possession domain separation tag
+func (s *BlsPopCipherSuiteSignerMinPk) CreateProofOfPossession(private crypto.PrivateKey) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+
+	pubKeyBytes, err := compressedMinPkPubKey(private.GeneratePublic())
+	if err != nil {
+		return nil, err
+	}
+
+	return signOnG2WithDST(private, pubKeyBytes, []byte(PopMinPkProofDST))
+}
+
+// VerifyProofOfPossession verifies the proof of possession for the given public key
+func (s *BlsPopCipherSuiteSignerMinPk) VerifyProofOfPossession(public crypto.PublicKey, pop []byte) error {
+	pubKeyBytes, err := compressedMinPkPubKey(public)
+	if err != nil {
+		return err
+	}
+
+	err = verifyOnG2WithDST(public, pubKeyBytes, pop, []byte(PopMinPkProofDST))
+	if err == crypto.ErrSigNotValid {
+		return crypto.ErrPoPNotValid
+	}
+
+	return err
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *BlsPopCipherSuiteSignerMinPk) IsInterfaceNil() bool {
+	return s == nil
+}
+
+func signOnG2WithDST(private crypto.PrivateKey, msg []byte, dst []byte) ([]byte, error) {
+	scalar := private.Scalar()
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+
+	mclScalar, ok := scalar.(*mcl.Scalar)
+	if !ok || !IsSecretKeyValid(mclScalar) {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	msgPoint, err := mcl.HashToG2(msg, dst)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &bls.G2{}
+	bls.G2Mul(sig, msgPoint.G2, mclScalar.Scalar)
+
+	return mcl.SerializeG2Compressed(sig), nil
+}
+
+// verifyOnG2WithDST checks that e(G1, sig) == e(pk, H(msg)), with G1 being the standard generator
+func verifyOnG2WithDST(public crypto.PublicKey, msg []byte, sig []byte, dst []byte) error {
+	pubKeyPoint, err := validMinPkPubKeyPoint(public)
+	if err != nil {
+		return err
+	}
+	if len(sig) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	sigPoint, err := mcl.DeserializeG2Compressed(sig)
+	if err != nil || sigPoint.IsZero() || !sigPoint.IsValidOrder() {
+		return crypto.ErrBLSInvalidSignature
+	}
+
+	msgPoint, err := mcl.HashToG2(msg, dst)
+	if err != nil {
+		return err
+	}
+
+	g1Points := make([]bls.G1, 2)
+	g2Points := make([]bls.G2, 2)
+	bls.G1Neg(&g1Points[0], mcl.NewPointG1().G1)
+	g2Points[0] = *sigPoint
+	g1Points[1] = *pubKeyPoint.G1
+	g2Points[1] = *msgPoint.G2
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+	if !gt.IsOne() {
+		return crypto.ErrSigNotValid
+	}
+
+	return nil
+}
+
+func compressedMinPkPubKey(public crypto.PublicKey) ([]byte, error) {
+	pubKeyPoint, err := validMinPkPubKeyPoint(public)
+	if err != nil {
+		return nil, err
+	}
+
+	return mcl.SerializeG1Compressed(pubKeyPoint.G1), nil
+}
+
+func validMinPkPubKeyPoint(public crypto.PublicKey) (*mcl.PointG1, error) {
+	if check.IfNil(public) {
+		return nil, crypto.ErrNilPublicKey
+	}
+
+	point := public.Point()
+	if check.IfNil(point) {
+		return nil, crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG1)
+	if !isPoint || !IsMinPkPubKeyPointValid(pubKeyPoint) {
+		return nil, crypto.ErrInvalidPublicKey
+	}
+
+	return pubKeyPoint, nil
+}
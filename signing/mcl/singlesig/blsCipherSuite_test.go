@@ -0,0 +1,350 @@
+package singlesig_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/stretchr/testify/require"
+)
+
+func cipherSuiteSigners() map[string]crypto.SingleSigner {
+	return map[string]crypto.SingleSigner{
+		singlesig.BasicCipherSuiteID: singlesig.NewBlsBasicSigner(),
+		singlesig.AugCipherSuiteID:   singlesig.NewBlsAugSigner(),
+		singlesig.PopCipherSuiteID:   singlesig.NewBlsPopSigner(),
+	}
+}
+
+func TestBlsCipherSuiteSigner_SignVerifyOK(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+
+	for id, signer := range cipherSuiteSigners() {
+		require.False(t, check.IfNil(signer))
+
+		sig, err := signer.Sign(privKey, msg)
+		require.Nil(t, err, id)
+		require.Len(t, sig, 48)
+		// compressed serialization flag
+		require.Equal(t, byte(0x80), sig[0]&0x80)
+
+		err = signer.Verify(pubKey, msg, sig)
+		require.Nil(t, err, id)
+
+		err = signer.Verify(pubKey, []byte("another message"), sig)
+		require.Equal(t, crypto.ErrSigNotValid, err, id)
+	}
+}
+
+func TestBlsCipherSuiteSigner_SignaturesShouldBeSeparatedBySuite(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signers := cipherSuiteSigners()
+
+	for signingID, signingSigner := range signers {
+		sig, err := signingSigner.Sign(privKey, msg)
+		require.Nil(t, err)
+
+		for verifyingID, verifyingSigner := range signers {
+			err = verifyingSigner.Verify(pubKey, msg, sig)
+			if signingID == verifyingID {
+				require.Nil(t, err)
+				continue
+			}
+
+			require.Equal(t, crypto.ErrSigNotValid, err, "signed with %s, verified with %s", signingID, verifyingID)
+		}
+	}
+
+	// the signatures of the legacy signer are not valid for the ciphersuites
+	legacySig, err := singlesig.NewBlsSigner().Sign(privKey, msg)
+	require.Nil(t, err)
+	err = singlesig.NewBlsBasicSigner().Verify(pubKey, msg, legacySig)
+	require.NotNil(t, err)
+}
+
+func TestBlsCipherSuiteSigner_SignatureShouldBeTheHashToCurveScaledBySecretKey(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF())
+	// secret key equal to 1, serialized as little endian
+	oneBytes := make([]byte, 32)
+	oneBytes[0] = 1
+	privKey, err := kg.PrivateKeyFromByteArray(oneBytes)
+	require.Nil(t, err)
+
+	msg := []byte("abc")
+	signer := singlesig.NewBlsBasicSigner()
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+
+	msgPoint, err := mcl.HashToG1(msg, []byte(singlesig.BasicCipherSuiteID))
+	require.Nil(t, err)
+	require.Equal(t, mcl.SerializeG1Compressed(msgPoint.G1), sig)
+
+	// the public key of the secret key 1 is the standard generator
+	pubKey := privKey.GeneratePublic()
+	require.True(t, pubKey.Point().(*mcl.PointG2).IsEqual(mcl.GeneratorG2IETF().G2))
+	require.Nil(t, signer.Verify(pubKey, msg, sig))
+}
+
+func TestBlsCipherSuiteSigner_KeysWithLibraryGeneratorShouldNotVerify(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signer := singlesig.NewBlsBasicSigner()
+
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+
+	err = signer.Verify(pubKey, msg, sig)
+	require.Equal(t, crypto.ErrSigNotValid, err)
+}
+
+func TestBlsCipherSuiteSigner_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+
+	for id, signer := range cipherSuiteSigners() {
+		sig, err := signer.Sign(nil, msg)
+		require.Nil(t, sig)
+		require.Equal(t, crypto.ErrNilPrivateKey, err, id)
+
+		sig, err = signer.Sign(privKey, nil)
+		require.Nil(t, sig)
+		require.Equal(t, crypto.ErrNilMessage, err, id)
+
+		sig, _ = signer.Sign(privKey, msg)
+		require.Equal(t, crypto.ErrNilPublicKey, signer.Verify(nil, msg, sig), id)
+		require.Equal(t, crypto.ErrNilMessage, signer.Verify(pubKey, nil, sig), id)
+		require.Equal(t, crypto.ErrNilSignature, signer.Verify(pubKey, msg, nil), id)
+		require.Equal(t, crypto.ErrBLSInvalidSignature, signer.Verify(pubKey, msg, sig[1:]), id)
+	}
+}
+
+func TestBlsPopCipherSuiteSigner_ProofOfPossession(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF())
+	privKey, pubKey := kg.GeneratePair()
+	_, otherPubKey := kg.GeneratePair()
+	signer := singlesig.NewBlsPopSigner()
+	require.Equal(t, singlesig.PopCipherSuiteID, signer.CipherSuiteID())
+
+	pop, err := signer.CreateProofOfPossession(privKey)
+	require.Nil(t, err)
+
+	require.Nil(t, signer.VerifyProofOfPossession(pubKey, pop))
+	require.Equal(t, crypto.ErrPoPNotValid, signer.VerifyProofOfPossession(otherPubKey, pop))
+
+	// a proof of possession is not a valid signature over the public key
+	pubKeyBytes := mcl.SerializeG2Compressed(pubKey.Point().(*mcl.PointG2).G2)
+	require.Equal(t, crypto.ErrSigNotValid, signer.Verify(pubKey, pubKeyBytes, pop))
+
+	_, err = signer.CreateProofOfPossession(nil)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+	require.Equal(t, crypto.ErrNilPublicKey, signer.VerifyProofOfPossession(nil, pop))
+	require.Equal(t, crypto.ErrNilSignature, signer.VerifyProofOfPossession(pubKey, nil))
+}
+
+// kat secret key, big endian as in the Ethereum consensus specification test vectors
+const katSecretKeyHex = "263dbd792f5b1be47ed85f8938c0f29586af0d3ac7b977f21c278fe1462040e3"
+
+func createKATPrivateKey(t *testing.T, suite crypto.Suite) crypto.PrivateKey {
+	skBytes, err := hex.DecodeString(katSecretKeyHex)
+	require.Nil(t, err)
+
+	// the keys of the suites are serialized as little endian
+	for i, j := 0, len(skBytes)-1; i < j; i, j = i+1, j-1 {
+		skBytes[i], skBytes[j] = skBytes[j], skBytes[i]
+	}
+
+	privKey, err := signing.NewKeyGenerator(suite).PrivateKeyFromByteArray(skBytes)
+	require.Nil(t, err)
+
+	return privKey
+}
+
+// known answer vectors generated with the blst library, the G2 POP signature over the 32 zero bytes message being
+// the one of the Ethereum consensus specification sign test vectors
+func TestBlsCipherSuiteSigners_KnownAnswerVectors(t *testing.T) {
+	t.Parallel()
+
+	vectors := []struct {
+		name   string
+		signer crypto.SingleSigner
+		suite  crypto.Suite
+		msg    string
+		sig    string
+	}{
+		{
+			name:   singlesig.BasicCipherSuiteID,
+			signer: singlesig.NewBlsBasicSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "616263",
+			sig:    "894868b11153b0352e9d3cea96a5b035a8780e4044d5538941ad27e40eb731b8a4a8fc8c4b36d67cd26f4e679ca914d6",
+		},
+		{
+			name:   singlesig.BasicCipherSuiteID,
+			signer: singlesig.NewBlsBasicSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:    "91137957a775ade818b445ba63d00c3edaf7d8d88aad7e1f80df864a8d8390ccb58b71b876edf37a565dc43abe52eb00",
+		},
+		{
+			name:   singlesig.AugCipherSuiteID,
+			signer: singlesig.NewBlsAugSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "616263",
+			sig:    "891e5b421e8ddfc64f34b97ec25abfcf63785e29796d4a16f37a3dd0de28cd371695ed245a5e2f2dfcb7331152c77cee",
+		},
+		{
+			name:   singlesig.AugCipherSuiteID,
+			signer: singlesig.NewBlsAugSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:    "ab1499fb74386ea5299481d609e81f92bb59281e47e6663215fd8a3399185580eb4667f280f533f92bb0cac6cc9c70a5",
+		},
+		{
+			name:   singlesig.PopCipherSuiteID,
+			signer: singlesig.NewBlsPopSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "616263",
+			sig:    "8fb10052b82bb7a49df8997cc8737faeaf75eef17766f6603709bf778571404cf2aa56f927d572843e7b7c32a13ec31e",
+		},
+		{
+			name:   singlesig.PopCipherSuiteID,
+			signer: singlesig.NewBlsPopSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			msg:    "0000000000000000000000000000000000000000000000000000000000000000",
+			sig:    "950998b098aeab7dddcef4916123247ae9f48ca4f7f0df3a487d244c26af107e4de324bd1181554122cfb251ed0b213f",
+		},
+		{
+			name:   singlesig.PopMinPkCipherSuiteID,
+			signer: singlesig.NewBlsPopSignerMinPk(),
+			suite:  mcl.NewSuiteBLS12MinPk(),
+			msg:    "616263",
+			sig: "a31751779876b59bddbd8896f966ab41b07556c0f020fbac55e862e027d48e79e57caba6153d7ec47db1219dca1b070d" +
+				"13a6469139855bd90ed9bb08b6686ee07836703f90547be20e7715a76de94115280b07b9238da2ea23704a1e1a71c2fe",
+		},
+		{
+			name:   singlesig.PopMinPkCipherSuiteID,
+			signer: singlesig.NewBlsPopSignerMinPk(),
+			suite:  mcl.NewSuiteBLS12MinPk(),
+			msg:    "0000000000000000000000000000000000000000000000000000000000000000",
+			sig: "b6ed936746e01f8ecf281f020953fbf1f01debd5657c4a383940b020b26507f6076334f91e2366c96e9ab279fb515809" +
+				"0352ea1c5b0c9274504f4f0e7053af24802e51e4568d164fe986834f41e55c8e850ce1f98458c0cfc9ab380b55285a55",
+		},
+	}
+
+	for _, vector := range vectors {
+		privKey := createKATPrivateKey(t, vector.suite)
+		msg, _ := hex.DecodeString(vector.msg)
+
+		sig, err := vector.signer.Sign(privKey, msg)
+		require.Nil(t, err, vector.name)
+		require.Equal(t, vector.sig, hex.EncodeToString(sig), vector.name)
+
+		expectedSig, _ := hex.DecodeString(vector.sig)
+		require.Nil(t, vector.signer.Verify(privKey.GeneratePublic(), msg, expectedSig), vector.name)
+	}
+}
+
+func TestBlsCipherSuiteSigners_ProofOfPossessionKnownAnswerVectors(t *testing.T) {
+	t.Parallel()
+
+	vectors := []struct {
+		name   string
+		signer crypto.ProofOfPossessionSigner
+		suite  crypto.Suite
+		pubKey string
+		pop    string
+	}{
+		{
+			name:   singlesig.PopProofDST,
+			signer: singlesig.NewBlsPopSigner(),
+			suite:  mcl.NewSuiteBLS12IETF(),
+			pubKey: "ac400b70f6f8cd35648f5c126cce5417f3be4d8eefbd42ceb4286a14df7e03135313fe5845e3a575faab3e8b949d2488" +
+				"14856c22d8cdb2967c720e963eedc999e738373b14172f06fc915769d3cc5ab7ae0a1b9c38f48b5585fb09d4bd2733bb",
+			pop: "85cd8b8b8e2677c1e6e861e6c720d08ff986bc39862de8f975fbb287f34a550402277ab6fd5fad7ae0d4f57a6ba80e19",
+		},
+		{
+			name:   singlesig.PopMinPkProofDST,
+			signer: singlesig.NewBlsPopSignerMinPk(),
+			suite:  mcl.NewSuiteBLS12MinPk(),
+			pubKey: "a491d1b0ecd9bb917989f0e74f0dea0422eac4a873e5e2644f368dffb9a6e20fd6e10c1b77654d067c0618f6e5a7f79a",
+			pop: "b803eb0ed93ea10224a73b6b9c725796be9f5fefd215ef7a5b97234cc956cf6870db6127b7e4d824ec62276078e787db" +
+				"05584ce1adbf076bc0808ca0f15b73d59060254b25393d95dfc7abe3cda566842aaedf50bbb062aae1bbb6ef3b1f77e1",
+		},
+	}
+
+	for _, vector := range vectors {
+		privKey := createKATPrivateKey(t, vector.suite)
+		pubKey := privKey.GeneratePublic()
+		switch point := pubKey.Point().(type) {
+		case *mcl.PointG1:
+			require.Equal(t, vector.pubKey, hex.EncodeToString(mcl.SerializeG1Compressed(point.G1)), vector.name)
+		case *mcl.PointG2:
+			require.Equal(t, vector.pubKey, hex.EncodeToString(mcl.SerializeG2Compressed(point.G2)), vector.name)
+		}
+
+		pop, err := vector.signer.CreateProofOfPossession(privKey)
+		require.Nil(t, err, vector.name)
+		require.Equal(t, vector.pop, hex.EncodeToString(pop), vector.name)
+		require.Nil(t, vector.signer.VerifyProofOfPossession(pubKey, pop), vector.name)
+	}
+}
+
+func TestBlsPopCipherSuiteSignerMinPk_SignVerify(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+	privKey, pubKey := kg.GeneratePair()
+	_, otherPubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signer := singlesig.NewBlsPopSignerMinPk()
+	require.False(t, check.IfNil(signer))
+	require.Equal(t, singlesig.PopMinPkCipherSuiteID, signer.CipherSuiteID())
+
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+	require.Len(t, sig, 96)
+	require.Nil(t, signer.Verify(pubKey, msg, sig))
+	require.Equal(t, crypto.ErrSigNotValid, signer.Verify(pubKey, []byte("another message"), sig))
+	require.Equal(t, crypto.ErrSigNotValid, signer.Verify(otherPubKey, msg, sig))
+
+	pop, err := signer.CreateProofOfPossession(privKey)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrPoPNotValid, signer.VerifyProofOfPossession(otherPubKey, pop))
+	// a proof of possession is not a valid signature over the public key
+	pubKeyBytes := mcl.SerializeG1Compressed(pubKey.Point().(*mcl.PointG1).G1)
+	require.Equal(t, crypto.ErrSigNotValid, signer.Verify(pubKey, pubKeyBytes, pop))
+
+	// keys with the public keys on G2 are rejected
+	_, pubKeyG2 := signing.NewKeyGenerator(mcl.NewSuiteBLS12IETF()).GeneratePair()
+	require.Equal(t, crypto.ErrInvalidPublicKey, signer.Verify(pubKeyG2, msg, sig))
+
+	_, err = signer.Sign(nil, msg)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+	_, err = signer.Sign(privKey, nil)
+	require.Equal(t, crypto.ErrNilMessage, err)
+	require.Equal(t, crypto.ErrNilPublicKey, signer.Verify(nil, msg, sig))
+	require.Equal(t, crypto.ErrNilSignature, signer.Verify(pubKey, msg, nil))
+	require.Equal(t, crypto.ErrBLSInvalidSignature, signer.Verify(pubKey, msg, sig[:48]))
+}
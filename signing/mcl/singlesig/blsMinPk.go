@@ -0,0 +1,140 @@
+package singlesig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.SingleSigner = (*BlsSingleSignerMinPk)(nil)
+
+// BlsSingleSignerMinPk is a SingleSigner implementation that uses a BLS signature scheme with the public keys on G1
+// and the signatures on G2, to be used with the keys of mcl.SuiteBLS12MinPk
+type BlsSingleSignerMinPk struct {
+}
+
+// NewBlsSignerMinPk creates a BLS single signer instance with the public keys on G1
+func NewBlsSignerMinPk() *BlsSingleSignerMinPk {
+	return &BlsSingleSignerMinPk{}
+}
+
+// Sign Signs a message using a single signature BLS scheme, the signature being a point on G2
+func (s *BlsSingleSignerMinPk) Sign(private crypto.PrivateKey, msg []byte) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if len(msg) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+
+	scalar := private.Scalar()
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+
+	mclScalar, ok := scalar.(*mcl.Scalar)
+	if !ok || !IsSecretKeyValid(mclScalar) {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	msgPoint := &bls.G2{}
+	err := msgPoint.HashAndMapTo(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &bls.G2{}
+	bls.G2Mul(sig, msgPoint, mclScalar.Scalar)
+
+	return sig.Serialize(), nil
+}
+
+// Verify verifies a signature using a single signature BLS scheme, checking that e(G1, sig) == e(pk, H(msg))
+func (s *BlsSingleSignerMinPk) Verify(public crypto.PublicKey, msg []byte, sig []byte) error {
+	if check.IfNil(public) {
+		return crypto.ErrNilPublicKey
+	}
+	if len(msg) == 0 {
+		return crypto.ErrNilMessage
+	}
+	if len(sig) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	point := public.Point()
+	if check.IfNil(point) {
+		return crypto.ErrNilPublicKeyPoint
+	}
+
+	pubKeyPoint, isPoint := point.(*mcl.PointG1)
+	if !isPoint || !IsMinPkPubKeyPointValid(pubKeyPoint) {
+		return crypto.ErrInvalidPublicKey
+	}
+
+	sigPoint, err := SigBytesToG2(sig)
+	if err != nil {
+		return err
+	}
+
+	return VerifyMinPkPairing(pubKeyPoint.G1, msg, sigPoint)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *BlsSingleSignerMinPk) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// VerifyMinPkPairing checks that e(G1, sig) == e(pk, H(msg)) for a public key on G1 and a signature on G2
+func VerifyMinPkPairing(pubKey *bls.G1, msg []byte, sig *bls.G2) error {
+	msgPoint := &bls.G2{}
+	err := msgPoint.HashAndMapTo(msg)
+	if err != nil {
+		return err
+	}
+
+	g1Points := make([]bls.G1, 2)
+	g2Points := make([]bls.G2, 2)
+	bls.G1Neg(&g1Points[0], mcl.NewPointG1().G1)
+	g2Points[0] = *sig
+	g1Points[1] = *pubKey
+	g2Points[1] = *msgPoint
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+	if !gt.IsOne() {
+		return crypto.ErrSigNotValid
+	}
+
+	return nil
+}
+
+// SigBytesToG2 returns the G2 point of a signature with the public keys on G1, validating it
+func SigBytesToG2(sig []byte) (*bls.G2, error) {
+	if len(sig) == 0 {
+		return nil, crypto.ErrNilSignature
+	}
+
+	sigPoint := &bls.G2{}
+	err := sigPoint.Deserialize(sig)
+	if err != nil {
+		return nil, err
+	}
+
+	if !IsMinPkSigValidPoint(sigPoint) {
+		return nil, crypto.ErrBLSInvalidSignature
+	}
+
+	return sigPoint, nil
+}
+
+// IsMinPkPubKeyPointValid validates the public key is a valid point on G1
+func IsMinPkPubKeyPointValid(pubKeyPoint *mcl.PointG1) bool {
+	return !pubKeyPoint.IsZero() && pubKeyPoint.IsValidOrder() && pubKeyPoint.IsValid()
+}
+
+// IsMinPkSigValidPoint validates that the signature is a valid point on G2
+func IsMinPkSigValidPoint(sig *bls.G2) bool {
+	return !sig.IsZero() && sig.IsValidOrder() && sig.IsValid()
+}
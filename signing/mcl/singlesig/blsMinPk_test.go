@@ -0,0 +1,98 @@
+package singlesig_test
+
+import (
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLSSignerMinPk_SignVerifyOK(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signer := singlesig.NewBlsSignerMinPk()
+	require.False(t, check.IfNil(signer))
+
+	pubKeyBytes, err := pubKey.ToByteArray()
+	require.Nil(t, err)
+	require.Len(t, pubKeyBytes, 48)
+
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+	require.Len(t, sig, 96)
+
+	err = signer.Verify(pubKey, msg, sig)
+	require.Nil(t, err)
+
+	// reconstructed public key
+	pubKey2, err := kg.PublicKeyFromByteArray(pubKeyBytes)
+	require.Nil(t, err)
+	err = signer.Verify(pubKey2, msg, sig)
+	require.Nil(t, err)
+}
+
+func TestBLSSignerMinPk_VerifyInvalidSignatureShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+	privKey, pubKey := kg.GeneratePair()
+	_, otherPubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signer := singlesig.NewBlsSignerMinPk()
+
+	sig, err := signer.Sign(privKey, msg)
+	require.Nil(t, err)
+
+	err = signer.Verify(pubKey, []byte("another message"), sig)
+	require.Equal(t, crypto.ErrSigNotValid, err)
+
+	err = signer.Verify(otherPubKey, msg, sig)
+	require.Equal(t, crypto.ErrSigNotValid, err)
+
+	err = signer.Verify(pubKey, msg, sig[:48])
+	require.NotNil(t, err)
+
+	zeroSig := make([]byte, 96)
+	err = signer.Verify(pubKey, msg, zeroSig)
+	require.Equal(t, crypto.ErrBLSInvalidSignature, err)
+}
+
+func TestBLSSignerMinPk_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12MinPk())
+	privKey, pubKey := kg.GeneratePair()
+	msg := []byte("message to be signed")
+	signer := singlesig.NewBlsSignerMinPk()
+	sig, _ := signer.Sign(privKey, msg)
+
+	_, err := signer.Sign(nil, msg)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	_, err = signer.Sign(privKey, nil)
+	require.Equal(t, crypto.ErrNilMessage, err)
+
+	privKeyInvalidScalar := &mock.PrivateKeyStub{
+		ScalarStub: func() crypto.Scalar {
+			return &mock.ScalarMock{}
+		},
+	}
+	_, err = signer.Sign(privKeyInvalidScalar, msg)
+	require.Equal(t, crypto.ErrInvalidPrivateKey, err)
+
+	require.Equal(t, crypto.ErrNilPublicKey, signer.Verify(nil, msg, sig))
+	require.Equal(t, crypto.ErrNilMessage, signer.Verify(pubKey, nil, sig))
+	require.Equal(t, crypto.ErrNilSignature, signer.Verify(pubKey, msg, nil))
+
+	// public keys on G2 are not valid for this signer
+	_, pubKeyG2 := signing.NewKeyGenerator(mcl.NewSuiteBLS12()).GeneratePair()
+	require.Equal(t, crypto.ErrInvalidPublicKey, signer.Verify(pubKeyG2, msg, sig))
+}
@@ -0,0 +1,95 @@
+package singlesig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.ProofOfPossessionSigner = (*BlsSingleSigner)(nil)
+
+// PopDomainTag is the domain separation tag used to hash the serialized public key to G1 with hash_to_curve when
+// creating a proof of possession. The regular messages are hashed by the mcl library under another tag, so a proof of
+// possession can never be mistaken for a signature over a regular message, whatever its content
+const PopDomainTag = "ME-BLS12381G2-POP-V01-"
+
+// CreateProofOfPossession creates a proof that the owner of the public key corresponding to the given private key
+// holds the private key, by signing the serialized G2 public key hashed under a dedicated domain tag
+func (s *BlsSingleSigner) CreateProofOfPossession(private crypto.PrivateKey) ([]byte, error) {
+	if check.IfNil(private) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+
+	scalar := private.Scalar()
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+
+	mclScalar, ok := scalar.(*mcl.Scalar)
+	if !ok || !IsSecretKeyValid(mclScalar) {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	pubKeyPoint, err := validPubKeyPoint(private.GeneratePublic())
+	if err != nil {
+		return nil, err
+	}
+
+	msgPoint, err := mcl.HashToG1(pubKeyPoint.G2.Serialize(), []byte(PopDomainTag))
+	if err != nil {
+		return nil, err
+	}
+
+	sig := &bls.G1{}
+	bls.G1Mul(sig, msgPoint.G1, mclScalar.Scalar)
+
+	return bls.CastToSign(sig).Serialize(), nil
+}
+
+// VerifyProofOfPossession verifies that the given proof of possession was created with the private key
+// corresponding to the given public key
+func (s *BlsSingleSigner) VerifyProofOfPossession(public crypto.PublicKey, pop []byte) error {
+	if check.IfNil(public) {
+		return crypto.ErrNilPublicKey
+	}
+	if len(pop) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	pubKeyPoint, err := validPubKeyPoint(public)
+	if err != nil {
+		return err
+	}
+
+	sig := &bls.Sign{}
+	err = sig.Deserialize(pop)
+	if err != nil || !IsSigValidPoint(sig) {
+		return crypto.ErrBLSInvalidSignature
+	}
+
+	msgPoint, err := mcl.HashToG1(pubKeyPoint.G2.Serialize(), []byte(PopDomainTag))
+	if err != nil {
+		return err
+	}
+
+	generator := &bls.PublicKey{}
+	bls.BlsGetGeneratorOfPublicKey(generator)
+
+	// e(pop, G2) == e(H(pk), pk), with G2 being the generator used by the mcl library for the public keys
+	g1Points := make([]bls.G1, 2)
+	g2Points := make([]bls.G2, 2)
+	bls.G1Neg(&g1Points[0], bls.CastFromSign(sig))
+	g2Points[0] = *bls.CastFromPublicKey(generator)
+	g1Points[1] = *msgPoint.G1
+	g2Points[1] = *pubKeyPoint.G2
+
+	gt := &bls.GT{}
+	bls.MillerLoopVec(gt, g1Points, g2Points)
+	bls.FinalExp(gt, gt)
+	if !gt.IsOne() {
+		return crypto.ErrPoPNotValid
+	}
+
+	return nil
+}
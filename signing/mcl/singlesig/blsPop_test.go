@@ -0,0 +1,119 @@
+package singlesig_test
+
+import (
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBLSSigner_CreateProofOfPossessionNilPrivateKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	signer := singlesig.NewBlsSigner()
+	pop, err := signer.CreateProofOfPossession(nil)
+
+	require.Nil(t, pop)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+}
+
+func TestBLSSigner_CreateProofOfPossessionInvalidScalarShouldErr(t *testing.T) {
+	t.Parallel()
+
+	privKey := &mock.PrivateKeyStub{
+		ScalarStub: func() crypto.Scalar {
+			return &mock.ScalarMock{}
+		},
+	}
+
+	signer := singlesig.NewBlsSigner()
+	pop, err := signer.CreateProofOfPossession(privKey)
+
+	require.Nil(t, pop)
+	require.Equal(t, crypto.ErrInvalidPrivateKey, err)
+}
+
+func TestBLSSigner_VerifyProofOfPossessionOK(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := kg.GeneratePair()
+	signer := singlesig.NewBlsSigner()
+
+	pop, err := signer.CreateProofOfPossession(privKey)
+	require.Nil(t, err)
+
+	err = signer.VerifyProofOfPossession(pubKey, pop)
+	require.Nil(t, err)
+}
+
+func TestBLSSigner_VerifyProofOfPossessionInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := kg.GeneratePair()
+	signer := singlesig.NewBlsSigner()
+	pop, _ := signer.CreateProofOfPossession(privKey)
+
+	require.Equal(t, crypto.ErrNilPublicKey, signer.VerifyProofOfPossession(nil, pop))
+	require.Equal(t, crypto.ErrNilSignature, signer.VerifyProofOfPossession(pubKey, nil))
+
+	invalidPubKey := &mock.PublicKeyStub{
+		PointStub: func() crypto.Point {
+			return &mock.PointMock{}
+		},
+	}
+	require.Equal(t, crypto.ErrInvalidPublicKey, signer.VerifyProofOfPossession(invalidPubKey, pop))
+}
+
+func TestBLSSigner_VerifyProofOfPossessionOtherKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, _ := kg.GeneratePair()
+	_, otherPubKey := kg.GeneratePair()
+	signer := singlesig.NewBlsSigner()
+	pop, _ := signer.CreateProofOfPossession(privKey)
+
+	err := signer.VerifyProofOfPossession(otherPubKey, pop)
+	require.Equal(t, crypto.ErrPoPNotValid, err)
+}
+
+func TestBLSSigner_ProofOfPossessionIsDomainSeparated(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := kg.GeneratePair()
+	pubKeyBytes, _ := pubKey.ToByteArray()
+	signer := singlesig.NewBlsSigner()
+
+	// a plain signature over the serialized public key must not be accepted as proof of possession
+	sig, err := signer.Sign(privKey, pubKeyBytes)
+	require.Nil(t, err)
+
+	err = signer.VerifyProofOfPossession(pubKey, sig)
+	require.Equal(t, crypto.ErrPoPNotValid, err)
+}
+
+func TestBLSSigner_ProofOfPossessionIsNotASignatureOverTheTaggedPublicKey(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := kg.GeneratePair()
+	pubKeyPoint, _ := pubKey.Point().(*mcl.PointG2)
+	signer := singlesig.NewBlsSigner()
+
+	taggedPubKey := append([]byte(singlesig.PopDomainTag), pubKeyPoint.G2.Serialize()...)
+	sig, err := signer.Sign(privKey, taggedPubKey)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrPoPNotValid, signer.VerifyProofOfPossession(pubKey, sig))
+
+	pop, err := signer.CreateProofOfPossession(privKey)
+	require.Nil(t, err)
+	require.NotEqual(t, sig, pop)
+	require.Equal(t, crypto.ErrSigNotValid, signer.Verify(pubKey, taggedPubKey, pop))
+}
@@ -60,3 +60,27 @@ func BenchmarkBlsSingleSigner_Verify(b *testing.B) {
 		require.Nil(b, err)
 	}
 }
+
+func BenchmarkBlsSingleSigner_VerifyBatch100(b *testing.B) {
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(b, 100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := signer.VerifyBatch(pubKeys, msgs, sigs)
+		require.Nil(b, err)
+	}
+}
+
+func BenchmarkBlsSingleSigner_VerifyOneByOne100(b *testing.B) {
+	signer := singlesig.NewBlsSigner()
+	pubKeys, msgs, sigs := createBatch(b, 100)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := range pubKeys {
+			err := signer.Verify(pubKeys[j], msgs[j], sigs[j])
+			require.Nil(b, err)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package mcl
+
+import (
+	"encoding/hex"
+	"sync"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+// generatorG2IETFCompressed is the compressed serialization of the G2 generator defined by the IETF BLS signature
+// standard and used by the Ethereum consensus specification
+const generatorG2IETFCompressed = "93e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+	"024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+
+var (
+	generatorG2IETF     *bls.G2
+	initGeneratorG2Once sync.Once
+)
+
+// SuiteBLS12IETF is a BLS12-381 suite deriving the public keys with the standard G2 generator of the IETF BLS
+// signature standard. The mcl library uses a different G2 generator, so the public keys created by SuiteBLS12 are
+// not interoperable with other implementations of the standard
+type SuiteBLS12IETF struct {
+	*SuiteBLS12
+}
+
+// NewSuiteBLS12IETF returns a wrapper over a BLS12 curve using the standard G2 generator for the public keys
+func NewSuiteBLS12IETF() *SuiteBLS12IETF {
+	suite := NewSuiteBLS12()
+	suite.strSuite = "BLS12-381 IETF suite"
+
+	return &SuiteBLS12IETF{
+		SuiteBLS12: suite,
+	}
+}
+
+// CreatePointForScalar creates the public key point on G2 corresponding to the given scalar
+func (s *SuiteBLS12IETF) CreatePointForScalar(scalar crypto.Scalar) (crypto.Point, error) {
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+	sc, ok := scalar.GetUnderlyingObj().(*bls.Fr)
+	if !ok {
+		return nil, crypto.ErrInvalidScalar
+	}
+
+	if sc.IsZero() || !sc.IsValid() {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	return GeneratorG2IETF().Mul(scalar)
+}
+
+// CreateKeyPair returns a pair of private public BLS keys.
+// The private key is a scalarInt, while the public key is a Point on G2 curve
+func (s *SuiteBLS12IETF) CreateKeyPair() (crypto.Scalar, crypto.Point) {
+	sc, err := s.G2.CreateScalar().Pick()
+	if err != nil {
+		log.Error("SuiteBLS12IETF CreateKeyPair", "error", err.Error())
+		return nil, nil
+	}
+
+	p, err := s.CreatePointForScalar(sc)
+	if err != nil {
+		log.Error("SuiteBLS12IETF CreateKeyPair", "error", err.Error())
+		return nil, nil
+	}
+
+	return sc, p
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *SuiteBLS12IETF) IsInterfaceNil() bool {
+	return s == nil
+}
+
+// GeneratorG2IETF returns the standard G2 generator of the IETF BLS signature standard
+func GeneratorG2IETF() *PointG2 {
+	initGeneratorG2Once.Do(func() {
+		doInit.Do(blsInit)
+
+		buff, err := hex.DecodeString(generatorG2IETFCompressed)
+		if err != nil {
+			panic(err.Error())
+		}
+
+		generatorG2IETF, err = DeserializeG2Compressed(buff)
+		if err != nil {
+			panic(err.Error())
+		}
+	})
+
+	generator := *generatorG2IETF
+
+	return &PointG2{
+		G2: &generator,
+	}
+}
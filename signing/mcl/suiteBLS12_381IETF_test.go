@@ -0,0 +1,63 @@
+package mcl_test
+
+import (
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSuiteBLS12IETF(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12IETF()
+	require.False(t, check.IfNil(suite))
+	require.Equal(t, "BLS12-381 IETF suite", suite.String())
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	require.True(t, ok)
+}
+
+func TestSuiteBLS12IETF_CreateKeyPairShouldUseStandardGenerator(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12IETF()
+	sk, pk := suite.CreateKeyPair()
+	require.NotNil(t, sk)
+
+	expected, err := mcl.GeneratorG2IETF().Mul(sk)
+	require.Nil(t, err)
+	equal, err := pk.Equal(expected)
+	require.Nil(t, err)
+	require.True(t, equal)
+
+	// the keys differ from the ones derived with the library generator
+	libraryPk, err := mcl.NewSuiteBLS12().CreatePointForScalar(sk)
+	require.Nil(t, err)
+	equal, _ = pk.Equal(libraryPk)
+	require.False(t, equal)
+}
+
+func TestSuiteBLS12IETF_CreatePointForScalarInvalidScalarShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12IETF()
+
+	point, err := suite.CreatePointForScalar(nil)
+	require.Nil(t, point)
+	require.Equal(t, crypto.ErrNilPrivateKeyScalar, err)
+
+	point, err = suite.CreatePointForScalar(mcl.NewScalar().Zero())
+	require.Nil(t, point)
+	require.Equal(t, crypto.ErrInvalidPrivateKey, err)
+}
+
+func TestGeneratorG2IETF_ShouldReturnCopies(t *testing.T) {
+	t.Parallel()
+
+	generator := mcl.GeneratorG2IETF()
+	generator.G2.Clear()
+
+	require.False(t, mcl.GeneratorG2IETF().IsZero())
+}
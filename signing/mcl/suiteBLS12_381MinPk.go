@@ -0,0 +1,106 @@
+package mcl
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/herumi/bls-go-binary/bls"
+)
+
+var _ crypto.Group = (*SuiteBLS12MinPk)(nil)
+var _ crypto.Random = (*SuiteBLS12MinPk)(nil)
+var _ crypto.Suite = (*SuiteBLS12MinPk)(nil)
+
+// SuiteBLS12MinPk provides an implementation of the Suite interface for BLS12-381 with the public keys on G1
+// (48 bytes) and the signatures on G2 (96 bytes), the layout selected at compile time in the mcl library by the
+// BLS_SWAP_G flag. It is selectable at runtime, as the signers for this suite work directly with the curve points
+// instead of the public key and signature types of the mcl library
+type SuiteBLS12MinPk struct {
+	*SuiteBLS12
+}
+
+// NewSuiteBLS12MinPk returns a wrapper over a BLS12 curve with the public keys on G1
+func NewSuiteBLS12MinPk() *SuiteBLS12MinPk {
+	suite := NewSuiteBLS12()
+	suite.strSuite = "BLS12-381 min public key size suite"
+
+	return &SuiteBLS12MinPk{
+		SuiteBLS12: suite,
+	}
+}
+
+// CreatePoint creates a new point on G1
+func (s *SuiteBLS12MinPk) CreatePoint() crypto.Point {
+	return s.G1.CreatePoint()
+}
+
+// CreatePointForScalar creates a new point on G1 corresponding to the given scalar
+func (s *SuiteBLS12MinPk) CreatePointForScalar(scalar crypto.Scalar) (crypto.Point, error) {
+	if check.IfNil(scalar) {
+		return nil, crypto.ErrNilPrivateKeyScalar
+	}
+	sc, ok := scalar.GetUnderlyingObj().(*bls.Fr)
+	if !ok {
+		return nil, crypto.ErrInvalidScalar
+	}
+
+	if sc.IsZero() || !sc.IsValid() {
+		return nil, crypto.ErrInvalidPrivateKey
+	}
+
+	point := s.G1.CreatePointForScalar(scalar)
+
+	return point, nil
+}
+
+// PointLen returns the max length of point in nb of bytes
+func (s *SuiteBLS12MinPk) PointLen() int {
+	return s.G1.PointLen()
+}
+
+// CreateKeyPair returns a pair of private public BLS keys.
+// The private key is a scalarInt, while the public key is a Point on G1 curve
+func (s *SuiteBLS12MinPk) CreateKeyPair() (crypto.Scalar, crypto.Point) {
+	var sc crypto.Scalar
+	var err error
+
+	sc = s.G1.CreateScalar()
+	sc, err = sc.Pick()
+	if err != nil {
+		log.Error("SuiteBLS12MinPk CreateKeyPair", "error", err.Error())
+		return nil, nil
+	}
+
+	p := s.G1.CreatePointForScalar(sc)
+
+	return sc, p
+}
+
+// GetUnderlyingSuite returns the underlying suite
+func (s *SuiteBLS12MinPk) GetUnderlyingSuite() interface{} {
+	return s
+}
+
+// CheckPointValid returns error if the point is not valid (zero is also not valid), otherwise nil
+func (s *SuiteBLS12MinPk) CheckPointValid(pointBytes []byte) error {
+	if len(pointBytes) != s.PointLen() {
+		return crypto.ErrInvalidParam
+	}
+
+	point := s.G1.CreatePoint()
+	err := point.UnmarshalBinary(pointBytes)
+	if err != nil {
+		return err
+	}
+
+	pG1, ok := point.GetUnderlyingObj().(*bls.G1)
+	if !ok || !pG1.IsValid() || !pG1.IsValidOrder() || pG1.IsZero() {
+		return crypto.ErrInvalidPoint
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *SuiteBLS12MinPk) IsInterfaceNil() bool {
+	return s == nil
+}
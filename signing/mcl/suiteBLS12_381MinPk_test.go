@@ -0,0 +1,79 @@
+package mcl_test
+
+import (
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSuiteBLS12MinPk(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	require.False(t, check.IfNil(suite))
+	require.Equal(t, "BLS12-381 min public key size suite", suite.String())
+	require.Equal(t, 48, suite.PointLen())
+	require.Equal(t, 32, suite.ScalarLen())
+
+	_, ok := suite.GetUnderlyingSuite().(*mcl.SuiteBLS12MinPk)
+	require.True(t, ok)
+	_, ok = suite.GetUnderlyingSuite().(*mcl.SuiteBLS12)
+	require.False(t, ok)
+}
+
+func TestSuiteBLS12MinPk_CreateKeyPairShouldBeOnG1(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	sk, pk := suite.CreateKeyPair()
+
+	pkG1, ok := pk.(*mcl.PointG1)
+	require.True(t, ok)
+	require.True(t, pkG1.IsValidOrder())
+
+	expected, err := suite.CreatePointForScalar(sk)
+	require.Nil(t, err)
+	equal, err := pk.Equal(expected)
+	require.Nil(t, err)
+	require.True(t, equal)
+
+	_, ok = suite.CreatePoint().(*mcl.PointG1)
+	require.True(t, ok)
+}
+
+func TestSuiteBLS12MinPk_CreatePointForScalarInvalidScalarShouldErr(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+
+	point, err := suite.CreatePointForScalar(nil)
+	require.Nil(t, point)
+	require.Equal(t, crypto.ErrNilPrivateKeyScalar, err)
+
+	point, err = suite.CreatePointForScalar(mcl.NewScalar().Zero())
+	require.Nil(t, point)
+	require.Equal(t, crypto.ErrInvalidPrivateKey, err)
+}
+
+func TestSuiteBLS12MinPk_CheckPointValid(t *testing.T) {
+	t.Parallel()
+
+	suite := mcl.NewSuiteBLS12MinPk()
+	_, pk := suite.CreateKeyPair()
+	pkBytes, err := pk.MarshalBinary()
+	require.Nil(t, err)
+
+	require.Nil(t, suite.CheckPointValid(pkBytes))
+	require.Equal(t, crypto.ErrInvalidParam, suite.CheckPointValid(pkBytes[1:]))
+
+	zeroBytes, _ := mcl.NewPointG1().Null().MarshalBinary()
+	require.Equal(t, crypto.ErrInvalidPoint, suite.CheckPointValid(zeroBytes))
+
+	// a public key on G2 is not valid for this suite
+	_, pkG2 := mcl.NewSuiteBLS12().CreateKeyPair()
+	pkG2Bytes, _ := pkG2.MarshalBinary()
+	require.Equal(t, crypto.ErrInvalidParam, suite.CheckPointValid(pkG2Bytes))
+}
@@ -0,0 +1,97 @@
+package multisig
+
+import (
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ crypto.AggregatedSigVerifier = (*aggregatedSigVerifier)(nil)
+
+/*
+aggregatedSigVerifier verifies the aggregated signatures of a consensus group given by its public keys, without
+requiring a private key or any of the mutable state of a multi-signer.
+
+The public keys are decoded once, at construction, and never modified afterwards, so the verifier can be used from
+many goroutines without locks. The low level signer needs to be concurrent safe, as the mcl ones are
+*/
+type aggregatedSigVerifier struct {
+	pubKeys  []crypto.PublicKey
+	keyGen   crypto.KeyGenerator
+	llSigner crypto.LowLevelSignerBLS
+}
+
+// NewAggregatedSigVerifier creates a stateless verifier of the aggregated signatures of the given consensus group
+func NewAggregatedSigVerifier(
+	llSigner crypto.LowLevelSignerBLS,
+	pubKeys []string,
+	keyGen crypto.KeyGenerator,
+) (*aggregatedSigVerifier, error) {
+	if check.IfNil(llSigner) {
+		return nil, crypto.ErrNilLowLevelSigner
+	}
+	if len(pubKeys) == 0 {
+		return nil, crypto.ErrNoPublicKeySet
+	}
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+
+	pk, err := convertStringsToPubKeys(pubKeys, keyGen)
+	if err != nil {
+		return nil, err
+	}
+
+	return &aggregatedSigVerifier{
+		pubKeys:  pk,
+		keyGen:   keyGen,
+		llSigner: llSigner,
+	}, nil
+}
+
+// Verify verifies the aggregated signature against the aggregated public keys of the signers selected by the bitmap
+func (asv *aggregatedSigVerifier) Verify(message []byte, bitmap []byte, aggSig []byte) error {
+	selection, err := NewBitmapFromBytes(bitmap, len(asv.pubKeys))
+	if err != nil {
+		return err
+	}
+
+	return asv.verify(message, selection, aggSig)
+}
+
+// VerifyWithBitmap verifies the aggregated signature against the aggregated public keys of the signers selected by
+// the bitmap
+func (asv *aggregatedSigVerifier) VerifyWithBitmap(message []byte, bitmap *Bitmap, aggSig []byte) error {
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	err := bitmap.CheckSize(len(asv.pubKeys))
+	if err != nil {
+		return err
+	}
+
+	return asv.verify(message, bitmap, aggSig)
+}
+
+func (asv *aggregatedSigVerifier) verify(message []byte, bitmap *Bitmap, aggSig []byte) error {
+	if len(aggSig) == 0 {
+		return crypto.ErrNilSignature
+	}
+
+	pubKeys := make([]crypto.PublicKey, 0, bitmap.Count())
+	bitmap.ForEach(func(index int) {
+		pubKeys = append(pubKeys, asv.pubKeys[index])
+	})
+
+	return asv.llSigner.VerifyAggregatedSig(asv.keyGen.Suite(), pubKeys, aggSig, message)
+}
+
+// NumPubKeys returns the size of the consensus group
+func (asv *aggregatedSigVerifier) NumPubKeys() int {
+	return len(asv.pubKeys)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (asv *aggregatedSigVerifier) IsInterfaceNil() bool {
+	return asv == nil
+}
@@ -0,0 +1,133 @@
+package multisig_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createAggregatedSigForVerifier(
+	t *testing.T,
+	llSigner crypto.LowLevelSignerBLS,
+	privKeys []crypto.PrivateKey,
+	pubKeys []string,
+	kg crypto.KeyGenerator,
+	msg []byte,
+	bitmap []byte,
+) []byte {
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKeys[0], kg, 0)
+	require.Nil(t, err)
+
+	selection, err := multisig.NewBitmapFromBytes(bitmap, len(pubKeys))
+	require.Nil(t, err)
+	selection.ForEach(func(index int) {
+		_, err = multiSigner.CreateAndAddSignatureShareForKey(msg, privKeys[index], []byte(pubKeys[index]))
+		require.Nil(t, err)
+	})
+
+	aggSig, err := multiSigner.AggregateSigs(bitmap)
+	require.Nil(t, err)
+
+	return aggSig
+}
+
+func TestNewAggregatedSigVerifier(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, pubKeys, kg := generateMultiSigParamsBLS(4, 0)
+
+	verifier, err := multisig.NewAggregatedSigVerifier(nil, pubKeys, kg)
+	require.Equal(t, crypto.ErrNilLowLevelSigner, err)
+	require.True(t, check.IfNil(verifier))
+
+	_, err = multisig.NewAggregatedSigVerifier(llSigner, nil, kg)
+	require.Equal(t, crypto.ErrNoPublicKeySet, err)
+
+	_, err = multisig.NewAggregatedSigVerifier(llSigner, pubKeys, nil)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, err = multisig.NewAggregatedSigVerifier(llSigner, []string{pubKeys[0], "invalid"}, kg)
+	require.Equal(t, crypto.ErrInvalidPublicKeyString, err)
+
+	verifier, err = multisig.NewAggregatedSigVerifier(llSigner, pubKeys, kg)
+	require.Nil(t, err)
+	require.False(t, check.IfNil(verifier))
+	require.Equal(t, 4, verifier.NumPubKeys())
+}
+
+func TestAggregatedSigVerifier_Verify(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	bitmap := []byte{0x0b, 0x00}
+	llSigners := map[string]crypto.LowLevelSignerBLS{
+		"modified BLS": &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}},
+		"KOSK":         &llsig.BlsMultiSignerKOSK{},
+	}
+
+	for name, llSigner := range llSigners {
+		llSigner := llSigner
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(10, 0)
+			aggSig := createAggregatedSigForVerifier(t, llSigner, privKeys, pubKeys, kg, msg, bitmap)
+
+			verifier, err := multisig.NewAggregatedSigVerifier(llSigner, pubKeys, kg)
+			require.Nil(t, err)
+
+			require.Nil(t, verifier.Verify(msg, bitmap, aggSig))
+			require.Equal(t, crypto.ErrNilBitmap, verifier.Verify(msg, nil, aggSig))
+			require.Equal(t, crypto.ErrBitmapMismatch, verifier.Verify(msg, []byte{0x0b}, aggSig))
+			require.Equal(t, crypto.ErrNilSignature, verifier.Verify(msg, bitmap, nil))
+			require.NotNil(t, verifier.Verify([]byte("other message"), bitmap, aggSig))
+			require.NotNil(t, verifier.Verify(msg, []byte{0x03, 0x00}, aggSig))
+
+			selection, _ := multisig.NewBitmapFromBytes(bitmap, 10)
+			require.Nil(t, verifier.VerifyWithBitmap(msg, selection, aggSig))
+			require.Equal(t, crypto.ErrNilBitmap, verifier.VerifyWithBitmap(msg, nil, aggSig))
+			wrongSize, _ := multisig.NewBitmap(11)
+			require.Equal(t, crypto.ErrBitmapMismatch, verifier.VerifyWithBitmap(msg, wrongSize, aggSig))
+		})
+	}
+}
+
+func TestAggregatedSigVerifier_ConcurrentVerify(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(8, 0)
+	bitmaps := [][]byte{{0x0f}, {0xf0}, {0xff}}
+	messages := [][]byte{[]byte("message 1"), []byte("message 2"), []byte("message 3")}
+	aggSigs := make([][]byte, len(bitmaps))
+	for i := range bitmaps {
+		aggSigs[i] = createAggregatedSigForVerifier(t, llSigner, privKeys, pubKeys, kg, messages[i], bitmaps[i])
+	}
+
+	verifier, _ := multisig.NewAggregatedSigVerifier(llSigner, pubKeys, kg)
+
+	numCalls := 30
+	errs := make([]error, numCalls)
+	wg := sync.WaitGroup{}
+	wg.Add(numCalls)
+	for i := 0; i < numCalls; i++ {
+		go func(idx int) {
+			defer wg.Done()
+
+			tuple := idx % len(bitmaps)
+			errs[idx] = verifier.Verify(messages[tuple], bitmaps[tuple], aggSigs[tuple])
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.Nil(t, err)
+	}
+}
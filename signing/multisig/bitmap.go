@@ -0,0 +1,175 @@
+package multisig
+
+import (
+	"math"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+/*
+Bitmap holds the selection of the signers of a multi-signature out of a consensus group of a given size.
+
+The serialization keeps the layout of the raw byte slice bitmaps used by the MultiSigner interface: the signer with
+index i is selected if the bit i%8 of the byte i/8 is set, the least significant bit being the first one
+*/
+type Bitmap struct {
+	bits []byte
+	size int
+}
+
+// NewBitmap creates an empty bitmap for a consensus group of the given size
+func NewBitmap(size int) (*Bitmap, error) {
+	if size <= 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+
+	return &Bitmap{
+		bits: make([]byte, bitmapByteLen(size)),
+		size: size,
+	}, nil
+}
+
+// NewBitmapFromBytes creates a bitmap for a consensus group of the given size from its serialization. As for the
+// raw byte slice bitmaps, the serialization can be longer than needed, the bits past the group size being ignored
+func NewBitmapFromBytes(buff []byte, size int) (*Bitmap, error) {
+	if buff == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+	if size <= 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+	if len(buff)*8 < size {
+		return nil, crypto.ErrBitmapMismatch
+	}
+
+	bitmap := &Bitmap{
+		bits: make([]byte, bitmapByteLen(size)),
+		size: size,
+	}
+	copy(bitmap.bits, buff)
+	if size%8 != 0 {
+		bitmap.bits[len(bitmap.bits)-1] &= byte(1<<uint(size%8)) - 1
+	}
+
+	return bitmap, nil
+}
+
+// Size returns the size of the consensus group
+func (b *Bitmap) Size() int {
+	return b.size
+}
+
+// CheckSize returns ErrBitmapMismatch if the bitmap is not sized for the given number of public keys
+func (b *Bitmap) CheckSize(numPubKeys int) error {
+	if b.size != numPubKeys {
+		return crypto.ErrBitmapMismatch
+	}
+
+	return nil
+}
+
+// Set selects the signer with the given index
+func (b *Bitmap) Set(index int) error {
+	if index < 0 || index >= b.size {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	b.bits[index/8] |= 1 << uint(index%8)
+
+	return nil
+}
+
+// Unset removes the signer with the given index from the selection
+func (b *Bitmap) Unset(index int) error {
+	if index < 0 || index >= b.size {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	b.bits[index/8] &^= 1 << uint(index%8)
+
+	return nil
+}
+
+// IsSet returns true if the signer with the given index is selected
+func (b *Bitmap) IsSet(index int) bool {
+	if index < 0 || index >= b.size {
+		return false
+	}
+
+	return b.bits[index/8]&(1<<uint(index%8)) != 0
+}
+
+// Count returns the number of selected signers
+func (b *Bitmap) Count() int {
+	count := 0
+	for _, bitsByte := range b.bits {
+		for ; bitsByte != 0; bitsByte &= bitsByte - 1 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ForEach calls the handler with the index of each selected signer, in ascending order
+func (b *Bitmap) ForEach(handler func(index int)) {
+	for i := 0; i < b.size; i++ {
+		if b.IsSet(i) {
+			handler(i)
+		}
+	}
+}
+
+// HasThreshold returns true if at least threshold signers are selected
+func (b *Bitmap) HasThreshold(threshold int) bool {
+	return b.Count() >= threshold
+}
+
+// HasSuperMajority returns true if at least 2/3+1 of the consensus group is selected
+func (b *Bitmap) HasSuperMajority() bool {
+	return b.HasThreshold(b.size*2/3 + 1)
+}
+
+// SignedWeight returns the sum of the weights of the selected signers, weights[i] being the weight of the signer
+// with index i
+func (b *Bitmap) SignedWeight(weights []uint64) (uint64, error) {
+	if len(weights) != b.size {
+		return 0, crypto.ErrInvalidParam
+	}
+
+	signedWeight := uint64(0)
+	for i, weight := range weights {
+		if !b.IsSet(i) {
+			continue
+		}
+		if signedWeight > math.MaxUint64-weight {
+			return 0, crypto.ErrInvalidParam
+		}
+
+		signedWeight += weight
+	}
+
+	return signedWeight, nil
+}
+
+// HasWeightedThreshold returns true if the sum of the weights of the selected signers is at least the threshold
+func (b *Bitmap) HasWeightedThreshold(weights []uint64, threshold uint64) (bool, error) {
+	signedWeight, err := b.SignedWeight(weights)
+	if err != nil {
+		return false, err
+	}
+
+	return signedWeight >= threshold, nil
+}
+
+// Bytes returns the serialization of the bitmap, with the same layout as the raw byte slice bitmaps
+func (b *Bitmap) Bytes() []byte {
+	buff := make([]byte, len(b.bits))
+	copy(buff, b.bits)
+
+	return buff
+}
+
+func bitmapByteLen(size int) int {
+	return (size + 7) / 8
+}
@@ -0,0 +1,151 @@
+package multisig_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBitmap(t *testing.T) {
+	t.Parallel()
+
+	bitmap, err := multisig.NewBitmap(0)
+	require.Nil(t, bitmap)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	bitmap, err = multisig.NewBitmap(12)
+	require.Nil(t, err)
+	require.Equal(t, 12, bitmap.Size())
+	require.Equal(t, 0, bitmap.Count())
+	require.Equal(t, []byte{0, 0}, bitmap.Bytes())
+}
+
+func TestBitmap_SetIsSetUnset(t *testing.T) {
+	t.Parallel()
+
+	bitmap, _ := multisig.NewBitmap(10)
+
+	require.Equal(t, crypto.ErrIndexOutOfBounds, bitmap.Set(-1))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, bitmap.Set(10))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, bitmap.Unset(10))
+	require.False(t, bitmap.IsSet(-1))
+	require.False(t, bitmap.IsSet(10))
+
+	require.Nil(t, bitmap.Set(0))
+	require.Nil(t, bitmap.Set(3))
+	require.Nil(t, bitmap.Set(9))
+	require.Nil(t, bitmap.Set(9))
+	require.True(t, bitmap.IsSet(0))
+	require.True(t, bitmap.IsSet(3))
+	require.True(t, bitmap.IsSet(9))
+	require.False(t, bitmap.IsSet(1))
+	require.Equal(t, 3, bitmap.Count())
+	require.Equal(t, []byte{0x09, 0x02}, bitmap.Bytes())
+
+	require.Nil(t, bitmap.Unset(3))
+	require.False(t, bitmap.IsSet(3))
+	require.Equal(t, 2, bitmap.Count())
+
+	indexes := make([]int, 0)
+	bitmap.ForEach(func(index int) {
+		indexes = append(indexes, index)
+	})
+	require.Equal(t, []int{0, 9}, indexes)
+}
+
+func TestNewBitmapFromBytes(t *testing.T) {
+	t.Parallel()
+
+	bitmap, err := multisig.NewBitmapFromBytes(nil, 8)
+	require.Nil(t, bitmap)
+	require.Equal(t, crypto.ErrNilBitmap, err)
+
+	bitmap, err = multisig.NewBitmapFromBytes([]byte{0xff}, 0)
+	require.Nil(t, bitmap)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	bitmap, err = multisig.NewBitmapFromBytes([]byte{0xff}, 9)
+	require.Nil(t, bitmap)
+	require.Equal(t, crypto.ErrBitmapMismatch, err)
+
+	// the bits past the group size are ignored, as for the raw byte slice bitmaps
+	bitmap, err = multisig.NewBitmapFromBytes([]byte{0x07, 0xf1, 0xff}, 12)
+	require.Nil(t, err)
+	require.Equal(t, 12, bitmap.Size())
+	require.Equal(t, 4, bitmap.Count())
+	require.Equal(t, []byte{0x07, 0x01}, bitmap.Bytes())
+	require.True(t, bitmap.IsSet(8))
+	require.False(t, bitmap.IsSet(12))
+
+	// the serialization is a copy
+	buff := []byte{0x05}
+	bitmap, _ = multisig.NewBitmapFromBytes(buff, 8)
+	buff[0] = 0
+	serialized := bitmap.Bytes()
+	serialized[0] = 0
+	require.Equal(t, []byte{0x05}, bitmap.Bytes())
+
+	bitmap2, err := multisig.NewBitmapFromBytes(bitmap.Bytes(), 8)
+	require.Nil(t, err)
+	require.Equal(t, bitmap, bitmap2)
+}
+
+func TestBitmap_CheckSize(t *testing.T) {
+	t.Parallel()
+
+	bitmap, _ := multisig.NewBitmap(15)
+	require.Nil(t, bitmap.CheckSize(15))
+	require.Equal(t, crypto.ErrBitmapMismatch, bitmap.CheckSize(16))
+	require.Equal(t, crypto.ErrBitmapMismatch, bitmap.CheckSize(14))
+}
+
+func TestBitmap_Quorums(t *testing.T) {
+	t.Parallel()
+
+	bitmap, _ := multisig.NewBitmap(10)
+	for i := 0; i < 6; i++ {
+		_ = bitmap.Set(i)
+	}
+
+	require.True(t, bitmap.HasThreshold(6))
+	require.False(t, bitmap.HasThreshold(7))
+	// 2/3+1 of 10 is 7
+	require.False(t, bitmap.HasSuperMajority())
+	_ = bitmap.Set(9)
+	require.True(t, bitmap.HasSuperMajority())
+
+	small, _ := multisig.NewBitmap(3)
+	_ = small.Set(0)
+	_ = small.Set(1)
+	require.False(t, small.HasSuperMajority())
+	_ = small.Set(2)
+	require.True(t, small.HasSuperMajority())
+}
+
+func TestBitmap_Weights(t *testing.T) {
+	t.Parallel()
+
+	bitmap, _ := multisig.NewBitmap(4)
+	_ = bitmap.Set(1)
+	_ = bitmap.Set(3)
+
+	_, err := bitmap.SignedWeight([]uint64{1, 2, 3})
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	weight, err := bitmap.SignedWeight([]uint64{1, 2, 3, 4})
+	require.Nil(t, err)
+	require.Equal(t, uint64(6), weight)
+
+	hasThreshold, err := bitmap.HasWeightedThreshold([]uint64{1, 2, 3, 4}, 6)
+	require.Nil(t, err)
+	require.True(t, hasThreshold)
+	hasThreshold, err = bitmap.HasWeightedThreshold([]uint64{1, 2, 3, 4}, 7)
+	require.Nil(t, err)
+	require.False(t, hasThreshold)
+
+	_, err = bitmap.HasWeightedThreshold([]uint64{1, math.MaxUint64, 1, 1}, 1)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+}
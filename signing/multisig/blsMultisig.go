@@ -2,6 +2,9 @@ package multisig
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/ME-MotherEarth/me-core/core/check"
@@ -12,6 +15,13 @@ import (
 const BlsHashSize = 16
 
 var _ crypto.MultiSigner = (*blsMultiSigner)(nil)
+var _ crypto.SigSharesVerifier = (*blsMultiSigner)(nil)
+
+// batchVerifier is implemented by the low level signers able to verify a batch of independent signatures at once,
+// reporting the positions of the invalid ones
+type batchVerifier interface {
+	VerifyBatch(pubKeys []crypto.PublicKey, msgs [][]byte, sigs [][]byte) error
+}
 
 /*
 This implementation follows the modified BLS scheme presented here (curve notation changed in this file as compared to
@@ -30,11 +40,22 @@ This is where the modified BLS scheme comes into play and prevents this attacks
 
 type blsMultiSigData struct {
 	pubKeys []crypto.PublicKey
-	privKey crypto.PrivateKey
+	// pubKeyIndexes maps the serialized public keys to their position in the consensus group
+	pubKeyIndexes map[string]uint16
+	privKey       crypto.PrivateKey
+	// ownKeys holds the private keys of the signers from the consensus group run by this node
+	ownKeys map[uint16]crypto.PrivateKey
 	// signatures in BLS are points on curve G1
 	sigShares [][]byte
-	aggSig    []byte
-	ownIndex  uint16
+	// rejectedSigShares counts the signature shares rejected in strict mode for each signer
+	rejectedSigShares []uint32
+	// invalidSigShares marks the signers whose stored signature share was found invalid in strict mode
+	invalidSigShares []bool
+	// message is pinned in strict mode by the first signature share verified or created, so all the shares are over
+	// the same message
+	message  []byte
+	aggSig   []byte
+	ownIndex uint16
 }
 
 type blsMultiSigner struct {
@@ -42,6 +63,15 @@ type blsMultiSigner struct {
 	mutSigData sync.RWMutex
 	keyGen     crypto.KeyGenerator
 	llSigner   crypto.LowLevelSignerBLS
+	// keySet maps the serialized public keys to the private keys of all the validators run by this node, being nil
+	// for a single key multi-signer
+	keySet map[string]crypto.PrivateKey
+	// rounds holds the signature shares of the last rounds, not being cleared by Reset
+	rounds *roundShares
+	// strictMode requires the signature shares to be verified when stored
+	strictMode bool
+	// detector records the verified signature shares to detect the equivocations, being nil if not set
+	detector *equivocationDetector
 }
 
 // NewBLSMultisig creates a new BLS multi-signer
@@ -52,44 +82,211 @@ func NewBLSMultisig(
 	keyGen crypto.KeyGenerator,
 	ownIndex uint16,
 ) (*blsMultiSigner, error) {
-	if check.IfNil(llSigner) {
-		return nil, crypto.ErrNilLowLevelSigner
+	err := checkMultiSigArgs(llSigner, privKey, len(pubKeys), keyGen, ownIndex)
+	if err != nil {
+		return nil, err
 	}
-	if check.IfNil(privKey) {
-		return nil, crypto.ErrNilPrivateKey
+
+	group, err := newConsensusGroupFromStrings(pubKeys, keyGen)
+	if err != nil {
+		return nil, err
 	}
-	if len(pubKeys) == 0 {
-		return nil, crypto.ErrNoPublicKeySet
+
+	return newBLSMultisig(llSigner, group, nil, privKey, keyGen, ownIndex), nil
+}
+
+// NewBLSMultisigWithPubKeyBytes creates a new BLS multi-signer for the consensus group given by the serialized public
+// keys. An invalid public key is reported through a *crypto.InvalidPublicKeyError holding its position
+func NewBLSMultisigWithPubKeyBytes(
+	llSigner crypto.LowLevelSignerBLS,
+	pubKeys [][]byte,
+	privKey crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) (*blsMultiSigner, error) {
+	err := checkMultiSigArgs(llSigner, privKey, len(pubKeys), keyGen, ownIndex)
+	if err != nil {
+		return nil, err
 	}
-	if check.IfNil(keyGen) {
-		return nil, crypto.ErrNilKeyGenerator
+
+	group, err := newConsensusGroupFromBytes(pubKeys, keyGen)
+	if err != nil {
+		return nil, err
 	}
-	if ownIndex >= uint16(len(pubKeys)) {
-		return nil, crypto.ErrIndexOutOfBounds
+
+	return newBLSMultisig(llSigner, group, nil, privKey, keyGen, ownIndex), nil
+}
+
+// NewBLSMultisigWithPublicKeys creates a new BLS multi-signer for the consensus group given by the public keys. A nil
+// public key is reported through a *crypto.InvalidPublicKeyError holding its position
+func NewBLSMultisigWithPublicKeys(
+	llSigner crypto.LowLevelSignerBLS,
+	pubKeys []crypto.PublicKey,
+	privKey crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) (*blsMultiSigner, error) {
+	err := checkMultiSigArgs(llSigner, privKey, len(pubKeys), keyGen, ownIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := newConsensusGroupFromPublicKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return newBLSMultisig(llSigner, group, nil, privKey, keyGen, ownIndex), nil
+}
+
+/*
+NewBLSMultisigWithKeySet creates a new BLS multi-signer holding the private keys of all the validators run by this
+node. The signers owned by this node are found by looking up the public keys of the key set in the consensus group,
+and the key set is kept across calls of Reset and Create, so the owned signers are updated with every new group.
+
+The own index selects the signer used by CreateSignatureShare, which fails with ErrIndexNotSelected if the signer is
+not owned. CreateSignatureSharesForBitmap signs for all the owned signers at once
+*/
+func NewBLSMultisigWithKeySet(
+	llSigner crypto.LowLevelSignerBLS,
+	pubKeys []string,
+	privKeys []crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) (*blsMultiSigner, error) {
+	if len(privKeys) == 0 {
+		return nil, crypto.ErrNilPrivateKey
+	}
+
+	err := checkMultiSigArgs(llSigner, privKeys[0], len(pubKeys), keyGen, ownIndex)
+	if err != nil {
+		return nil, err
 	}
 
-	sizeConsensus := uint16(len(pubKeys))
-	sigShares := make([][]byte, sizeConsensus)
-	pk, err := convertStringsToPubKeys(pubKeys, keyGen)
+	keySet, err := createKeySet(privKeys)
+	if err != nil {
+		return nil, err
+	}
 
+	group, err := newConsensusGroupFromStrings(pubKeys, keyGen)
 	if err != nil {
 		return nil, err
 	}
 
-	data := &blsMultiSigData{
-		pubKeys:   pk,
-		privKey:   privKey,
-		ownIndex:  ownIndex,
-		sigShares: sigShares,
+	return newBLSMultisig(llSigner, group, keySet, nil, keyGen, ownIndex), nil
+}
+
+func checkMultiSigArgs(
+	llSigner crypto.LowLevelSignerBLS,
+	privKey crypto.PrivateKey,
+	numPubKeys int,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) error {
+	if check.IfNil(llSigner) {
+		return crypto.ErrNilLowLevelSigner
+	}
+	if check.IfNil(privKey) {
+		return crypto.ErrNilPrivateKey
+	}
+	if numPubKeys == 0 {
+		return crypto.ErrNoPublicKeySet
+	}
+	if check.IfNil(keyGen) {
+		return crypto.ErrNilKeyGenerator
+	}
+	if int(ownIndex) >= numPubKeys {
+		return crypto.ErrIndexOutOfBounds
 	}
 
+	return nil
+}
+
+func newBLSMultisig(
+	llSigner crypto.LowLevelSignerBLS,
+	group *consensusGroup,
+	keySet map[string]crypto.PrivateKey,
+	privKey crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) *blsMultiSigner {
 	// own index is used only for signing
 	return &blsMultiSigner{
-		data:       data,
+		data:       newBlsMultiSigData(group, keySet, privKey, ownIndex),
 		mutSigData: sync.RWMutex{},
 		keyGen:     keyGen,
 		llSigner:   llSigner,
-	}, nil
+		keySet:     keySet,
+		rounds:     newRoundShares(DefaultRetainedRounds),
+	}
+}
+
+func createKeySet(privKeys []crypto.PrivateKey) (map[string]crypto.PrivateKey, error) {
+	keySet := make(map[string]crypto.PrivateKey, len(privKeys))
+	for i, privKey := range privKeys {
+		if check.IfNil(privKey) {
+			return nil, fmt.Errorf("%w at index %d", crypto.ErrNilPrivateKey, i)
+		}
+
+		pubKey := privKey.GeneratePublic()
+		if check.IfNil(pubKey) {
+			return nil, fmt.Errorf("%w at index %d", crypto.ErrNilPublicKey, i)
+		}
+
+		pubKeyBytes, err := pubKey.ToByteArray()
+		if err != nil {
+			return nil, fmt.Errorf("%w at index %d", err, i)
+		}
+
+		_, exists := keySet[string(pubKeyBytes)]
+		if exists {
+			return nil, fmt.Errorf("%w: duplicate private key at index %d", crypto.ErrInvalidParam, i)
+		}
+
+		keySet[string(pubKeyBytes)] = privKey
+	}
+
+	return keySet, nil
+}
+
+// newBlsMultiSigData creates the multiSigData for the consensus group. For a multi-signer with a key set, the owned
+// signers are looked up in the group and the given private key is ignored
+func newBlsMultiSigData(
+	group *consensusGroup,
+	keySet map[string]crypto.PrivateKey,
+	privKey crypto.PrivateKey,
+	ownIndex uint16,
+) *blsMultiSigData {
+	numPubKeys := len(group.pubKeys)
+	pubKeyIndexes := make(map[string]uint16, numPubKeys)
+	// iterate backwards so the first position is kept for a public key present several times in the group
+	for i := numPubKeys - 1; i >= 0; i-- {
+		pubKeyIndexes[group.serialized[i]] = uint16(i)
+	}
+
+	ownKeys := map[uint16]crypto.PrivateKey{ownIndex: privKey}
+	if keySet != nil {
+		ownKeys = make(map[uint16]crypto.PrivateKey)
+		for i, pubKey := range group.serialized {
+			ownedKey, owned := keySet[pubKey]
+			if owned {
+				ownKeys[uint16(i)] = ownedKey
+			}
+		}
+
+		privKey = ownKeys[ownIndex]
+	}
+
+	return &blsMultiSigData{
+		pubKeys:           group.pubKeys,
+		pubKeyIndexes:     pubKeyIndexes,
+		privKey:           privKey,
+		ownKeys:           ownKeys,
+		ownIndex:          ownIndex,
+		sigShares:         make([][]byte, numPubKeys),
+		rejectedSigShares: make([]uint32, numPubKeys),
+		invalidSigShares:  make([]bool, numPubKeys),
+	}
 }
 
 // Reset resets the multiSigData inside the multiSigner
@@ -102,38 +299,145 @@ func (bms *blsMultiSigner) Reset(pubKeys []string, index uint16) error {
 		return crypto.ErrIndexOutOfBounds
 	}
 
-	sizeConsensus := uint16(len(pubKeys))
-	sigShares := make([][]byte, sizeConsensus)
-	pk, err := convertStringsToPubKeys(pubKeys, bms.keyGen)
+	group, err := newConsensusGroupFromStrings(pubKeys, bms.keyGen)
+	if err != nil {
+		return err
+	}
+
+	bms.reset(group, index)
+
+	return nil
+}
+
+// ResetWithPubKeyBytes resets the multiSigData inside the multiSigner for the consensus group given by the serialized
+// public keys
+func (bms *blsMultiSigner) ResetWithPubKeyBytes(pubKeys [][]byte, index uint16) error {
+	if pubKeys == nil {
+		return crypto.ErrNilPublicKeys
+	}
+	if int(index) >= len(pubKeys) {
+		return crypto.ErrIndexOutOfBounds
+	}
 
+	group, err := newConsensusGroupFromBytes(pubKeys, bms.keyGen)
 	if err != nil {
 		return err
 	}
 
-	bms.mutSigData.Lock()
-	defer bms.mutSigData.Unlock()
+	bms.reset(group, index)
 
-	privKey := bms.data.privKey
+	return nil
+}
+
+// ResetWithPublicKeys resets the multiSigData inside the multiSigner for the consensus group given by the public keys
+func (bms *blsMultiSigner) ResetWithPublicKeys(pubKeys []crypto.PublicKey, index uint16) error {
+	if pubKeys == nil {
+		return crypto.ErrNilPublicKeys
+	}
+	if int(index) >= len(pubKeys) {
+		return crypto.ErrIndexOutOfBounds
+	}
 
-	data := &blsMultiSigData{
-		pubKeys:   pk,
-		privKey:   privKey,
-		ownIndex:  index,
-		sigShares: sigShares,
+	group, err := newConsensusGroupFromPublicKeys(pubKeys)
+	if err != nil {
+		return err
 	}
 
-	bms.data = data
+	bms.reset(group, index)
 
 	return nil
 }
 
-// Create generates a multiSigner and initializes corresponding fields with the given params
+func (bms *blsMultiSigner) reset(group *consensusGroup, index uint16) {
+	bms.mutSigData.Lock()
+	bms.data = newBlsMultiSigData(group, bms.keySet, bms.data.privKey, index)
+	bms.mutSigData.Unlock()
+}
+
+// Create generates a multiSigner and initializes corresponding fields with the given params. A multi-signer with a
+// key set creates a multi-signer with the same key set
 func (bms *blsMultiSigner) Create(pubKeys []string, index uint16) (crypto.MultiSigner, error) {
+	err := checkMultiSigArgs(bms.llSigner, bms.privKeyForCreate(), len(pubKeys), bms.keyGen, index)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := newConsensusGroupFromStrings(pubKeys, bms.keyGen)
+	if err != nil {
+		return nil, err
+	}
+
+	return bms.create(group, index), nil
+}
+
+// CreateWithPubKeyBytes generates a multiSigner for the consensus group given by the serialized public keys
+func (bms *blsMultiSigner) CreateWithPubKeyBytes(pubKeys [][]byte, index uint16) (crypto.MultiSigner, error) {
+	err := checkMultiSigArgs(bms.llSigner, bms.privKeyForCreate(), len(pubKeys), bms.keyGen, index)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := newConsensusGroupFromBytes(pubKeys, bms.keyGen)
+	if err != nil {
+		return nil, err
+	}
+
+	return bms.create(group, index), nil
+}
+
+// CreateWithPublicKeys generates a multiSigner for the consensus group given by the public keys
+func (bms *blsMultiSigner) CreateWithPublicKeys(pubKeys []crypto.PublicKey, index uint16) (crypto.MultiSigner, error) {
+	err := checkMultiSigArgs(bms.llSigner, bms.privKeyForCreate(), len(pubKeys), bms.keyGen, index)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := newConsensusGroupFromPublicKeys(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return bms.create(group, index), nil
+}
+
+// privKeyForCreate returns the private key of a single key multi-signer, or any key of the key set
+func (bms *blsMultiSigner) privKeyForCreate() crypto.PrivateKey {
+	for _, privKey := range bms.keySet {
+		return privKey
+	}
+
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	return bms.data.privKey
+}
+
+// create generates a multi-signer for the consensus group, keeping the key set and the strict mode
+func (bms *blsMultiSigner) create(group *consensusGroup, index uint16) *blsMultiSigner {
+	multiSigner := newBLSMultisig(bms.llSigner, group, bms.keySet, bms.privKeyForCreate(), bms.keyGen, index)
+
 	bms.mutSigData.RLock()
-	privKey := bms.data.privKey
+	multiSigner.strictMode = bms.strictMode
 	bms.mutSigData.RUnlock()
 
-	return NewBLSMultisig(bms.llSigner, pubKeys, privKey, bms.keyGen, index)
+	return multiSigner
+}
+
+// OwnIndexes returns the sorted positions in the consensus group of the signers owned by this node
+func (bms *blsMultiSigner) OwnIndexes() []uint16 {
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	ownIndexes := make([]uint16, 0, len(bms.data.ownKeys))
+	for index := range bms.data.ownKeys {
+		ownIndexes = append(ownIndexes, index)
+	}
+
+	sort.Slice(ownIndexes, func(i, j int) bool {
+		return ownIndexes[i] < ownIndexes[j]
+	})
+
+	return ownIndexes
 }
 
 // CreateSignatureShare returns a BLS single signature over the message
@@ -142,29 +446,29 @@ func (bms *blsMultiSigner) CreateSignatureShare(message []byte, _ []byte) ([]byt
 	defer bms.mutSigData.Unlock()
 
 	data := bms.data
+	if check.IfNil(data.privKey) {
+		return nil, crypto.ErrIndexNotSelected
+	}
+
+	err := bms.checkPinnedMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
 	sigShareBytes, err := bms.llSigner.SignShare(data.privKey, message)
 	if err != nil {
 		return nil, err
 	}
 
-	data.sigShares[data.ownIndex] = sigShareBytes
+	bms.pinMessage(message)
+	bms.setSigShare(data.ownIndex, sigShareBytes)
 
 	return sigShareBytes, nil
 }
 
 // not concurrent safe, should be used under RLock mutex
-func (bms *blsMultiSigner) isIndexInBitmap(index uint16, bitmap []byte) error {
-	indexOutOfBounds := index >= uint16(len(bms.data.pubKeys))
-	if indexOutOfBounds {
-		return crypto.ErrIndexOutOfBounds
-	}
-
-	indexNotInBitmap := bitmap[index/8]&(1<<uint8(index%8)) == 0
-	if indexNotInBitmap {
-		return crypto.ErrIndexNotSelected
-	}
-
-	return nil
+func (bms *blsMultiSigner) bitmapFromBytes(bitmap []byte) (*Bitmap, error) {
+	return NewBitmapFromBytes(bitmap, len(bms.data.pubKeys))
 }
 
 // VerifySignatureShare verifies the single signature share of the signer with specified position
@@ -188,7 +492,8 @@ func (bms *blsMultiSigner) VerifySignatureShare(index uint16, sig []byte, messag
 }
 
 // StoreSignatureShare stores the partial signature of the signer with specified position
-// Function does not validate the signature, as it expects caller to have already called VerifySignatureShare
+// Function does not validate the signature, as it expects caller to have already called VerifySignatureShare.
+// In strict mode the function fails with ErrUnverifiedSigShare, StoreVerifiedSignatureShare being used instead
 func (bms *blsMultiSigner) StoreSignatureShare(index uint16, sig []byte) error {
 	err := bms.llSigner.VerifySigBytes(bms.keyGen.Suite(), sig)
 	if err != nil {
@@ -198,6 +503,10 @@ func (bms *blsMultiSigner) StoreSignatureShare(index uint16, sig []byte) error {
 	bms.mutSigData.Lock()
 	defer bms.mutSigData.Unlock()
 
+	if bms.strictMode {
+		return crypto.ErrUnverifiedSigShare
+	}
+
 	if int(index) >= len(bms.data.sigShares) {
 		return crypto.ErrIndexOutOfBounds
 	}
@@ -207,96 +516,707 @@ func (bms *blsMultiSigner) StoreSignatureShare(index uint16, sig []byte) error {
 	return nil
 }
 
-// SignatureShare returns the partial signature set for given index
-func (bms *blsMultiSigner) SignatureShare(index uint16) ([]byte, error) {
-	bms.mutSigData.RLock()
-	defer bms.mutSigData.RUnlock()
-
-	if int(index) >= len(bms.data.sigShares) {
-		return nil, crypto.ErrIndexOutOfBounds
-	}
+// SetStrictMode enables or disables the strict mode, in which the signature shares are verified when stored and must
+// all be over the same message, pinned by the first share verified or created since the last Reset. The mode is kept
+// across calls of Reset and by the multi-signers generated with Create
+func (bms *blsMultiSigner) SetStrictMode(strict bool) {
+	bms.mutSigData.Lock()
+	bms.strictMode = strict
+	bms.mutSigData.Unlock()
+}
 
-	if bms.data.sigShares[index] == nil {
-		return nil, crypto.ErrNilElement
-	}
+/*
+StoreVerifiedSignatureShare verifies the partial signature of the signer with specified position over the message,
+against the public key of the signer, and stores it only if it is valid.
 
-	return bms.data.sigShares[index], nil
-}
+An invalid signature share is rejected and counted for the signer, a previously stored valid share being kept. In
+strict mode, a share verified against another message than the pinned one fails with ErrSigShareMessageMismatch,
+and the stored shares later found invalid by VerifySigShares are dropped, so AggregateSigs excludes them.
 
-// AggregateSigs aggregates all collected partial signatures
-func (bms *blsMultiSigner) AggregateSigs(bitmap []byte) ([]byte, error) {
-	if bitmap == nil {
-		return nil, crypto.ErrNilBitmap
+If an equivocation detector is set, the valid share is recorded for the current round set by SetCurrentRound, and a
+share of a signer who signed another message in the round is not stored, an EquivocationError holding the proof being
+returned instead
+*/
+func (bms *blsMultiSigner) StoreVerifiedSignatureShare(index uint16, sig []byte, message []byte) error {
+	if sig == nil {
+		return crypto.ErrNilSignature
+	}
+	if len(message) == 0 {
+		return crypto.ErrNilMessage
 	}
 
 	bms.mutSigData.Lock()
 	defer bms.mutSigData.Unlock()
 
-	maxFlags := len(bitmap) * 8
-	flagsMismatch := maxFlags < len(bms.data.pubKeys)
-	if flagsMismatch {
-		return nil, crypto.ErrBitmapMismatch
+	if int(index) >= len(bms.data.sigShares) {
+		return crypto.ErrIndexOutOfBounds
 	}
 
-	// for the modified BLS scheme, aggregation is done not between sigs but between H1(pk_i, {pk1,..., pk_n})*sig_i
-	signatures := make([][]byte, 0, len(bms.data.sigShares))
-	pubKeysSigners := make([]crypto.PublicKey, 0, len(bms.data.sigShares))
-
-	for i := range bms.data.sigShares {
-		err := bms.isIndexInBitmap(uint16(i), bitmap)
-		if err != nil {
-			continue
-		}
-
-		signatures = append(signatures, bms.data.sigShares[i])
-		pubKeysSigners = append(pubKeysSigners, bms.data.pubKeys[i])
+	errPinned := bms.checkPinnedMessage(message)
+	if errPinned != nil && bms.detector == nil {
+		return errPinned
 	}
 
-	return bms.llSigner.AggregateSignatures(bms.keyGen.Suite(), signatures, pubKeysSigners)
-}
+	err := bms.llSigner.VerifySigShare(bms.data.pubKeys[index], message, sig)
+	if err != nil {
+		bms.data.rejectedSigShares[index]++
+		return err
+	}
 
-// SetAggregatedSig sets the aggregated signature
-func (bms *blsMultiSigner) SetAggregatedSig(aggSig []byte) error {
-	err := bms.llSigner.VerifySigBytes(bms.keyGen.Suite(), aggSig)
+	err = bms.recordSigShare(bms.rounds.currentRound, bms.data.pubKeys[index], index, message, sig)
 	if err != nil {
 		return err
 	}
+	if errPinned != nil {
+		return errPinned
+	}
 
-	bms.mutSigData.Lock()
-	bms.data.aggSig = aggSig
-	bms.mutSigData.Unlock()
+	bms.pinMessage(message)
+	bms.setSigShare(index, sig)
 
 	return nil
 }
 
-// Verify verifies the aggregated signature by checking that aggregated signature is valid with respect
-// to aggregated public keys.
-func (bms *blsMultiSigner) Verify(message []byte, bitmap []byte) error {
-	if bitmap == nil {
-		return crypto.ErrNilBitmap
-	}
-
+// RejectedSigShares returns the number of signature shares of the signer with specified position rejected since the
+// last Reset, either when stored or when later found invalid in strict mode
+func (bms *blsMultiSigner) RejectedSigShares(index uint16) (uint32, error) {
 	bms.mutSigData.RLock()
 	defer bms.mutSigData.RUnlock()
 
-	maxFlags := len(bitmap) * 8
-	flagsMismatch := maxFlags < len(bms.data.pubKeys)
-	if flagsMismatch {
-		return crypto.ErrBitmapMismatch
+	if int(index) >= len(bms.data.rejectedSigShares) {
+		return 0, crypto.ErrIndexOutOfBounds
 	}
 
-	pubKeys := make([]crypto.PublicKey, 0)
-	for i := range bms.data.pubKeys {
-		err := bms.isIndexInBitmap(uint16(i), bitmap)
-		if err != nil {
-			continue
-		}
+	return bms.data.rejectedSigShares[index], nil
+}
 
-		pubKeys = append(pubKeys, bms.data.pubKeys[i])
+// ValidSignersBitmap returns the bitmap without the signers whose signature share was found invalid in strict mode,
+// which are the signers of the signature returned by AggregateSigs for the given bitmap
+func (bms *blsMultiSigner) ValidSignersBitmap(bitmap []byte) ([]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
 	}
 
-	return bms.llSigner.VerifyAggregatedSig(bms.keyGen.Suite(), pubKeys, bms.data.aggSig, message)
-}
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	selection, err := bms.bitmapFromBytes(bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	bms.excludeInvalidSigShares(selection)
+
+	return selection.Bytes(), nil
+}
+
+// checkPinnedMessage fails in strict mode if the message is not the pinned one
+// not concurrent safe, should be used under RLock mutex
+func (bms *blsMultiSigner) checkPinnedMessage(message []byte) error {
+	if !bms.strictMode || bms.data.message == nil {
+		return nil
+	}
+	if !bytes.Equal(bms.data.message, message) {
+		return crypto.ErrSigShareMessageMismatch
+	}
+
+	return nil
+}
+
+// pinMessage pins the message in strict mode if no message is pinned yet
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) pinMessage(message []byte) {
+	if !bms.strictMode || bms.data.message != nil || len(message) == 0 {
+		return
+	}
+
+	bms.data.message = append([]byte{}, message...)
+}
+
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) setSigShare(index uint16, sig []byte) {
+	bms.data.sigShares[index] = sig
+	bms.data.invalidSigShares[index] = false
+}
+
+// not concurrent safe, should be used under RLock mutex
+func (bms *blsMultiSigner) excludeInvalidSigShares(bitmap *Bitmap) {
+	for index, invalid := range bms.data.invalidSigShares {
+		if invalid {
+			_ = bitmap.Unset(index)
+		}
+	}
+}
+
+// SignatureShare returns the partial signature set for given index
+func (bms *blsMultiSigner) SignatureShare(index uint16) ([]byte, error) {
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	if int(index) >= len(bms.data.sigShares) {
+		return nil, crypto.ErrIndexOutOfBounds
+	}
+
+	if bms.data.sigShares[index] == nil {
+		return nil, crypto.ErrNilElement
+	}
+
+	return bms.data.sigShares[index], nil
+}
+
+// AggregateSigs aggregates all collected partial signatures
+func (bms *blsMultiSigner) AggregateSigs(bitmap []byte) ([]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	selection, err := bms.bitmapFromBytes(bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	return bms.aggregateSigs(selection)
+}
+
+// AggregateSigsWithBitmap aggregates the collected partial signatures of the signers selected by the bitmap
+func (bms *blsMultiSigner) AggregateSigsWithBitmap(bitmap *Bitmap) ([]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	err := bitmap.CheckSize(len(bms.data.pubKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	return bms.aggregateSigs(bitmap)
+}
+
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) aggregateSigs(bitmap *Bitmap) ([]byte, error) {
+	// the signature shares found invalid in strict mode are excluded
+	validSigners := &Bitmap{
+		bits: bitmap.Bytes(),
+		size: bitmap.Size(),
+	}
+	bms.excludeInvalidSigShares(validSigners)
+	if validSigners.Count() == 0 && bitmap.Count() > 0 {
+		return nil, crypto.ErrSigNotValid
+	}
+
+	return bms.aggregateSigShares(bms.data.pubKeys, bms.data.sigShares, validSigners)
+}
+
+// aggregateSigShares aggregates the selected shares, positioned in the given consensus group
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) aggregateSigShares(
+	pubKeys []crypto.PublicKey,
+	sigShares [][]byte,
+	bitmap *Bitmap,
+) ([]byte, error) {
+	// for the modified BLS scheme, aggregation is done not between sigs but between H1(pk_i, {pk1,..., pk_n})*sig_i
+	signatures := make([][]byte, 0, len(sigShares))
+	pubKeysSigners := make([]crypto.PublicKey, 0, len(sigShares))
+
+	bitmap.ForEach(func(index int) {
+		signatures = append(signatures, sigShares[index])
+		pubKeysSigners = append(pubKeysSigners, pubKeys[index])
+	})
+
+	return bms.llSigner.AggregateSignatures(bms.keyGen.Suite(), signatures, pubKeysSigners)
+}
+
+// SetRetainedRounds sets the number of rounds up to the current one for which the signature shares are retained,
+// evicting the older rounds
+func (bms *blsMultiSigner) SetRetainedRounds(numRounds int) error {
+	if numRounds <= 0 {
+		return crypto.ErrInvalidParam
+	}
+
+	bms.mutSigData.Lock()
+	bms.rounds.setMaxRounds(numRounds)
+	bms.mutSigData.Unlock()
+
+	return nil
+}
+
+/*
+SetCurrentRound sets the round the consensus is in, which anchors the window of retained rounds. The shares of the
+rounds older than the retained ones are evicted.
+
+The current consensus group is set as the group of the round, so the multi-signer should be reset to the consensus
+group of the round first. The shares of a round are verified and aggregated against the group of their round, which
+is otherwise the current group when the first share of the round is stored
+*/
+func (bms *blsMultiSigner) SetCurrentRound(round uint64) {
+	bms.mutSigData.Lock()
+	bms.rounds.setCurrentRound(round)
+	bms.rounds.setGroup(round, bms.data.pubKeys)
+	if bms.detector != nil {
+		bms.detector.SetCurrentRound(round)
+	}
+	bms.mutSigData.Unlock()
+}
+
+/*
+StoreSignatureShareForRound stores the partial signature of the signer with specified position for the given round.
+The shares of a round are kept across calls of Reset, so late shares from the retained previous rounds and early
+shares for the next round can be held, shares for any other round failing with ErrRoundOutOfWindow.
+
+The signer is the one at that position in the consensus group of the round, as set by SetCurrentRound. The function
+does not validate the signature and fails in strict mode with ErrUnverifiedSigShare, StoreVerifiedSignatureShareForRound
+being used instead
+*/
+func (bms *blsMultiSigner) StoreSignatureShareForRound(round uint64, index uint16, sig []byte) error {
+	err := bms.llSigner.VerifySigBytes(bms.keyGen.Suite(), sig)
+	if err != nil {
+		return err
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	if bms.strictMode {
+		return crypto.ErrUnverifiedSigShare
+	}
+
+	pubKeys := bms.rounds.group(round, bms.data.pubKeys)
+	if int(index) >= len(pubKeys) {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	return bms.rounds.store(round, pubKeys, index, sig, nil)
+}
+
+/*
+StoreVerifiedSignatureShareForRound verifies the partial signature of the signer with specified position for the given
+round over the message, against the public key at that position in the consensus group of the round, and stores it
+only if it is valid. The shares for a round verified against different messages are rejected with
+ErrSigShareMessageMismatch, the message of the first verified share being pinned for the round.
+
+As the group of a round not set yet is the current one, the early shares for the next round should be stored once its
+group is set by SetCurrentRound. In strict mode the round shares are verified again when aggregated, the invalid ones
+being excluded.
+
+If an equivocation detector is set, the valid share is recorded for the round, and a share of a signer who signed
+another message in the round is not stored, an EquivocationError holding the proof being returned instead
+*/
+func (bms *blsMultiSigner) StoreVerifiedSignatureShareForRound(
+	round uint64,
+	index uint16,
+	sig []byte,
+	message []byte,
+) error {
+	if sig == nil {
+		return crypto.ErrNilSignature
+	}
+	if len(message) == 0 {
+		return crypto.ErrNilMessage
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	pubKeys := bms.rounds.group(round, bms.data.pubKeys)
+	if int(index) >= len(pubKeys) {
+		return crypto.ErrIndexOutOfBounds
+	}
+
+	pinnedMessage := bms.rounds.message(round)
+	isPinnedMessage := pinnedMessage == nil || bytes.Equal(pinnedMessage, message)
+	if !isPinnedMessage && bms.detector == nil {
+		return crypto.ErrSigShareMessageMismatch
+	}
+
+	err := bms.llSigner.VerifySigShare(pubKeys[index], message, sig)
+	if err != nil {
+		return err
+	}
+
+	err = bms.recordSigShare(round, pubKeys[index], index, message, sig)
+	if err != nil {
+		return err
+	}
+	if !isPinnedMessage {
+		return crypto.ErrSigShareMessageMismatch
+	}
+
+	return bms.rounds.store(round, pubKeys, index, sig, message)
+}
+
+/*
+SetEquivocationDetector sets the detector recording the signature shares verified by the multi-signer, a nil detector
+disabling the detection. The current round of the detector is then set by SetCurrentRound, so the detector should not
+be shared with other multi-signers
+*/
+func (bms *blsMultiSigner) SetEquivocationDetector(detector *equivocationDetector) {
+	bms.mutSigData.Lock()
+	bms.detector = detector
+	bms.mutSigData.Unlock()
+}
+
+// recordSigShare records the verified signature share in the equivocation detector, if set, returning an
+// EquivocationError if the signer signed another message in the round. The shares for rounds outside the window of
+// the detector are not recorded
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) recordSigShare(
+	round uint64,
+	pubKey crypto.PublicKey,
+	index uint16,
+	message []byte,
+	sig []byte,
+) error {
+	if bms.detector == nil {
+		return nil
+	}
+
+	pubKeyBytes, err := pubKey.ToByteArray()
+	if err != nil {
+		return err
+	}
+
+	proof, err := bms.detector.record(round, pubKeyBytes, index, message, sig)
+	if err == crypto.ErrRoundOutOfWindow {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if proof != nil {
+		return &EquivocationError{Proof: proof}
+	}
+
+	return nil
+}
+
+// SignatureShareForRound returns the partial signature set for given index in the given round
+func (bms *blsMultiSigner) SignatureShareForRound(round uint64, index uint16) ([]byte, error) {
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	sig, exists := bms.rounds.get(round, index)
+	if !exists {
+		return nil, crypto.ErrNilElement
+	}
+
+	return sig, nil
+}
+
+/*
+AggregateSigsForRound aggregates the partial signatures collected for the given round, the signers being selected by
+the bitmap out of the consensus group of the round.
+
+In strict mode, the selected shares are verified against the message pinned for the round and the consensus group of
+the round, the invalid ones being dropped and excluded from the aggregation, as reported by ValidSignersBitmapForRound.
+The round shares stored without verification fail the aggregation in strict mode with ErrUnverifiedSigShare
+*/
+func (bms *blsMultiSigner) AggregateSigsForRound(round uint64, bitmap []byte) ([]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	pubKeys := bms.rounds.group(round, bms.data.pubKeys)
+	selection, err := NewBitmapFromBytes(bitmap, len(pubKeys))
+	if err != nil {
+		return nil, err
+	}
+
+	sigShares, exists := bms.rounds.sharesForGroup(round, len(pubKeys))
+	if !exists {
+		return nil, crypto.ErrNilElement
+	}
+
+	if !bms.strictMode {
+		return bms.aggregateSigShares(pubKeys, sigShares, selection)
+	}
+
+	err = bms.dropInvalidRoundSigShares(round, pubKeys, sigShares, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	validSigners := &Bitmap{
+		bits: selection.Bytes(),
+		size: selection.Size(),
+	}
+	bms.rounds.excludeInvalid(round, validSigners)
+	if validSigners.Count() == 0 && selection.Count() > 0 {
+		return nil, crypto.ErrSigNotValid
+	}
+
+	return bms.aggregateSigShares(pubKeys, sigShares, validSigners)
+}
+
+// dropInvalidRoundSigShares verifies the selected shares of the round against the message pinned for the round and the
+// consensus group of the round, dropping the invalid ones
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) dropInvalidRoundSigShares(
+	round uint64,
+	pubKeys []crypto.PublicKey,
+	sigShares [][]byte,
+	bitmap *Bitmap,
+) error {
+	indexes := make([]int, 0, bitmap.Count())
+	bitmap.ForEach(func(index int) {
+		if len(sigShares[index]) > 0 {
+			indexes = append(indexes, index)
+		}
+	})
+	if len(indexes) == 0 {
+		return nil
+	}
+
+	message := bms.rounds.message(round)
+	if message == nil {
+		return crypto.ErrUnverifiedSigShare
+	}
+
+	invalidIndexes, err := bms.findInvalidSigShares(pubKeys, message, sigShares, indexes)
+	if err != nil {
+		return err
+	}
+
+	bms.rounds.drop(round, invalidIndexes)
+	for _, index := range invalidIndexes {
+		sigShares[index] = nil
+	}
+
+	return nil
+}
+
+// ValidSignersBitmapForRound returns the bitmap without the signers whose signature share for the round was found
+// invalid in strict mode, which are the signers of the signature returned by AggregateSigsForRound for the bitmap
+func (bms *blsMultiSigner) ValidSignersBitmapForRound(round uint64, bitmap []byte) ([]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	selection, err := NewBitmapFromBytes(bitmap, len(bms.rounds.group(round, bms.data.pubKeys)))
+	if err != nil {
+		return nil, err
+	}
+
+	bms.rounds.excludeInvalid(round, selection)
+
+	return selection.Bytes(), nil
+}
+
+// RemoveRound removes the signature shares stored for the given round
+func (bms *blsMultiSigner) RemoveRound(round uint64) {
+	bms.mutSigData.Lock()
+	bms.rounds.remove(round)
+	bms.mutSigData.Unlock()
+}
+
+// SetAggregatedSig sets the aggregated signature
+func (bms *blsMultiSigner) SetAggregatedSig(aggSig []byte) error {
+	err := bms.llSigner.VerifySigBytes(bms.keyGen.Suite(), aggSig)
+	if err != nil {
+		return err
+	}
+
+	bms.mutSigData.Lock()
+	bms.data.aggSig = aggSig
+	bms.mutSigData.Unlock()
+
+	return nil
+}
+
+// Verify verifies the aggregated signature by checking that aggregated signature is valid with respect
+// to aggregated public keys.
+func (bms *blsMultiSigner) Verify(message []byte, bitmap []byte) error {
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	selection, err := bms.bitmapFromBytes(bitmap)
+	if err != nil {
+		return err
+	}
+
+	return bms.verify(message, selection)
+}
+
+// VerifyWithBitmap verifies the aggregated signature against the aggregated public keys of the signers selected by
+// the bitmap
+func (bms *blsMultiSigner) VerifyWithBitmap(message []byte, bitmap *Bitmap) error {
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	err := bitmap.CheckSize(len(bms.data.pubKeys))
+	if err != nil {
+		return err
+	}
+
+	return bms.verify(message, bitmap)
+}
+
+// not concurrent safe, should be used under RLock mutex
+func (bms *blsMultiSigner) verify(message []byte, bitmap *Bitmap) error {
+	pubKeys := make([]crypto.PublicKey, 0, bitmap.Count())
+	bitmap.ForEach(func(index int) {
+		pubKeys = append(pubKeys, bms.data.pubKeys[index])
+	})
+
+	return bms.llSigner.VerifyAggregatedSig(bms.keyGen.Suite(), pubKeys, bms.data.aggSig, message)
+}
+
+/*
+VerifySigShares re-checks the stored signature shares of the signers selected by the bitmap, to find out which of them
+broke an aggregated signature that failed verification. The shares are verified as a batch when the low level signer
+supports it, bisecting the set to locate the invalid ones, and one by one otherwise.
+
+If any of the selected signers has a missing, malformed or invalid signature share for the message, the returned
+error is a *crypto.InvalidSignaturesError wrapping crypto.ErrSigNotValid, holding the sorted positions of the
+offending signers in the consensus group.
+
+In strict mode, the message must be the pinned one, otherwise ErrSigShareMessageMismatch is returned, and the stored
+signature shares found invalid are dropped and counted as rejected, so they are excluded from the following
+aggregations
+*/
+func (bms *blsMultiSigner) VerifySigShares(message []byte, bitmap []byte) error {
+	if len(message) == 0 {
+		return crypto.ErrNilMessage
+	}
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	selection, err := bms.bitmapFromBytes(bitmap)
+	if err != nil {
+		return err
+	}
+
+	err = bms.checkPinnedMessage(message)
+	if err != nil {
+		return err
+	}
+
+	invalidIndexes := make([]int, 0)
+	indexes := make([]int, 0, selection.Count())
+	selection.ForEach(func(index int) {
+		if len(bms.data.sigShares[index]) == 0 {
+			invalidIndexes = append(invalidIndexes, index)
+			return
+		}
+
+		indexes = append(indexes, index)
+	})
+
+	invalidSharesIndexes, err := bms.findInvalidSigShares(bms.data.pubKeys, message, bms.data.sigShares, indexes)
+	if err != nil {
+		return err
+	}
+
+	if bms.strictMode {
+		bms.pinMessage(message)
+		bms.dropSigShares(invalidSharesIndexes)
+	}
+
+	invalidIndexes = append(invalidIndexes, invalidSharesIndexes...)
+	if len(invalidIndexes) == 0 {
+		return nil
+	}
+
+	sort.Ints(invalidIndexes)
+
+	return &crypto.InvalidSignaturesError{
+		Indexes: invalidIndexes,
+		Err:     crypto.ErrSigNotValid,
+	}
+}
+
+// not concurrent safe, should be used under Lock mutex
+func (bms *blsMultiSigner) dropSigShares(indexes []int) {
+	for _, index := range indexes {
+		bms.data.sigShares[index] = nil
+		bms.data.invalidSigShares[index] = true
+		bms.data.rejectedSigShares[index]++
+	}
+}
+
+// not concurrent safe, should be used under RLock mutex
+func (bms *blsMultiSigner) findInvalidSigShares(
+	pubKeys []crypto.PublicKey,
+	message []byte,
+	sigShares [][]byte,
+	indexes []int,
+) ([]int, error) {
+	if len(indexes) == 0 {
+		return nil, nil
+	}
+
+	verifier, ok := bms.llSigner.(batchVerifier)
+	if !ok {
+		return bms.findInvalidSigSharesOneByOne(pubKeys, message, sigShares, indexes), nil
+	}
+
+	signersPubKeys := make([]crypto.PublicKey, len(indexes))
+	msgs := make([][]byte, len(indexes))
+	sigs := make([][]byte, len(indexes))
+	for i, index := range indexes {
+		signersPubKeys[i] = pubKeys[index]
+		msgs[i] = message
+		sigs[i] = sigShares[index]
+	}
+
+	err := verifier.VerifyBatch(signersPubKeys, msgs, sigs)
+	if err == nil {
+		return nil, nil
+	}
+
+	var invalidSigsErr *crypto.InvalidSignaturesError
+	if !errors.As(err, &invalidSigsErr) {
+		return nil, err
+	}
+
+	invalidIndexes := make([]int, 0, len(invalidSigsErr.Indexes))
+	for _, batchIndex := range invalidSigsErr.Indexes {
+		invalidIndexes = append(invalidIndexes, indexes[batchIndex])
+	}
+
+	return invalidIndexes, nil
+}
+
+// not concurrent safe, should be used under RLock mutex
+func (bms *blsMultiSigner) findInvalidSigSharesOneByOne(
+	pubKeys []crypto.PublicKey,
+	message []byte,
+	sigShares [][]byte,
+	indexes []int,
+) []int {
+	invalidIndexes := make([]int, 0)
+	for _, index := range indexes {
+		err := bms.llSigner.VerifySigShare(pubKeys[index], message, sigShares[index])
+		if err != nil {
+			invalidIndexes = append(invalidIndexes, index)
+		}
+	}
+
+	return invalidIndexes
+}
 
 // CreateAndAddSignatureShareForKey will manually create and add the signature share for the provided key
 func (bms *blsMultiSigner) CreateAndAddSignatureShareForKey(
@@ -307,26 +1227,75 @@ func (bms *blsMultiSigner) CreateAndAddSignatureShareForKey(
 	bms.mutSigData.Lock()
 	defer bms.mutSigData.Unlock()
 
-	for index, pk := range bms.data.pubKeys {
-		pkBytes, err := pk.ToByteArray()
-		if err != nil {
-			continue
-		}
+	index, found := bms.data.pubKeyIndexes[string(pubKeyBytes)]
+	if !found {
+		return nil, crypto.ErrIndexNotSelected
+	}
+
+	err := bms.checkPinnedMessage(message)
+	if err != nil {
+		return nil, err
+	}
+
+	sigShareBytes, err := bms.llSigner.SignShare(privateKey, message)
+	if err != nil {
+		return nil, err
+	}
+
+	bms.pinMessage(message)
+	bms.setSigShare(index, sigShareBytes)
+
+	return sigShareBytes, nil
+}
+
+/*
+CreateSignatureSharesForBitmap creates and stores in one call the signature shares of all the signers selected by the
+bitmap that are owned by this node, returning them mapped by the positions of the signers in the consensus group.
+
+ErrIndexNotSelected is returned if none of the owned signers is selected by the bitmap
+*/
+func (bms *blsMultiSigner) CreateSignatureSharesForBitmap(message []byte, bitmap []byte) (map[uint16][]byte, error) {
+	if bitmap == nil {
+		return nil, crypto.ErrNilBitmap
+	}
+
+	bms.mutSigData.Lock()
+	defer bms.mutSigData.Unlock()
+
+	selection, err := bms.bitmapFromBytes(bitmap)
+	if err != nil {
+		return nil, err
+	}
+
+	err = bms.checkPinnedMessage(message)
+	if err != nil {
+		return nil, err
+	}
 
-		if bytes.Compare(pkBytes, pubKeyBytes) != 0 {
+	sigShares := make(map[uint16][]byte)
+	for index, privKey := range bms.data.ownKeys {
+		if !selection.IsSet(int(index)) {
 			continue
 		}
 
-		sigShareBytes, err := bms.llSigner.SignShare(privateKey, message)
-		if err != nil {
-			return nil, err
+		sigShareBytes, errSign := bms.llSigner.SignShare(privKey, message)
+		if errSign != nil {
+			return nil, errSign
 		}
 
-		bms.data.sigShares[index] = sigShareBytes
-		return sigShareBytes, nil
+		sigShares[index] = sigShareBytes
+	}
+
+	if len(sigShares) == 0 {
+		return nil, crypto.ErrIndexNotSelected
+	}
+
+	bms.pinMessage(message)
+	for index, sigShareBytes := range sigShares {
+		bms.setSigShare(index, sigShareBytes)
 	}
 
-	return nil, crypto.ErrIndexNotSelected
+	return sigShares, nil
 }
 
 // IsInterfaceNil returns true if there is no value under the interface
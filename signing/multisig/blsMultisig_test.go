@@ -1,6 +1,7 @@
 package multisig_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/ME-MotherEarth/me-core/core/check"
@@ -1163,6 +1164,9 @@ func TestBlsMultiSigner_CreateAndAddSignatureShareForKey(t *testing.T) {
 		require.Nil(t, err)
 	}
 
+	_, err = multiSigCreated.CreateAndAddSignatureShareForKey(msg, sk, []byte("unknown public key"))
+	require.Equal(t, crypto.ErrIndexNotSelected, err)
+
 	allSigSharesBitmap := []byte{15}
 	sig, err := multiSigCreated.AggregateSigs(allSigSharesBitmap)
 	require.Nil(t, err)
@@ -1190,3 +1194,566 @@ func TestBlsMultiSigner_CreateAndAddSignatureShareForKey(t *testing.T) {
 	err = multiSigKOSKVerify.Verify(msg, allSigSharesBitmap)
 	require.Nil(t, err)
 }
+
+// lowLevelSignerWithoutBatch hides the batch verification of the wrapped low level signer
+type lowLevelSignerWithoutBatch struct {
+	crypto.LowLevelSignerBLS
+}
+
+func TestBLSMultiSigner_VerifySigShares(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigners := map[string]crypto.LowLevelSignerBLS{
+		"with rogue key prevention": &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}},
+		"with KOSK":                 &llsig.BlsMultiSignerKOSK{},
+		"without batch verification": &lowLevelSignerWithoutBatch{
+			LowLevelSignerBLS: &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}},
+		},
+	}
+
+	for name, llSigner := range llSigners {
+		llSigner := llSigner
+		t.Run(name+" invalid params should err", func(t *testing.T) {
+			t.Parallel()
+
+			multiSigner, bitmap := createAndAddSignatureSharesBLS(msg, llSigner)
+			verifier := multiSigner.(crypto.SigSharesVerifier)
+			require.False(t, check.IfNil(verifier))
+
+			require.Equal(t, crypto.ErrNilMessage, verifier.VerifySigShares(nil, bitmap))
+			require.Equal(t, crypto.ErrNilBitmap, verifier.VerifySigShares(msg, nil))
+			require.Equal(t, crypto.ErrBitmapMismatch, verifier.VerifySigShares(msg, []byte{0x07}))
+		})
+		t.Run(name+" valid shares should not err", func(t *testing.T) {
+			t.Parallel()
+
+			multiSigner, bitmap := createAndAddSignatureSharesBLS(msg, llSigner)
+			verifier := multiSigner.(crypto.SigSharesVerifier)
+
+			require.Nil(t, verifier.VerifySigShares(msg, bitmap))
+			require.Nil(t, verifier.VerifySigShares(msg, make([]byte, 2)))
+		})
+		t.Run(name+" should return the offending indexes", func(t *testing.T) {
+			t.Parallel()
+
+			sigShares, multiSigner := createSigSharesBLS(12, 15, msg, 0, llSigner)
+			for i := range sigShares {
+				// index 9 is selected by the bitmap but has no signature share
+				if i == 9 {
+					continue
+				}
+				// index 3 holds a valid signature of another signer
+				sigShare := sigShares[i]
+				if i == 3 {
+					sigShare = sigShares[4]
+				}
+				// index 11 holds a valid signature of another signer, but it is not selected by the bitmap
+				if i == 11 {
+					sigShare = sigShares[5]
+				}
+
+				err := multiSigner.StoreSignatureShare(uint16(i), sigShare)
+				require.Nil(t, err)
+			}
+
+			bitmap := []byte{0xff, 0x07}
+			_, err := multiSigner.AggregateSigs(bitmap)
+			require.NotNil(t, err)
+
+			err = multiSigner.(crypto.SigSharesVerifier).VerifySigShares(msg, bitmap)
+			require.True(t, errors.Is(err, crypto.ErrSigNotValid))
+
+			var invalidSigsErr *crypto.InvalidSignaturesError
+			require.True(t, errors.As(err, &invalidSigsErr))
+			require.Equal(t, []int{3, 9}, invalidSigsErr.Indexes)
+
+			// all the shares are invalid for another message
+			err = multiSigner.(crypto.SigSharesVerifier).VerifySigShares([]byte("another message"), []byte{0x0f, 0x00})
+			require.True(t, errors.As(err, &invalidSigsErr))
+			require.Equal(t, []int{0, 1, 2, 3}, invalidSigsErr.Indexes)
+		})
+	}
+}
+
+func TestBLSMultiSigner_AggregateSigsAndVerifyWithBitmap(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	sigShares, multiSigner := createSigSharesBLS(3, 15, msg, 0, llSigner)
+	for i, sigShare := range sigShares {
+		_ = multiSigner.StoreSignatureShare(uint16(i), sigShare)
+	}
+
+	type bitmapMultiSigner interface {
+		AggregateSigsWithBitmap(bitmap *multisig.Bitmap) ([]byte, error)
+		VerifyWithBitmap(message []byte, bitmap *multisig.Bitmap) error
+	}
+	bitmapSigner := multiSigner.(bitmapMultiSigner)
+
+	_, err := bitmapSigner.AggregateSigsWithBitmap(nil)
+	require.Equal(t, crypto.ErrNilBitmap, err)
+	require.Equal(t, crypto.ErrNilBitmap, bitmapSigner.VerifyWithBitmap(msg, nil))
+
+	wrongSizeBitmap, _ := multisig.NewBitmap(16)
+	_, err = bitmapSigner.AggregateSigsWithBitmap(wrongSizeBitmap)
+	require.Equal(t, crypto.ErrBitmapMismatch, err)
+	require.Equal(t, crypto.ErrBitmapMismatch, bitmapSigner.VerifyWithBitmap(msg, wrongSizeBitmap))
+
+	bitmap, _ := multisig.NewBitmap(15)
+	for i := range sigShares {
+		_ = bitmap.Set(i)
+	}
+
+	aggSig, err := bitmapSigner.AggregateSigsWithBitmap(bitmap)
+	require.Nil(t, err)
+
+	// same result as with the raw byte slice bitmap
+	aggSigRaw, err := multiSigner.AggregateSigs(bitmap.Bytes())
+	require.Nil(t, err)
+	require.Equal(t, aggSigRaw, aggSig)
+
+	err = multiSigner.SetAggregatedSig(aggSig)
+	require.Nil(t, err)
+	require.Nil(t, bitmapSigner.VerifyWithBitmap(msg, bitmap))
+	require.Nil(t, multiSigner.Verify(msg, bitmap.Bytes()))
+	require.Equal(t, crypto.ErrAggSigNotValid, bitmapSigner.VerifyWithBitmap([]byte("other message"), bitmap))
+
+	_ = bitmap.Unset(1)
+	require.Equal(t, crypto.ErrAggSigNotValid, bitmapSigner.VerifyWithBitmap(msg, bitmap))
+}
+
+func TestNewBLSMultisigWithKeySet_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+
+	_, err := multisig.NewBLSMultisigWithKeySet(nil, pubKeys, privKeys, kg, 0)
+	require.Equal(t, crypto.ErrNilLowLevelSigner, err)
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, nil, kg, 0)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, nil, privKeys, kg, 0)
+	require.Equal(t, crypto.ErrNoPublicKeySet, err)
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, privKeys, nil, 0)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, privKeys, kg, 4)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, []crypto.PrivateKey{privKeys[0], nil}, kg, 0)
+	require.True(t, errors.Is(err, crypto.ErrNilPrivateKey))
+
+	_, err = multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, []crypto.PrivateKey{privKeys[1], privKeys[1]}, kg, 0)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+}
+
+func TestBLSMultiSigner_KeySetOwnIndexes(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(7, 0)
+	otherSk, _ := kg.GeneratePair()
+	keySet := []crypto.PrivateKey{privKeys[5], otherSk, privKeys[1], privKeys[3]}
+
+	multiSigner, err := multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, keySet, kg, 0)
+	require.Nil(t, err)
+	require.False(t, check.IfNil(multiSigner))
+	require.Equal(t, []uint16{1, 3, 5}, multiSigner.OwnIndexes())
+
+	// the signer with the own index is not owned
+	_, err = multiSigner.CreateSignatureShare([]byte("message"), nil)
+	require.Equal(t, crypto.ErrIndexNotSelected, err)
+
+	// the owned signers are looked up again in the new consensus group
+	newPubKeys := []string{pubKeys[3], pubKeys[6], pubKeys[0]}
+	err = multiSigner.Reset(newPubKeys, 0)
+	require.Nil(t, err)
+	require.Equal(t, []uint16{0}, multiSigner.OwnIndexes())
+
+	sigShare, err := multiSigner.CreateSignatureShare([]byte("message"), nil)
+	require.Nil(t, err)
+	require.Nil(t, multiSigner.VerifySignatureShare(0, sigShare, []byte("message"), nil))
+
+	created, err := multiSigner.Create(pubKeys, 2)
+	require.Nil(t, err)
+	require.Equal(t, []uint16{1, 3, 5}, created.(interface{ OwnIndexes() []uint16 }).OwnIndexes())
+
+	_, err = multiSigner.Create(pubKeys, 7)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+}
+
+func TestBLSMultiSigner_CreateSignatureSharesForBitmap(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(10, 0)
+	keySet := []crypto.PrivateKey{privKeys[1], privKeys[3], privKeys[8]}
+
+	multiSigner, err := multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, keySet, kg, 1)
+	require.Nil(t, err)
+
+	_, err = multiSigner.CreateSignatureSharesForBitmap(msg, nil)
+	require.Equal(t, crypto.ErrNilBitmap, err)
+
+	_, err = multiSigner.CreateSignatureSharesForBitmap(msg, []byte{0x01})
+	require.Equal(t, crypto.ErrBitmapMismatch, err)
+
+	// none of the owned signers is selected
+	_, err = multiSigner.CreateSignatureSharesForBitmap(msg, []byte{0x05, 0x00})
+	require.Equal(t, crypto.ErrIndexNotSelected, err)
+
+	// signers 0, 1, 2 and 3 selected, 1 and 3 being owned
+	bitmap := []byte{0x0f, 0x00}
+	sigShares, err := multiSigner.CreateSignatureSharesForBitmap(msg, bitmap)
+	require.Nil(t, err)
+	require.Equal(t, 2, len(sigShares))
+	for _, index := range []uint16{1, 3} {
+		require.Nil(t, multiSigner.VerifySignatureShare(index, sigShares[index], msg, nil))
+
+		stored, errGet := multiSigner.SignatureShare(index)
+		require.Nil(t, errGet)
+		require.Equal(t, sigShares[index], stored)
+	}
+
+	_, err = multiSigner.SignatureShare(8)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	for _, index := range []uint16{0, 2} {
+		sigShare, errSign := llSigner.SignShare(privKeys[index], msg)
+		require.Nil(t, errSign)
+		require.Nil(t, multiSigner.StoreSignatureShare(index, sigShare))
+	}
+
+	aggSig, err := multiSigner.AggregateSigs(bitmap)
+	require.Nil(t, err)
+	require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+	require.Nil(t, multiSigner.Verify(msg, bitmap))
+}
+
+func TestBLSMultiSigner_SingleKeyOwnIndexes(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, pubKeys, kg := generateMultiSigParamsBLS(4, 2)
+
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 2)
+	require.Nil(t, err)
+	require.Equal(t, []uint16{2}, multiSigner.OwnIndexes())
+
+	sigShares, err := multiSigner.CreateSignatureSharesForBitmap(msg, []byte{0x0f})
+	require.Nil(t, err)
+	require.Equal(t, 1, len(sigShares))
+	require.Nil(t, multiSigner.VerifySignatureShare(2, sigShares[2], msg, nil))
+
+	err = multiSigner.Reset(pubKeys, 3)
+	require.Nil(t, err)
+	require.Equal(t, []uint16{3}, multiSigner.OwnIndexes())
+}
+
+type strictMultiSigner interface {
+	crypto.MultiSigner
+	crypto.SigSharesVerifier
+	SetStrictMode(strict bool)
+	StoreVerifiedSignatureShare(index uint16, sig []byte, message []byte) error
+	RejectedSigShares(index uint16) (uint32, error)
+	ValidSignersBitmap(bitmap []byte) ([]byte, error)
+}
+
+func TestBLSMultiSigner_StoreVerifiedSignatureShare(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+
+	var signer strictMultiSigner = multiSigner
+	signer.SetStrictMode(true)
+
+	validSig, _ := llSigner.SignShare(privKeys[1], msg)
+	otherMsgSig, _ := llSigner.SignShare(privKeys[1], []byte("other message"))
+
+	require.Equal(t, crypto.ErrUnverifiedSigShare, signer.StoreSignatureShare(1, validSig))
+	require.Equal(t, crypto.ErrNilSignature, signer.StoreVerifiedSignatureShare(1, nil, msg))
+	require.Equal(t, crypto.ErrNilMessage, signer.StoreVerifiedSignatureShare(1, validSig, nil))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, signer.StoreVerifiedSignatureShare(4, validSig, msg))
+
+	// the share of another signer is rejected
+	require.NotNil(t, signer.StoreVerifiedSignatureShare(2, validSig, msg))
+	require.NotNil(t, signer.StoreVerifiedSignatureShare(1, otherMsgSig, msg))
+	rejected, err := signer.RejectedSigShares(1)
+	require.Nil(t, err)
+	require.Equal(t, uint32(1), rejected)
+	rejected, _ = signer.RejectedSigShares(2)
+	require.Equal(t, uint32(1), rejected)
+	_, err = signer.SignatureShare(1)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	require.Nil(t, signer.StoreVerifiedSignatureShare(1, validSig, msg))
+	stored, err := signer.SignatureShare(1)
+	require.Nil(t, err)
+	require.Equal(t, validSig, stored)
+
+	// a valid share is kept when a later one is rejected
+	require.NotNil(t, signer.StoreVerifiedSignatureShare(1, otherMsgSig, msg))
+	stored, _ = signer.SignatureShare(1)
+	require.Equal(t, validSig, stored)
+	rejected, _ = signer.RejectedSigShares(1)
+	require.Equal(t, uint32(2), rejected)
+
+	_, err = signer.RejectedSigShares(4)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	require.Nil(t, signer.Reset(pubKeys, 0))
+	rejected, _ = signer.RejectedSigShares(1)
+	require.Equal(t, uint32(0), rejected)
+	// the strict mode is kept across resets
+	require.Equal(t, crypto.ErrUnverifiedSigShare, signer.StoreSignatureShare(1, validSig))
+}
+
+func TestBLSMultiSigner_StrictModeExcludesInvalidSigShares(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	bitmap := []byte{0x0f}
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+
+	storeShares := func(signer strictMultiSigner) {
+		for i, sk := range privKeys {
+			message := msg
+			if i == 2 {
+				message = []byte("other message")
+			}
+
+			sigShare, _ := llSigner.SignShare(sk, message)
+			_ = signer.StoreSignatureShare(uint16(i), sigShare)
+		}
+	}
+
+	// without strict mode, the invalid shares found are only reported
+	multiSigner, _ := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	var signer strictMultiSigner = multiSigner
+	storeShares(signer)
+	require.NotNil(t, signer.VerifySigShares(msg, bitmap))
+	_, err := signer.SignatureShare(2)
+	require.Nil(t, err)
+	validBitmap, err := signer.ValidSignersBitmap(bitmap)
+	require.Nil(t, err)
+	require.Equal(t, bitmap, validBitmap)
+
+	multiSigner, _ = multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	signer = multiSigner
+	storeShares(signer)
+	signer.SetStrictMode(true)
+
+	err = signer.VerifySigShares(msg, bitmap)
+	var invalidSigsErr *crypto.InvalidSignaturesError
+	require.True(t, errors.As(err, &invalidSigsErr))
+	require.Equal(t, []int{2}, invalidSigsErr.Indexes)
+
+	_, err = signer.SignatureShare(2)
+	require.Equal(t, crypto.ErrNilElement, err)
+	rejected, _ := signer.RejectedSigShares(2)
+	require.Equal(t, uint32(1), rejected)
+
+	// the invalid share is excluded without the caller changing the bitmap
+	aggSig, err := signer.AggregateSigs(bitmap)
+	require.Nil(t, err)
+	validBitmap, err = signer.ValidSignersBitmap(bitmap)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x0b}, validBitmap)
+
+	require.Nil(t, signer.SetAggregatedSig(aggSig))
+	require.Nil(t, signer.Verify(msg, validBitmap))
+
+	// only the invalid share is selected
+	_, err = signer.AggregateSigs([]byte{0x04})
+	require.Equal(t, crypto.ErrSigNotValid, err)
+
+	// a new valid share replaces the invalid one
+	sigShare, _ := llSigner.SignShare(privKeys[2], msg)
+	require.Nil(t, signer.StoreVerifiedSignatureShare(2, sigShare, msg))
+	validBitmap, _ = signer.ValidSignersBitmap(bitmap)
+	require.Equal(t, bitmap, validBitmap)
+	aggSig, err = signer.AggregateSigs(bitmap)
+	require.Nil(t, err)
+	require.Nil(t, signer.SetAggregatedSig(aggSig))
+	require.Nil(t, signer.Verify(msg, bitmap))
+}
+
+func TestBLSMultiSigner_StrictModePinsMessage(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	otherMsg := []byte("other message")
+	bitmap := []byte{0x0f}
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+
+	var signer strictMultiSigner = multiSigner
+	signer.SetStrictMode(true)
+
+	sigShare, _ := llSigner.SignShare(privKeys[1], msg)
+	require.Nil(t, signer.StoreVerifiedSignatureShare(1, sigShare, msg))
+
+	// the valid shares over another message are not mixed with the ones over the pinned message
+	otherSigShare, _ := llSigner.SignShare(privKeys[2], otherMsg)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, signer.StoreVerifiedSignatureShare(2, otherSigShare, otherMsg))
+	_, err = signer.SignatureShare(2)
+	require.Equal(t, crypto.ErrNilElement, err)
+	rejected, _ := signer.RejectedSigShares(2)
+	require.Equal(t, uint32(0), rejected)
+
+	_, err = signer.CreateSignatureShare(otherMsg, nil)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	_, err = multiSigner.CreateSignatureSharesForBitmap(otherMsg, bitmap)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	_, err = multiSigner.CreateAndAddSignatureShareForKey(otherMsg, privKeys[3], []byte(pubKeys[3]))
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, signer.VerifySigShares(otherMsg, []byte{0x02}))
+
+	_, err = signer.CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+
+	// the message is pinned again after a reset
+	require.Nil(t, signer.Reset(pubKeys, 0))
+	require.Nil(t, signer.StoreVerifiedSignatureShare(2, otherSigShare, otherMsg))
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, signer.StoreVerifiedSignatureShare(1, sigShare, msg))
+
+	// the own share pins the message as well
+	require.Nil(t, signer.Reset(pubKeys, 0))
+	_, err = signer.CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, signer.StoreVerifiedSignatureShare(2, otherSigShare, otherMsg))
+
+	// without strict mode the message is not pinned
+	signer.SetStrictMode(false)
+	_, err = signer.CreateSignatureShare(otherMsg, nil)
+	require.Nil(t, err)
+}
+
+func TestBLSMultiSigner_CreateKeepsStrictMode(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+	sigShare, _ := llSigner.SignShare(privKeys[1], msg)
+
+	created, err := multiSigner.Create(pubKeys, 0)
+	require.Nil(t, err)
+	require.Nil(t, created.StoreSignatureShare(1, sigShare))
+
+	multiSigner.SetStrictMode(true)
+	pubKeysBytes := make([][]byte, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		pubKeysBytes[i] = []byte(pubKey)
+	}
+
+	created, err = multiSigner.Create(pubKeys, 0)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrUnverifiedSigShare, created.StoreSignatureShare(1, sigShare))
+
+	created, err = multiSigner.CreateWithPubKeyBytes(pubKeysBytes, 0)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrUnverifiedSigShare, created.StoreSignatureShare(1, sigShare))
+}
+
+type strictRoundMultiSigner interface {
+	roundMultiSigner
+	SetStrictMode(strict bool)
+	StoreVerifiedSignatureShareForRound(round uint64, index uint16, sig []byte, message []byte) error
+	ValidSignersBitmapForRound(round uint64, bitmap []byte) ([]byte, error)
+}
+
+func TestBLSMultiSigner_StrictModeRoundShares(t *testing.T) {
+	t.Parallel()
+
+	signer, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	multiSigner := signer.(strictRoundMultiSigner)
+	msg := []byte("message")
+	otherMsg := []byte("other message")
+	bitmap := []byte{0x0f}
+	multiSigner.SetStrictMode(true)
+
+	sigShares := make([][]byte, len(privKeys))
+	for i, sk := range privKeys {
+		sigShares[i], _ = llSigner.SignShare(sk, msg)
+	}
+	otherSigShare, _ := llSigner.SignShare(privKeys[3], otherMsg)
+
+	require.Equal(t, crypto.ErrUnverifiedSigShare, multiSigner.StoreSignatureShareForRound(0, 0, sigShares[0]))
+	require.Equal(t, crypto.ErrNilSignature, multiSigner.StoreVerifiedSignatureShareForRound(0, 0, nil, msg))
+	require.Equal(t, crypto.ErrNilMessage, multiSigner.StoreVerifiedSignatureShareForRound(0, 0, sigShares[0], nil))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, multiSigner.StoreVerifiedSignatureShareForRound(0, 4, sigShares[0], msg))
+	require.Equal(t, crypto.ErrRoundOutOfWindow, multiSigner.StoreVerifiedSignatureShareForRound(5, 0, sigShares[0], msg))
+
+	// the share of another signer is rejected
+	require.NotNil(t, multiSigner.StoreVerifiedSignatureShareForRound(0, 1, sigShares[0], msg))
+	_, err := multiSigner.SignatureShareForRound(0, 1)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	for i := 0; i < 3; i++ {
+		require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(0, uint16(i), sigShares[i], msg))
+	}
+
+	// the valid share over another message is not mixed with the ones over the pinned message of the round
+	err = multiSigner.StoreVerifiedSignatureShareForRound(0, 3, otherSigShare, otherMsg)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(1, 3, otherSigShare, otherMsg))
+
+	// an invalid share stored outside the strict mode is excluded from the aggregation in strict mode
+	multiSigner.SetStrictMode(false)
+	require.Nil(t, multiSigner.StoreSignatureShareForRound(0, 3, otherSigShare))
+	multiSigner.SetStrictMode(true)
+
+	aggSig, err := multiSigner.AggregateSigsForRound(0, bitmap)
+	require.Nil(t, err)
+	validBitmap, err := multiSigner.ValidSignersBitmapForRound(0, bitmap)
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x07}, validBitmap)
+	_, err = multiSigner.SignatureShareForRound(0, 3)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+	require.Nil(t, multiSigner.Verify(msg, validBitmap))
+
+	_, err = multiSigner.AggregateSigsForRound(0, []byte{0x08})
+	require.Equal(t, crypto.ErrSigNotValid, err)
+
+	// a new valid share replaces the invalid one
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(0, 3, sigShares[3], msg))
+	validBitmap, _ = multiSigner.ValidSignersBitmapForRound(0, bitmap)
+	require.Equal(t, bitmap, validBitmap)
+	aggSig, err = multiSigner.AggregateSigsForRound(0, bitmap)
+	require.Nil(t, err)
+	require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+	require.Nil(t, multiSigner.Verify(msg, bitmap))
+}
+
+func TestBLSMultiSigner_StrictModeUnverifiedRoundSharesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	signer, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	multiSigner := signer.(strictRoundMultiSigner)
+	sigShare, _ := llSigner.SignShare(privKeys[0], []byte("message"))
+
+	require.Nil(t, multiSigner.StoreSignatureShareForRound(0, 0, sigShare))
+	multiSigner.SetStrictMode(true)
+
+	_, err := multiSigner.AggregateSigsForRound(0, []byte{0x01})
+	require.Equal(t, crypto.ErrUnverifiedSigShare, err)
+}
@@ -0,0 +1,272 @@
+package multisig
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+// maxRecordedMessages is the number of distinct messages recorded for a signer in a round, enough to prove an
+// equivocation, so a misbehaving signer can not grow the history without bound
+const maxRecordedMessages = 2
+
+/*
+EquivocationProof holds two valid signature shares of the same signer over different messages, recorded in the same
+round. It can be checked independently of the detector that produced it, so it can be used as slashing evidence.
+
+The signatures are over the messages as they are, the round being carried alongside them: it is not authenticated by
+the signatures, so the signed messages are expected to identify their round, as the consensus messages do
+*/
+type EquivocationProof struct {
+	Round      uint64
+	Index      uint16
+	PubKey     []byte
+	Message1   []byte
+	Signature1 []byte
+	Message2   []byte
+	Signature2 []byte
+}
+
+// Verify checks that the public key is the one at the proof index in the consensus group of the round, and that the
+// proof holds two valid signatures of the public key over different messages
+func (ep *EquivocationProof) Verify(
+	llSigner crypto.LowLevelSignerBLS,
+	keyGen crypto.KeyGenerator,
+	consensusGroup [][]byte,
+) error {
+	if check.IfNil(llSigner) {
+		return crypto.ErrNilLowLevelSigner
+	}
+	if check.IfNil(keyGen) {
+		return crypto.ErrNilKeyGenerator
+	}
+	if len(consensusGroup) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if int(ep.Index) >= len(consensusGroup) || !bytes.Equal(consensusGroup[ep.Index], ep.PubKey) {
+		return crypto.ErrInvalidEquivocationProof
+	}
+	if len(ep.Message1) == 0 || len(ep.Message2) == 0 || bytes.Equal(ep.Message1, ep.Message2) {
+		return crypto.ErrInvalidEquivocationProof
+	}
+
+	pubKey, err := keyGen.PublicKeyFromByteArray(ep.PubKey)
+	if err != nil {
+		return err
+	}
+
+	err = llSigner.VerifySigShare(pubKey, ep.Message1, ep.Signature1)
+	if err != nil {
+		return crypto.ErrInvalidEquivocationProof
+	}
+
+	err = llSigner.VerifySigShare(pubKey, ep.Message2, ep.Signature2)
+	if err != nil {
+		return crypto.ErrInvalidEquivocationProof
+	}
+
+	return nil
+}
+
+// EquivocationError is returned by the multi-signer when a signature share proves the equivocation of its signer,
+// holding the proof
+type EquivocationError struct {
+	Proof *EquivocationProof
+}
+
+// Error returns the error message, including the position of the signer and the round
+func (e *EquivocationError) Error() string {
+	return fmt.Sprintf("%v at index %d in round %d", crypto.ErrEquivocation, e.Proof.Index, e.Proof.Round)
+}
+
+// Unwrap returns the wrapped sentinel error
+func (e *EquivocationError) Unwrap() error {
+	return crypto.ErrEquivocation
+}
+
+type signedMessage struct {
+	message   []byte
+	signature []byte
+}
+
+/*
+equivocationDetector keeps, for the last rounds, the history of the messages signed by each signer of the consensus
+group, so a signer sending valid signature shares over different messages in the same round is detected. It can be
+used on its own or set on the multi-signer, which then records the signature shares it verifies.
+
+Only valid signature shares are recorded, the signers being looked up in the consensus group by their position, so a
+proof can not be forged from the shares of other signers. The retained window is anchored on the current round, as
+for the round shares of the multi-signer: it holds the last maxRounds rounds up to the current one, plus the next
+round, the shares for any other round being rejected
+*/
+type equivocationDetector struct {
+	mutRounds    sync.Mutex
+	llSigner     crypto.LowLevelSignerBLS
+	keyGen       crypto.KeyGenerator
+	maxRounds    int
+	currentRound uint64
+	rounds       map[uint64]map[string][]signedMessage
+}
+
+// NewEquivocationDetector creates an equivocation detector retaining the history of the given number of rounds
+func NewEquivocationDetector(
+	llSigner crypto.LowLevelSignerBLS,
+	keyGen crypto.KeyGenerator,
+	maxRounds int,
+) (*equivocationDetector, error) {
+	if check.IfNil(llSigner) {
+		return nil, crypto.ErrNilLowLevelSigner
+	}
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+	if maxRounds <= 0 {
+		return nil, crypto.ErrInvalidParam
+	}
+
+	return &equivocationDetector{
+		llSigner:  llSigner,
+		keyGen:    keyGen,
+		maxRounds: maxRounds,
+		rounds:    make(map[uint64]map[string][]signedMessage, maxRounds+1),
+	}, nil
+}
+
+// SetCurrentRound sets the round the consensus is in, which anchors the window of retained rounds. The history of
+// the rounds older than the retained ones is evicted
+func (ed *equivocationDetector) SetCurrentRound(round uint64) {
+	ed.mutRounds.Lock()
+	defer ed.mutRounds.Unlock()
+
+	ed.currentRound = round
+	for recordedRound := range ed.rounds {
+		if !isRoundInWindow(recordedRound, ed.currentRound, ed.maxRounds) {
+			delete(ed.rounds, recordedRound)
+		}
+	}
+}
+
+/*
+Record verifies the signature share of the signer with specified position in the consensus group of the round over
+the message and adds the message to the history of the round. If the signer already signed a different message in
+the same round, the returned proof holds the first message signed and the new one, nil being returned otherwise.
+
+An invalid signature share is not recorded and its verification error is returned. The shares for rounds outside the
+retained window fail with ErrRoundOutOfWindow, and once an equivocation is recorded for a signer in a round, the
+following messages only produce proofs without being added to the history
+*/
+func (ed *equivocationDetector) Record(
+	round uint64,
+	consensusGroup [][]byte,
+	index uint16,
+	message []byte,
+	sig []byte,
+) (*EquivocationProof, error) {
+	if len(consensusGroup) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+	if int(index) >= len(consensusGroup) {
+		return nil, crypto.ErrIndexOutOfBounds
+	}
+	if len(message) == 0 {
+		return nil, crypto.ErrNilMessage
+	}
+	if len(sig) == 0 {
+		return nil, crypto.ErrNilSignature
+	}
+
+	pubKey := consensusGroup[index]
+	pk, err := ed.keyGen.PublicKeyFromByteArray(pubKey)
+	if err != nil {
+		return nil, err
+	}
+
+	ed.mutRounds.Lock()
+	inWindow := isRoundInWindow(round, ed.currentRound, ed.maxRounds)
+	ed.mutRounds.Unlock()
+	if !inWindow {
+		return nil, crypto.ErrRoundOutOfWindow
+	}
+
+	err = ed.llSigner.VerifySigShare(pk, message, sig)
+	if err != nil {
+		return nil, err
+	}
+
+	return ed.record(round, pubKey, index, message, sig)
+}
+
+// record adds the message of the already verified signature share to the history of the signer in the round, the
+// message and the signature being copied as the caller might reuse them
+func (ed *equivocationDetector) record(
+	round uint64,
+	pubKey []byte,
+	index uint16,
+	message []byte,
+	sig []byte,
+) (*EquivocationProof, error) {
+	ed.mutRounds.Lock()
+	defer ed.mutRounds.Unlock()
+
+	// the current round might have moved while verifying the signature share
+	if !isRoundInWindow(round, ed.currentRound, ed.maxRounds) {
+		return nil, crypto.ErrRoundOutOfWindow
+	}
+
+	signers, exists := ed.rounds[round]
+	if !exists {
+		signers = make(map[string][]signedMessage)
+		ed.rounds[round] = signers
+	}
+
+	history := signers[string(pubKey)]
+	for _, signed := range history {
+		if bytes.Equal(signed.message, message) {
+			return nil, nil
+		}
+	}
+
+	signed := signedMessage{
+		message:   append([]byte{}, message...),
+		signature: append([]byte{}, sig...),
+	}
+	if len(history) < maxRecordedMessages {
+		signers[string(pubKey)] = append(history, signed)
+	}
+	if len(history) == 0 {
+		return nil, nil
+	}
+
+	return &EquivocationProof{
+		Round:      round,
+		Index:      index,
+		PubKey:     append([]byte{}, pubKey...),
+		Message1:   append([]byte{}, history[0].message...),
+		Signature1: append([]byte{}, history[0].signature...),
+		Message2:   append([]byte{}, message...),
+		Signature2: append([]byte{}, sig...),
+	}, nil
+}
+
+// SignedMessages returns the distinct messages recorded for the signer with the given public key in the given round,
+// in the order they were recorded
+func (ed *equivocationDetector) SignedMessages(round uint64, pubKey []byte) [][]byte {
+	ed.mutRounds.Lock()
+	defer ed.mutRounds.Unlock()
+
+	history := ed.rounds[round][string(pubKey)]
+	messages := make([][]byte, 0, len(history))
+	for _, signed := range history {
+		messages = append(messages, append([]byte{}, signed.message...))
+	}
+
+	return messages
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ed *equivocationDetector) IsInterfaceNil() bool {
+	return ed == nil
+}
@@ -0,0 +1,300 @@
+package multisig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewEquivocationDetector(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, _, kg := generateMultiSigParamsBLS(1, 0)
+
+	detector, err := multisig.NewEquivocationDetector(nil, kg, 2)
+	require.Equal(t, crypto.ErrNilLowLevelSigner, err)
+	require.True(t, check.IfNil(detector))
+
+	_, err = multisig.NewEquivocationDetector(llSigner, nil, 2)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, err = multisig.NewEquivocationDetector(llSigner, kg, 0)
+	require.Equal(t, crypto.ErrInvalidParam, err)
+
+	detector, err = multisig.NewEquivocationDetector(llSigner, kg, 2)
+	require.Nil(t, err)
+	require.False(t, check.IfNil(detector))
+}
+
+func TestEquivocationDetector_Record(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(3, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	detector, _ := multisig.NewEquivocationDetector(llSigner, kg, 2)
+	detector.SetCurrentRound(1)
+
+	round := uint64(1)
+	msg1 := []byte("block 1")
+	msg2 := []byte("block 2")
+	sig1, _ := llSigner.SignShare(privKeys[1], msg1)
+	sig2, _ := llSigner.SignShare(privKeys[1], msg2)
+
+	_, err := detector.Record(round, nil, 1, msg1, sig1)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+	_, err = detector.Record(round, pubKeys, 3, msg1, sig1)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+	_, err = detector.Record(round, pubKeys, 1, nil, sig1)
+	require.Equal(t, crypto.ErrNilMessage, err)
+	_, err = detector.Record(round, pubKeys, 1, msg1, nil)
+	require.Equal(t, crypto.ErrNilSignature, err)
+
+	// the share of another signer is not recorded
+	_, err = detector.Record(round, pubKeys, 2, msg2, sig2)
+	require.NotNil(t, err)
+	require.Equal(t, 0, len(detector.SignedMessages(round, pubKeys[2])))
+
+	proof, err := detector.Record(round, pubKeys, 1, msg1, sig1)
+	require.Nil(t, err)
+	require.Nil(t, proof)
+
+	// the same message signed again is not an equivocation
+	proof, err = detector.Record(round, pubKeys, 1, msg1, sig1)
+	require.Nil(t, err)
+	require.Nil(t, proof)
+
+	// the same signer in another round is not an equivocation
+	proof, err = detector.Record(round+1, pubKeys, 1, msg2, sig2)
+	require.Nil(t, err)
+	require.Nil(t, proof)
+
+	proof, err = detector.Record(round, pubKeys, 1, msg2, sig2)
+	require.Nil(t, err)
+	require.NotNil(t, proof)
+	require.Equal(t, &multisig.EquivocationProof{
+		Round:      round,
+		Index:      1,
+		PubKey:     pubKeys[1],
+		Message1:   msg1,
+		Signature1: sig1,
+		Message2:   msg2,
+		Signature2: sig2,
+	}, proof)
+	require.Equal(t, [][]byte{msg1, msg2}, detector.SignedMessages(round, pubKeys[1]))
+
+	// the proof is checked without the detector
+	require.Nil(t, proof.Verify(llSigner, kg, pubKeys))
+}
+
+func TestEquivocationDetector_RecordCopiesSlices(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(1, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	detector, _ := multisig.NewEquivocationDetector(llSigner, kg, 2)
+
+	msg1 := []byte("block 1")
+	msg2 := []byte("block 2")
+	sig1, _ := llSigner.SignShare(privKeys[0], msg1)
+	sig2, _ := llSigner.SignShare(privKeys[0], msg2)
+
+	// the caller reuses its buffers once the shares are recorded
+	buffMsg := append([]byte{}, msg1...)
+	buffSig := append([]byte{}, sig1...)
+	_, err := detector.Record(0, pubKeys, 0, buffMsg, buffSig)
+	require.Nil(t, err)
+	copy(buffMsg, "block 3")
+	copy(buffSig, sig2)
+
+	proof, err := detector.Record(0, pubKeys, 0, msg2, sig2)
+	require.Nil(t, err)
+	require.Equal(t, msg1, proof.Message1)
+	require.Equal(t, sig1, proof.Signature1)
+	require.Nil(t, proof.Verify(llSigner, kg, pubKeys))
+
+	// the proof and the returned messages do not alias the history
+	proof.Message1[0] = 'x'
+	proof.Message2[0] = 'x'
+	messages := detector.SignedMessages(0, pubKeys[0])
+	messages[0][0] = 'x'
+	require.Equal(t, [][]byte{msg1, msg2}, detector.SignedMessages(0, pubKeys[0]))
+}
+
+func TestEquivocationDetector_RecordLimitsHistory(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(1, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	detector, _ := multisig.NewEquivocationDetector(llSigner, kg, 2)
+
+	messages := [][]byte{[]byte("block 1"), []byte("block 2"), []byte("block 3"), []byte("block 4")}
+	for i, msg := range messages {
+		sig, _ := llSigner.SignShare(privKeys[0], msg)
+		proof, err := detector.Record(0, pubKeys, 0, msg, sig)
+		require.Nil(t, err)
+		require.Equal(t, i > 0, proof != nil)
+		if proof != nil {
+			require.Equal(t, messages[0], proof.Message1)
+			require.Equal(t, msg, proof.Message2)
+		}
+	}
+
+	require.Equal(t, messages[:2], detector.SignedMessages(0, pubKeys[0]))
+}
+
+func TestEquivocationDetector_RoundsWindow(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(1, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	detector, _ := multisig.NewEquivocationDetector(llSigner, kg, 2)
+
+	msg := []byte("message")
+	sig, _ := llSigner.SignShare(privKeys[0], msg)
+	record := func(round uint64) error {
+		_, err := detector.Record(round, pubKeys, 0, msg, sig)
+
+		return err
+	}
+
+	detector.SetCurrentRound(10)
+	require.Equal(t, crypto.ErrRoundOutOfWindow, record(8))
+	require.Equal(t, crypto.ErrRoundOutOfWindow, record(12))
+	require.Equal(t, crypto.ErrRoundOutOfWindow, record(1000))
+	for _, round := range []uint64{9, 10, 11} {
+		require.Nil(t, record(round))
+	}
+
+	// the rounds are evicted by round number when the current round moves
+	detector.SetCurrentRound(11)
+	require.Equal(t, 0, len(detector.SignedMessages(9, pubKeys[0])))
+	require.Equal(t, [][]byte{msg}, detector.SignedMessages(10, pubKeys[0]))
+	require.Equal(t, [][]byte{msg}, detector.SignedMessages(11, pubKeys[0]))
+}
+
+func TestEquivocationProof_Verify(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(2, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	round := uint64(7)
+	msg1 := []byte("block 1")
+	msg2 := []byte("block 2")
+	sig1, _ := llSigner.SignShare(privKeys[0], msg1)
+	sig2, _ := llSigner.SignShare(privKeys[0], msg2)
+	otherSig2, _ := llSigner.SignShare(privKeys[1], msg2)
+
+	createProof := func() *multisig.EquivocationProof {
+		return &multisig.EquivocationProof{
+			Round:      round,
+			Index:      0,
+			PubKey:     pubKeys[0],
+			Message1:   msg1,
+			Signature1: sig1,
+			Message2:   msg2,
+			Signature2: sig2,
+		}
+	}
+
+	require.Nil(t, createProof().Verify(llSigner, kg, pubKeys))
+	require.Equal(t, crypto.ErrNilLowLevelSigner, createProof().Verify(nil, kg, pubKeys))
+	require.Equal(t, crypto.ErrNilKeyGenerator, createProof().Verify(llSigner, nil, pubKeys))
+	require.Equal(t, crypto.ErrNilPublicKeys, createProof().Verify(llSigner, kg, nil))
+
+	proof := createProof()
+	proof.Message2 = msg1
+	proof.Signature2 = sig1
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+
+	proof = createProof()
+	proof.Signature2 = otherSig2
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+
+	proof = createProof()
+	proof.Message1 = []byte("block 3")
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+
+	// the index needs to hold the public key in the consensus group
+	proof = createProof()
+	proof.Index = 1
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+	proof.Index = 2
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+
+	proof = createProof()
+	proof.PubKey = []byte("invalid public key")
+	require.Equal(t, crypto.ErrInvalidEquivocationProof, proof.Verify(llSigner, kg, pubKeys))
+	require.NotNil(t, proof.Verify(llSigner, kg, [][]byte{[]byte("invalid public key")}))
+}
+
+func TestBLSMultiSigner_EquivocationDetector(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeysStrings, kg := generateMultiSigParamsBLSWithPrivateKeys(3, 0)
+	pubKeys := stringsToPubKeysBytes(pubKeysStrings)
+	multiSigner, _ := multisig.NewBLSMultisig(llSigner, pubKeysStrings, privKey, kg, 0)
+	detector, _ := multisig.NewEquivocationDetector(llSigner, kg, 2)
+	multiSigner.SetEquivocationDetector(detector)
+	multiSigner.SetCurrentRound(1)
+
+	msg1 := []byte("block 1")
+	msg2 := []byte("block 2")
+	sig1, _ := llSigner.SignShare(privKeys[1], msg1)
+	sig2, _ := llSigner.SignShare(privKeys[1], msg2)
+	otherSig2, _ := llSigner.SignShare(privKeys[2], msg2)
+
+	// the share over another message is not stored over the first one, the proof being returned instead
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShare(1, sig1, msg1))
+	err := multiSigner.StoreVerifiedSignatureShare(1, sig2, msg2)
+	require.True(t, errors.Is(err, crypto.ErrEquivocation))
+	var equivocationErr *multisig.EquivocationError
+	require.True(t, errors.As(err, &equivocationErr))
+	require.Equal(t, uint64(1), equivocationErr.Proof.Round)
+	require.Equal(t, uint16(1), equivocationErr.Proof.Index)
+	require.Nil(t, equivocationErr.Proof.Verify(llSigner, kg, pubKeys))
+	stored, _ := multiSigner.SignatureShare(1)
+	require.Equal(t, sig1, stored)
+
+	// in strict mode, the shares over another message than the pinned one are still recorded
+	multiSigner.SetStrictMode(true)
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(2, 1, sig1, msg1))
+	err = multiSigner.StoreVerifiedSignatureShareForRound(2, 2, otherSig2, msg2)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	err = multiSigner.StoreVerifiedSignatureShareForRound(2, 1, sig2, msg2)
+	require.True(t, errors.As(err, &equivocationErr))
+	require.Equal(t, uint64(2), equivocationErr.Proof.Round)
+	require.Nil(t, equivocationErr.Proof.Verify(llSigner, kg, pubKeys))
+	stored, _ = multiSigner.SignatureShareForRound(2, 1)
+	require.Equal(t, sig1, stored)
+
+	// the detector follows the current round of the multi-signer
+	multiSigner.SetCurrentRound(3)
+	require.Equal(t, 0, len(detector.SignedMessages(1, pubKeys[1])))
+	require.Equal(t, [][]byte{msg1, msg2}, detector.SignedMessages(2, pubKeys[1]))
+
+	multiSigner.SetEquivocationDetector(nil)
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(4, 1, sig1, msg1))
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, multiSigner.StoreVerifiedSignatureShareForRound(4, 1, sig2, msg2))
+}
+
+func stringsToPubKeysBytes(pubKeys []string) [][]byte {
+	pubKeysBytes := make([][]byte, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		pubKeysBytes[i] = []byte(pubKey)
+	}
+
+	return pubKeysBytes
+}
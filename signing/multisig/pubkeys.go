@@ -1,23 +1,126 @@
 package multisig
 
 import (
+	"errors"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
 	crypto "github.com/ME-MotherEarth/me-crypto"
 )
 
+// consensusGroup holds the public keys of a consensus group together with their serialization, used to look up the
+// positions of the signers
+type consensusGroup struct {
+	pubKeys    []crypto.PublicKey
+	serialized []string
+}
+
+func newConsensusGroupFromStrings(pubKeys []string, kg crypto.KeyGenerator) (*consensusGroup, error) {
+	pk, err := convertStringsToPubKeys(pubKeys, kg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consensusGroup{
+		pubKeys:    pk,
+		serialized: pubKeys,
+	}, nil
+}
+
+func newConsensusGroupFromBytes(pubKeys [][]byte, kg crypto.KeyGenerator) (*consensusGroup, error) {
+	pk, err := convertBytesToPubKeys(pubKeys, kg)
+	if err != nil {
+		return nil, err
+	}
+
+	return &consensusGroup{
+		pubKeys:    pk,
+		serialized: bytesToStrings(pubKeys),
+	}, nil
+}
+
+func newConsensusGroupFromPublicKeys(pubKeys []crypto.PublicKey) (*consensusGroup, error) {
+	serialized, err := pubKeysToStrings(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	pk := make([]crypto.PublicKey, len(pubKeys))
+	copy(pk, pubKeys)
+
+	return &consensusGroup{
+		pubKeys:    pk,
+		serialized: serialized,
+	}, nil
+}
+
+// convertStringsToPubKeys keeps returning the plain ErrEmptyPubKeyString and ErrInvalidPublicKeyString errors for the
+// invalid public keys, the positions of the offending keys being reported only by the byte slice variants
 func convertStringsToPubKeys(pubKeys []string, kg crypto.KeyGenerator) ([]crypto.PublicKey, error) {
-	// convert pubKeys
-	pk := make([]crypto.PublicKey, 0, len(pubKeys))
+	pubKeysBytes := make([][]byte, 0, len(pubKeys))
 	for _, pubKeyStr := range pubKeys {
-		if pubKeyStr == "" {
-			return nil, crypto.ErrEmptyPubKeyString
+		pubKeysBytes = append(pubKeysBytes, []byte(pubKeyStr))
+	}
+
+	pk, err := convertBytesToPubKeys(pubKeysBytes, kg)
+	var invalidPubKeyErr *crypto.InvalidPublicKeyError
+	if !errors.As(err, &invalidPubKeyErr) {
+		return pk, err
+	}
+	if invalidPubKeyErr.Err == crypto.ErrEmptyPubKeyString {
+		return nil, crypto.ErrEmptyPubKeyString
+	}
+
+	return nil, crypto.ErrInvalidPublicKeyString
+}
+
+// convertBytesToPubKeys returns a *crypto.InvalidPublicKeyError for the first public key that is empty, has the wrong
+// length or is off the curve
+func convertBytesToPubKeys(pubKeys [][]byte, kg crypto.KeyGenerator) ([]crypto.PublicKey, error) {
+	pointLen := kg.Suite().PointLen()
+	pk := make([]crypto.PublicKey, 0, len(pubKeys))
+	for i, pubKeyBytes := range pubKeys {
+		if len(pubKeyBytes) == 0 {
+			return nil, &crypto.InvalidPublicKeyError{Index: i, Err: crypto.ErrEmptyPubKeyString}
+		}
+		if len(pubKeyBytes) != pointLen {
+			return nil, &crypto.InvalidPublicKeyError{Index: i, Err: crypto.ErrInvalidPublicKeyLength}
 		}
 
-		pubKey, err := kg.PublicKeyFromByteArray([]byte(pubKeyStr))
+		pubKey, err := kg.PublicKeyFromByteArray(pubKeyBytes)
 		if err != nil {
-			return nil, crypto.ErrInvalidPublicKeyString
+			return nil, &crypto.InvalidPublicKeyError{Index: i, Err: crypto.ErrInvalidPoint}
 		}
 
 		pk = append(pk, pubKey)
 	}
+
 	return pk, nil
 }
+
+// pubKeysToStrings returns the serialized public keys, used to look up the positions of the signers
+func pubKeysToStrings(pubKeys []crypto.PublicKey) ([]string, error) {
+	pubKeysStr := make([]string, 0, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		if check.IfNil(pubKey) {
+			return nil, &crypto.InvalidPublicKeyError{Index: i, Err: crypto.ErrNilPublicKey}
+		}
+
+		pubKeyBytes, err := pubKey.ToByteArray()
+		if err != nil {
+			return nil, &crypto.InvalidPublicKeyError{Index: i, Err: err}
+		}
+
+		pubKeysStr = append(pubKeysStr, string(pubKeyBytes))
+	}
+
+	return pubKeysStr, nil
+}
+
+func bytesToStrings(pubKeys [][]byte) []string {
+	pubKeysStr := make([]string, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		pubKeysStr = append(pubKeysStr, string(pubKey))
+	}
+
+	return pubKeysStr
+}
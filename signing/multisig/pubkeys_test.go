@@ -0,0 +1,148 @@
+package multisig_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func stringsToBytes(pubKeys []string) [][]byte {
+	pubKeysBytes := make([][]byte, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		pubKeysBytes = append(pubKeysBytes, []byte(pubKey))
+	}
+
+	return pubKeysBytes
+}
+
+func requireInvalidPublicKeyError(t *testing.T, err error, index int, reason error) {
+	var invalidPubKeyErr *crypto.InvalidPublicKeyError
+	require.True(t, errors.As(err, &invalidPubKeyErr))
+	require.Equal(t, index, invalidPubKeyErr.Index)
+	require.Equal(t, reason, invalidPubKeyErr.Err)
+}
+
+func TestNewBLSMultisigWithPubKeyBytes_InvalidPubKeysShouldErr(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, pubKeys, kg := generateMultiSigParamsBLS(4, 0)
+	pointLen := kg.Suite().PointLen()
+
+	_, err := multisig.NewBLSMultisigWithPubKeyBytes(llSigner, nil, privKey, kg, 0)
+	require.Equal(t, crypto.ErrNoPublicKeySet, err)
+
+	_, err = multisig.NewBLSMultisigWithPubKeyBytes(llSigner, stringsToBytes(pubKeys), privKey, kg, 4)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+
+	testCases := []struct {
+		name   string
+		pubKey []byte
+		reason error
+	}{
+		{"empty", []byte{}, crypto.ErrEmptyPubKeyString},
+		{"wrong length", []byte(pubKeys[2])[:pointLen-1], crypto.ErrInvalidPublicKeyLength},
+		{"off the curve", bytes.Repeat([]byte{0xff}, pointLen), crypto.ErrInvalidPoint},
+	}
+	for _, tc := range testCases {
+		pubKeysBytes := stringsToBytes(pubKeys)
+		pubKeysBytes[2] = tc.pubKey
+
+		multiSigner, errCreate := multisig.NewBLSMultisigWithPubKeyBytes(llSigner, pubKeysBytes, privKey, kg, 0)
+		require.Nil(t, multiSigner, tc.name)
+		requireInvalidPublicKeyError(t, errCreate, 2, tc.reason)
+		require.Contains(t, errCreate.Error(), "at index 2", tc.name)
+	}
+}
+
+func TestNewBLSMultisigWithPublicKeys_NilPubKeyShouldErr(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, pubKey, _, kg := generateMultiSigParamsBLS(4, 0)
+
+	_, err := multisig.NewBLSMultisigWithPublicKeys(llSigner, nil, privKey, kg, 0)
+	require.Equal(t, crypto.ErrNoPublicKeySet, err)
+
+	_, err = multisig.NewBLSMultisigWithPublicKeys(llSigner, []crypto.PublicKey{pubKey, nil}, privKey, kg, 0)
+	requireInvalidPublicKeyError(t, err, 1, crypto.ErrNilPublicKey)
+	require.False(t, errors.Is(err, crypto.ErrInvalidPublicKeyString))
+}
+
+func TestBLSMultiSigner_PubKeyVariantsAggregateAndVerify(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	bitmap := []byte{0x0d}
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+	pubKeysBytes := stringsToBytes(pubKeys)
+	publicKeys := make([]crypto.PublicKey, 0, len(privKeys))
+	for _, sk := range privKeys {
+		publicKeys = append(publicKeys, sk.GeneratePublic())
+	}
+
+	fromStrings, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+	fromBytes, err := multisig.NewBLSMultisigWithPubKeyBytes(llSigner, pubKeysBytes, privKey, kg, 0)
+	require.Nil(t, err)
+	fromPublicKeys, err := multisig.NewBLSMultisigWithPublicKeys(llSigner, publicKeys, privKey, kg, 0)
+	require.Nil(t, err)
+
+	aggregate := func(multiSigner crypto.MultiSigner) []byte {
+		for _, index := range []int{0, 2, 3} {
+			_, errAdd := multiSigner.CreateAndAddSignatureShareForKey(msg, privKeys[index], []byte(pubKeys[index]))
+			require.Nil(t, errAdd)
+		}
+
+		aggSig, errAggregate := multiSigner.AggregateSigs(bitmap)
+		require.Nil(t, errAggregate)
+
+		return aggSig
+	}
+
+	aggSig := aggregate(fromStrings)
+	require.Equal(t, aggSig, aggregate(fromBytes))
+	require.Equal(t, aggSig, aggregate(fromPublicKeys))
+
+	// the group changes to the first 3 signers, the own signer becoming the last one
+	require.Nil(t, fromBytes.ResetWithPubKeyBytes(pubKeysBytes[:3], 2))
+	require.Nil(t, fromPublicKeys.ResetWithPublicKeys(publicKeys[:3], 2))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, fromBytes.ResetWithPubKeyBytes(pubKeysBytes[:3], 3))
+	require.Equal(t, crypto.ErrNilPublicKeys, fromPublicKeys.ResetWithPublicKeys(nil, 0))
+
+	bitmap = []byte{0x05}
+	for _, multiSigner := range []crypto.MultiSigner{fromBytes, fromPublicKeys} {
+		_, err = multiSigner.CreateAndAddSignatureShareForKey(msg, privKeys[2], []byte(pubKeys[2]))
+		require.Nil(t, err)
+		_, err = multiSigner.CreateAndAddSignatureShareForKey(msg, privKeys[0], []byte(pubKeys[0]))
+		require.Nil(t, err)
+
+		aggSig, err = multiSigner.AggregateSigs(bitmap)
+		require.Nil(t, err)
+		require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+		require.Nil(t, multiSigner.Verify(msg, bitmap))
+	}
+
+	created, err := fromStrings.CreateWithPubKeyBytes(pubKeysBytes[:3], 1)
+	require.Nil(t, err)
+	require.Nil(t, created.SetAggregatedSig(aggSig))
+	require.Nil(t, created.Verify(msg, bitmap))
+
+	created, err = fromStrings.CreateWithPublicKeys(publicKeys[:3], 1)
+	require.Nil(t, err)
+	require.Nil(t, created.SetAggregatedSig(aggSig))
+	require.Nil(t, created.Verify(msg, bitmap))
+
+	_, err = fromStrings.CreateWithPubKeyBytes([][]byte{pubKeysBytes[0], nil}, 1)
+	requireInvalidPublicKeyError(t, err, 1, crypto.ErrEmptyPubKeyString)
+
+	_, err = fromStrings.CreateWithPublicKeys(publicKeys, 4)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+}
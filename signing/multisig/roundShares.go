@@ -0,0 +1,194 @@
+package multisig
+
+import (
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+// DefaultRetainedRounds is the default number of rounds for which the signature shares are retained
+const DefaultRetainedRounds = 4
+
+/*
+roundShares holds the signature shares of the last rounds, keyed by the round number. The shares are kept by the
+position of the signer in the consensus group of their round, which is kept with them as it might not be the current
+one.
+
+The retained window is anchored on the current round set by the owner, not on the rounds of the received shares:
+it holds the last maxRounds rounds up to the current one, plus the next round for early shares. Shares for rounds
+outside the window are rejected, so neither late shares for old rounds nor shares for made up rounds can push out
+the shares of the live rounds. The struct is not concurrent safe, the multi-signer guarding it with its own mutex
+*/
+type roundShares struct {
+	maxRounds    int
+	currentRound uint64
+	shares       map[uint64]*roundSigShares
+}
+
+// roundSigShares holds the signature shares of a round
+type roundSigShares struct {
+	// pubKeys is the consensus group of the round, nil if it is not known
+	pubKeys []crypto.PublicKey
+	// message is the message the shares were verified against in strict mode, nil if none was verified
+	message []byte
+	sigs    map[uint16][]byte
+	// invalid marks the signers whose share was found invalid when the round was aggregated in strict mode
+	invalid map[uint16]bool
+}
+
+func newRoundShares(maxRounds int) *roundShares {
+	return &roundShares{
+		maxRounds: maxRounds,
+		shares:    make(map[uint64]*roundSigShares, maxRounds+1),
+	}
+}
+
+func (rs *roundShares) setMaxRounds(maxRounds int) {
+	rs.maxRounds = maxRounds
+	rs.evict()
+}
+
+func (rs *roundShares) setCurrentRound(round uint64) {
+	rs.currentRound = round
+	rs.evict()
+}
+
+func (rs *roundShares) isInWindow(round uint64) bool {
+	return isRoundInWindow(round, rs.currentRound, rs.maxRounds)
+}
+
+// isRoundInWindow returns true if the round is one of the last maxRounds rounds up to the current one, or the next
+// round
+func isRoundInWindow(round uint64, currentRound uint64, maxRounds int) bool {
+	if round > currentRound {
+		return round-currentRound == 1
+	}
+
+	return currentRound-round < uint64(maxRounds)
+}
+
+// setGroup sets the consensus group of the round, the shares already stored for the round being positioned in it
+func (rs *roundShares) setGroup(round uint64, pubKeys []crypto.PublicKey) {
+	if !rs.isInWindow(round) {
+		return
+	}
+
+	rs.getOrCreate(round, pubKeys).pubKeys = pubKeys
+}
+
+// group returns the consensus group of the round, the given one being returned if the group of the round is not known
+func (rs *roundShares) group(round uint64, pubKeys []crypto.PublicKey) []crypto.PublicKey {
+	roundSigs, exists := rs.shares[round]
+	if !exists || roundSigs.pubKeys == nil {
+		return pubKeys
+	}
+
+	return roundSigs.pubKeys
+}
+
+// getOrCreate returns the shares of the round, creating them for the given consensus group if the round has none
+func (rs *roundShares) getOrCreate(round uint64, pubKeys []crypto.PublicKey) *roundSigShares {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		roundSigs = &roundSigShares{
+			sigs:    make(map[uint16][]byte),
+			invalid: make(map[uint16]bool),
+		}
+		rs.shares[round] = roundSigs
+	}
+	if roundSigs.pubKeys == nil {
+		roundSigs.pubKeys = pubKeys
+	}
+
+	return roundSigs
+}
+
+// store keeps the share of the signer for the round, the round being created for the given consensus group if it has
+// no shares yet. The message is pinned for the round if the share was verified against it
+func (rs *roundShares) store(round uint64, pubKeys []crypto.PublicKey, index uint16, sig []byte, message []byte) error {
+	if !rs.isInWindow(round) {
+		return crypto.ErrRoundOutOfWindow
+	}
+
+	roundSigs := rs.getOrCreate(round, pubKeys)
+	if roundSigs.message == nil && len(message) > 0 {
+		roundSigs.message = append([]byte{}, message...)
+	}
+	roundSigs.sigs[index] = sig
+	delete(roundSigs.invalid, index)
+
+	return nil
+}
+
+// message returns the message pinned for the round, nil if there is none
+func (rs *roundShares) message(round uint64) []byte {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		return nil
+	}
+
+	return roundSigs.message
+}
+
+func (rs *roundShares) get(round uint64, index uint16) ([]byte, bool) {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		return nil, false
+	}
+
+	sig, exists := roundSigs.sigs[index]
+
+	return sig, exists
+}
+
+// sharesForGroup returns the shares of the round positioned in a consensus group of the given size
+func (rs *roundShares) sharesForGroup(round uint64, size int) ([][]byte, bool) {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		return nil, false
+	}
+
+	sigShares := make([][]byte, size)
+	for index, sig := range roundSigs.sigs {
+		if int(index) < size {
+			sigShares[index] = sig
+		}
+	}
+
+	return sigShares, true
+}
+
+// drop removes the shares of the signers found invalid in the round, marking them as invalid
+func (rs *roundShares) drop(round uint64, indexes []int) {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		return
+	}
+
+	for _, index := range indexes {
+		delete(roundSigs.sigs, uint16(index))
+		roundSigs.invalid[uint16(index)] = true
+	}
+}
+
+// excludeInvalid unsets from the bitmap the signers whose share was found invalid in the round
+func (rs *roundShares) excludeInvalid(round uint64, bitmap *Bitmap) {
+	roundSigs, exists := rs.shares[round]
+	if !exists {
+		return
+	}
+
+	for index := range roundSigs.invalid {
+		_ = bitmap.Unset(int(index))
+	}
+}
+
+func (rs *roundShares) remove(round uint64) {
+	delete(rs.shares, round)
+}
+
+func (rs *roundShares) evict() {
+	for round := range rs.shares {
+		if !rs.isInWindow(round) {
+			delete(rs.shares, round)
+		}
+	}
+}
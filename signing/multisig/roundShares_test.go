@@ -0,0 +1,217 @@
+package multisig_test
+
+import (
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+type roundMultiSigner interface {
+	crypto.MultiSigner
+	SetRetainedRounds(numRounds int) error
+	SetCurrentRound(round uint64)
+	StoreSignatureShareForRound(round uint64, index uint16, sig []byte) error
+	SignatureShareForRound(round uint64, index uint16) ([]byte, error)
+	AggregateSigsForRound(round uint64, bitmap []byte) ([]byte, error)
+	RemoveRound(round uint64)
+}
+
+func createRoundMultiSigner(t *testing.T, grSize int) (roundMultiSigner, []crypto.PrivateKey, []string, crypto.LowLevelSignerBLS) {
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(grSize, 0)
+
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+
+	return multiSigner, privKeys, pubKeys, llSigner
+}
+
+func TestBLSMultiSigner_RoundSharesInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	multiSigner, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	sig, _ := llSigner.SignShare(privKeys[0], []byte("message"))
+
+	require.Equal(t, crypto.ErrInvalidParam, multiSigner.SetRetainedRounds(0))
+	require.NotNil(t, multiSigner.StoreSignatureShareForRound(0, 0, []byte("invalid signature")))
+	require.Equal(t, crypto.ErrIndexOutOfBounds, multiSigner.StoreSignatureShareForRound(0, 4, sig))
+
+	_, err := multiSigner.SignatureShareForRound(0, 0)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	_, err = multiSigner.AggregateSigsForRound(0, nil)
+	require.Equal(t, crypto.ErrNilBitmap, err)
+
+	_, err = multiSigner.AggregateSigsForRound(0, []byte{0x0f})
+	require.Equal(t, crypto.ErrNilElement, err)
+}
+
+func TestBLSMultiSigner_RoundSharesKeptAcrossReset(t *testing.T) {
+	t.Parallel()
+
+	multiSigner, privKeys, pubKeys, llSigner := createRoundMultiSigner(t, 4)
+	msgCurrent := []byte("current round message")
+	msgNext := []byte("next round message")
+	bitmap := []byte{0x0f}
+	multiSigner.SetCurrentRound(1)
+
+	for i, privKey := range privKeys {
+		sigCurrent, _ := llSigner.SignShare(privKey, msgCurrent)
+		require.Nil(t, multiSigner.StoreSignatureShareForRound(1, uint16(i), sigCurrent))
+
+		// early shares for the next round
+		sigNext, _ := llSigner.SignShare(privKey, msgNext)
+		require.Nil(t, multiSigner.StoreSignatureShareForRound(2, uint16(i), sigNext))
+	}
+
+	stored, err := multiSigner.SignatureShareForRound(2, 3)
+	require.Nil(t, err)
+	expectedSig, _ := llSigner.SignShare(privKeys[3], msgNext)
+	require.Equal(t, expectedSig, stored)
+
+	// the current round shares are not mixed with the round shares
+	_, err = multiSigner.SignatureShare(3)
+	require.Equal(t, crypto.ErrNilElement, err)
+
+	err = multiSigner.Reset(pubKeys, 1)
+	require.Nil(t, err)
+
+	for _, round := range []struct {
+		number uint64
+		msg    []byte
+	}{
+		{1, msgCurrent},
+		{2, msgNext},
+	} {
+		aggSig, errAggregate := multiSigner.AggregateSigsForRound(round.number, bitmap)
+		require.Nil(t, errAggregate)
+		require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+		require.Nil(t, multiSigner.Verify(round.msg, bitmap))
+	}
+
+	multiSigner.RemoveRound(1)
+	_, err = multiSigner.SignatureShareForRound(1, 0)
+	require.Equal(t, crypto.ErrNilElement, err)
+	_, err = multiSigner.SignatureShareForRound(2, 0)
+	require.Nil(t, err)
+}
+
+func TestBLSMultiSigner_RoundSharesOutsideWindowShouldErr(t *testing.T) {
+	t.Parallel()
+
+	multiSigner, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	sig, _ := llSigner.SignShare(privKeys[0], []byte("message"))
+	currentRound := uint64(10)
+	multiSigner.SetCurrentRound(currentRound)
+
+	// the retained rounds up to the current one and the next round are accepted
+	oldestRound := currentRound - multisig.DefaultRetainedRounds + 1
+	for round := oldestRound; round <= currentRound+1; round++ {
+		require.Nil(t, multiSigner.StoreSignatureShareForRound(round, 0, sig))
+	}
+
+	// late shares for old rounds and shares for made up future rounds do not evict the live rounds
+	require.Equal(t, crypto.ErrRoundOutOfWindow, multiSigner.StoreSignatureShareForRound(oldestRound-1, 0, sig))
+	for round := currentRound + 2; round < currentRound+100; round++ {
+		require.Equal(t, crypto.ErrRoundOutOfWindow, multiSigner.StoreSignatureShareForRound(round, 0, sig))
+	}
+	for round := oldestRound; round <= currentRound+1; round++ {
+		_, err := multiSigner.SignatureShareForRound(round, 0)
+		require.Nil(t, err)
+	}
+}
+
+func TestBLSMultiSigner_RoundSharesEvictsOldestRounds(t *testing.T) {
+	t.Parallel()
+
+	multiSigner, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	sig, _ := llSigner.SignShare(privKeys[0], []byte("message"))
+	multiSigner.SetCurrentRound(4)
+	for round := uint64(1); round <= 5; round++ {
+		require.Nil(t, multiSigner.StoreSignatureShareForRound(round, 0, sig))
+	}
+
+	// advancing the current round evicts the rounds falling out of the window, whatever their arrival order
+	multiSigner.SetCurrentRound(5)
+	_, err := multiSigner.SignatureShareForRound(1, 0)
+	require.Equal(t, crypto.ErrNilElement, err)
+	for round := uint64(2); round <= 5; round++ {
+		_, err = multiSigner.SignatureShareForRound(round, 0)
+		require.Nil(t, err)
+	}
+
+	// shrinking the retained rounds evicts the oldest rounds
+	require.Nil(t, multiSigner.SetRetainedRounds(2))
+	for _, round := range []uint64{2, 3} {
+		_, err = multiSigner.SignatureShareForRound(round, 0)
+		require.Equal(t, crypto.ErrNilElement, err)
+	}
+	for _, round := range []uint64{4, 5} {
+		_, err = multiSigner.SignatureShareForRound(round, 0)
+		require.Nil(t, err)
+	}
+}
+
+func TestBLSMultiSigner_AggregateSigsForRoundMissingShareShouldErr(t *testing.T) {
+	t.Parallel()
+
+	multiSigner, privKeys, _, llSigner := createRoundMultiSigner(t, 4)
+	sig, _ := llSigner.SignShare(privKeys[0], []byte("message"))
+	require.Nil(t, multiSigner.StoreSignatureShareForRound(0, 0, sig))
+
+	_, err := multiSigner.AggregateSigsForRound(0, []byte{0x01})
+	require.Nil(t, err)
+
+	_, err = multiSigner.AggregateSigsForRound(0, []byte{0x03})
+	require.NotNil(t, err)
+
+	_, err = multiSigner.AggregateSigsForRound(0, []byte{})
+	require.Equal(t, crypto.ErrBitmapMismatch, err)
+}
+
+func TestBLSMultiSigner_RoundSharesLateShareVerifiedAgainstRoundGroup(t *testing.T) {
+	t.Parallel()
+
+	signer, privKeys, pubKeys, llSigner := createRoundMultiSigner(t, 4)
+	multiSigner := signer.(strictRoundMultiSigner)
+	msgPrevious := []byte("previous round message")
+	msgCurrent := []byte("current round message")
+	bitmap := []byte{0x0f}
+	multiSigner.SetStrictMode(true)
+	multiSigner.SetCurrentRound(1)
+
+	for i := 0; i < 2; i++ {
+		sig, _ := llSigner.SignShare(privKeys[i], msgPrevious)
+		require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(1, uint16(i), sig, msgPrevious))
+	}
+
+	// the consensus group of the next round holds the same signers in reverse order
+	nextPubKeys := make([]string, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		nextPubKeys[len(pubKeys)-1-i] = pubKey
+	}
+	require.Nil(t, multiSigner.Reset(nextPubKeys, uint16(len(pubKeys)-1)))
+	multiSigner.SetCurrentRound(2)
+
+	// the late shares for the previous round are verified against the group of the previous round
+	for i := 2; i < len(privKeys); i++ {
+		sig, _ := llSigner.SignShare(privKeys[i], msgPrevious)
+		require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(1, uint16(i), sig, msgPrevious))
+	}
+
+	// the shares for the current round are verified against the current group
+	sigCurrent, _ := llSigner.SignShare(privKeys[0], msgCurrent)
+	require.NotNil(t, multiSigner.StoreVerifiedSignatureShareForRound(2, 0, sigCurrent, msgCurrent))
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(2, 3, sigCurrent, msgCurrent))
+
+	aggSig, err := multiSigner.AggregateSigsForRound(1, bitmap)
+	require.Nil(t, err)
+
+	require.Nil(t, multiSigner.Reset(pubKeys, 0))
+	require.Nil(t, multiSigner.SetAggregatedSig(aggSig))
+	require.Nil(t, multiSigner.Verify(msgPrevious, bitmap))
+}
@@ -0,0 +1,571 @@
+package multisig
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+// SnapshotVersion is the version of the binary format of the multi-signer snapshots
+const SnapshotVersion = byte(1)
+
+const snapshotFlagStrictMode = byte(1)
+
+/*
+The snapshot of the multi-signer state has the following binary format, all the integers being big endian:
+
+	version           1 byte
+	flags             1 byte, bit 0 being set for the strict mode
+	own index         2 bytes
+	public keys count 2 bytes
+	public keys       for each public key, its length on 4 bytes followed by its bytes
+	signature shares  for each public key, the length of the share on 4 bytes followed by its bytes, 0 if missing
+	signer states     for each public key, the number of its shares rejected in strict mode on 4 bytes and 1 byte
+	                  set to 1 if its stored share was found invalid in strict mode, 0 otherwise
+	aggregated sig    its length on 4 bytes followed by its bytes, 0 if not set
+	pinned message    its length on 4 bytes followed by its bytes, 0 if no message is pinned in strict mode
+	retained rounds   4 bytes
+	current round     8 bytes
+	rounds count      4 bytes
+	rounds            for each round in increasing order, the round number on 8 bytes, the public keys count of its
+	                  consensus group on 2 bytes followed by the public keys as above, 0 if the group is not known,
+	                  the length of its pinned message on 4 bytes followed by its bytes, the number of shares on
+	                  2 bytes, each share being its signer position on 2 bytes, its length on 4 bytes and its bytes,
+	                  then the number of signers whose share was found invalid on 2 bytes followed by their positions
+	                  on 2 bytes each
+
+The private keys are not part of the snapshot, so they need to be supplied again on restore
+*/
+type multiSigSnapshot struct {
+	strictMode bool
+	ownIndex   uint16
+	pubKeys    []string
+	sigShares  [][]byte
+	rejected   []uint32
+	invalid    []bool
+	aggSig     []byte
+	message    []byte
+	rounds     *roundShares
+	// roundGroups holds the serialized consensus groups of the rounds, converted to public keys on restore
+	roundGroups map[uint64][][]byte
+}
+
+// Snapshot serializes the state of the multi-signer, holding the public key set, the own index, the collected
+// signature shares, the aggregated signature, the strict mode with its counters and the signature shares of the
+// retained rounds, so it can be restored after a restart
+func (bms *blsMultiSigner) Snapshot() ([]byte, error) {
+	bms.mutSigData.RLock()
+	defer bms.mutSigData.RUnlock()
+
+	data := bms.data
+	buff := make([]byte, 0)
+	buff = append(buff, SnapshotVersion)
+	flags := byte(0)
+	if bms.strictMode {
+		flags |= snapshotFlagStrictMode
+	}
+	buff = append(buff, flags)
+	buff = binary.BigEndian.AppendUint16(buff, data.ownIndex)
+	buff = binary.BigEndian.AppendUint16(buff, uint16(len(data.pubKeys)))
+	for _, pubKey := range data.pubKeys {
+		pubKeyBytes, err := pubKey.ToByteArray()
+		if err != nil {
+			return nil, err
+		}
+
+		buff = appendSnapshotField(buff, pubKeyBytes)
+	}
+
+	for _, sigShare := range data.sigShares {
+		buff = appendSnapshotField(buff, sigShare)
+	}
+
+	for i, rejected := range data.rejectedSigShares {
+		buff = binary.BigEndian.AppendUint32(buff, rejected)
+		invalid := byte(0)
+		if data.invalidSigShares[i] {
+			invalid = 1
+		}
+		buff = append(buff, invalid)
+	}
+
+	buff = appendSnapshotField(buff, data.aggSig)
+	buff = appendSnapshotField(buff, data.message)
+
+	return appendRoundShares(buff, bms.rounds)
+}
+
+// not concurrent safe, should be used under RLock mutex
+func appendRoundShares(buff []byte, rounds *roundShares) ([]byte, error) {
+	buff = binary.BigEndian.AppendUint32(buff, uint32(rounds.maxRounds))
+	buff = binary.BigEndian.AppendUint64(buff, rounds.currentRound)
+	buff = binary.BigEndian.AppendUint32(buff, uint32(len(rounds.shares)))
+
+	roundNumbers := make([]uint64, 0, len(rounds.shares))
+	for round := range rounds.shares {
+		roundNumbers = append(roundNumbers, round)
+	}
+	sort.Slice(roundNumbers, func(i, j int) bool {
+		return roundNumbers[i] < roundNumbers[j]
+	})
+
+	for _, round := range roundNumbers {
+		roundSigs := rounds.shares[round]
+		buff = binary.BigEndian.AppendUint64(buff, round)
+		buff = binary.BigEndian.AppendUint16(buff, uint16(len(roundSigs.pubKeys)))
+		for _, pubKey := range roundSigs.pubKeys {
+			pubKeyBytes, err := pubKey.ToByteArray()
+			if err != nil {
+				return nil, err
+			}
+
+			buff = appendSnapshotField(buff, pubKeyBytes)
+		}
+		buff = appendSnapshotField(buff, roundSigs.message)
+
+		indexes := make([]uint16, 0, len(roundSigs.sigs))
+		for index := range roundSigs.sigs {
+			indexes = append(indexes, index)
+		}
+		buff = binary.BigEndian.AppendUint16(buff, uint16(len(indexes)))
+		for _, index := range sortIndexes(indexes) {
+			buff = binary.BigEndian.AppendUint16(buff, index)
+			buff = appendSnapshotField(buff, roundSigs.sigs[index])
+		}
+
+		indexes = make([]uint16, 0, len(roundSigs.invalid))
+		for index := range roundSigs.invalid {
+			indexes = append(indexes, index)
+		}
+		buff = binary.BigEndian.AppendUint16(buff, uint16(len(indexes)))
+		for _, index := range sortIndexes(indexes) {
+			buff = binary.BigEndian.AppendUint16(buff, index)
+		}
+	}
+
+	return buff, nil
+}
+
+func sortIndexes(indexes []uint16) []uint16 {
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i] < indexes[j]
+	})
+
+	return indexes
+}
+
+// NewBLSMultisigFromSnapshot creates a BLS multi-signer with the state restored from the snapshot, the private key
+// being supplied again as it is not part of the snapshot
+func NewBLSMultisigFromSnapshot(
+	llSigner crypto.LowLevelSignerBLS,
+	snapshot []byte,
+	privKey crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+) (*blsMultiSigner, error) {
+	decoded, err := decodeSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	multiSigner, err := NewBLSMultisig(llSigner, decoded.pubKeys, privKey, keyGen, decoded.ownIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	err = multiSigner.restore(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiSigner, nil
+}
+
+// NewBLSMultisigWithKeySetFromSnapshot creates a BLS multi-signer holding the key set with the state restored from
+// the snapshot, the private keys being supplied again as they are not part of the snapshot
+func NewBLSMultisigWithKeySetFromSnapshot(
+	llSigner crypto.LowLevelSignerBLS,
+	snapshot []byte,
+	privKeys []crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+) (*blsMultiSigner, error) {
+	decoded, err := decodeSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+
+	multiSigner, err := NewBLSMultisigWithKeySet(llSigner, decoded.pubKeys, privKeys, keyGen, decoded.ownIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	err = multiSigner.restore(decoded)
+	if err != nil {
+		return nil, err
+	}
+
+	return multiSigner, nil
+}
+
+func (bms *blsMultiSigner) restore(snapshot *multiSigSnapshot) error {
+	suite := bms.keyGen.Suite()
+	for i, sigShare := range snapshot.sigShares {
+		if len(sigShare) == 0 {
+			continue
+		}
+
+		err := bms.llSigner.VerifySigBytes(suite, sigShare)
+		if err != nil {
+			return fmt.Errorf("%w: signature share at index %d: %v", crypto.ErrInvalidSnapshot, i, err)
+		}
+	}
+
+	if len(snapshot.aggSig) > 0 {
+		err := bms.llSigner.VerifySigBytes(suite, snapshot.aggSig)
+		if err != nil {
+			return fmt.Errorf("%w: aggregated signature: %v", crypto.ErrInvalidSnapshot, err)
+		}
+	}
+
+	for round, group := range snapshot.roundGroups {
+		pubKeys, err := convertBytesToPubKeys(group, bms.keyGen)
+		if err != nil {
+			return fmt.Errorf("%w: consensus group of round %d: %v", crypto.ErrInvalidSnapshot, round, err)
+		}
+
+		snapshot.rounds.shares[round].pubKeys = pubKeys
+	}
+
+	for round, roundSigs := range snapshot.rounds.shares {
+		for index, sigShare := range roundSigs.sigs {
+			err := bms.llSigner.VerifySigBytes(suite, sigShare)
+			if err != nil {
+				return fmt.Errorf("%w: signature share at index %d in round %d: %v",
+					crypto.ErrInvalidSnapshot, index, round, err)
+			}
+		}
+	}
+
+	bms.mutSigData.Lock()
+	copy(bms.data.sigShares, snapshot.sigShares)
+	copy(bms.data.rejectedSigShares, snapshot.rejected)
+	copy(bms.data.invalidSigShares, snapshot.invalid)
+	bms.data.aggSig = snapshot.aggSig
+	bms.data.message = snapshot.message
+	bms.strictMode = snapshot.strictMode
+	bms.rounds = snapshot.rounds
+	bms.mutSigData.Unlock()
+
+	return nil
+}
+
+func appendSnapshotField(buff []byte, field []byte) []byte {
+	buff = binary.BigEndian.AppendUint32(buff, uint32(len(field)))
+
+	return append(buff, field...)
+}
+
+func decodeSnapshot(snapshot []byte) (*multiSigSnapshot, error) {
+	if len(snapshot) == 0 {
+		return nil, crypto.ErrInvalidSnapshot
+	}
+	version := snapshot[0]
+	if version != SnapshotVersion {
+		return nil, crypto.ErrUnsupportedSnapshotVersion
+	}
+
+	reader := &snapshotReader{buff: snapshot[1:]}
+	flags, err := reader.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if flags&^snapshotFlagStrictMode != 0 {
+		return nil, fmt.Errorf("%w: unknown flags", crypto.ErrInvalidSnapshot)
+	}
+
+	ownIndex, err := reader.readUint16()
+	if err != nil {
+		return nil, err
+	}
+
+	numPubKeys, err := reader.readUint16()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &multiSigSnapshot{
+		strictMode: flags&snapshotFlagStrictMode != 0,
+		ownIndex:   ownIndex,
+		pubKeys:    make([]string, numPubKeys),
+		sigShares:  make([][]byte, numPubKeys),
+		rejected:   make([]uint32, numPubKeys),
+		invalid:    make([]bool, numPubKeys),
+	}
+	for i := range decoded.pubKeys {
+		pubKey, errRead := reader.readField()
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		decoded.pubKeys[i] = string(pubKey)
+	}
+
+	for i := range decoded.sigShares {
+		decoded.sigShares[i], err = reader.readField()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range decoded.rejected {
+		decoded.rejected[i], err = reader.readUint32()
+		if err != nil {
+			return nil, err
+		}
+
+		invalid, errRead := reader.readByte()
+		if errRead != nil {
+			return nil, errRead
+		}
+		if invalid > 1 {
+			return nil, fmt.Errorf("%w: invalid signer state", crypto.ErrInvalidSnapshot)
+		}
+
+		decoded.invalid[i] = invalid == 1
+	}
+
+	decoded.aggSig, err = reader.readField()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded.message, err = reader.readField()
+	if err != nil {
+		return nil, err
+	}
+
+	decoded.rounds, decoded.roundGroups, err = reader.readRoundShares(numPubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(reader.buff) != 0 {
+		return nil, fmt.Errorf("%w: trailing bytes", crypto.ErrInvalidSnapshot)
+	}
+
+	return decoded, nil
+}
+
+// readRoundShares reads the shares of the retained rounds, returning the serialized consensus groups of the rounds
+// separately as the public keys can only be converted on restore
+func (sr *snapshotReader) readRoundShares(numPubKeys uint16) (*roundShares, map[uint64][][]byte, error) {
+	maxRounds, err := sr.readUint32()
+	if err != nil {
+		return nil, nil, err
+	}
+	if maxRounds == 0 || maxRounds > math.MaxInt32 {
+		return nil, nil, fmt.Errorf("%w: invalid retained rounds", crypto.ErrInvalidSnapshot)
+	}
+
+	currentRound, err := sr.readUint64()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	numRounds, err := sr.readUint32()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the map is not sized by the decoded values, so a malformed snapshot can not trigger a large allocation
+	rounds := &roundShares{
+		maxRounds:    int(maxRounds),
+		currentRound: currentRound,
+		shares:       make(map[uint64]*roundSigShares),
+	}
+	groups := make(map[uint64][][]byte)
+	for i := uint32(0); i < numRounds; i++ {
+		round, errRead := sr.readUint64()
+		if errRead != nil {
+			return nil, nil, errRead
+		}
+
+		_, exists := rounds.shares[round]
+		if exists || !rounds.isInWindow(round) {
+			return nil, nil, fmt.Errorf("%w: invalid round %d", crypto.ErrInvalidSnapshot, round)
+		}
+
+		group, errRead := sr.readRoundGroup()
+		if errRead != nil {
+			return nil, nil, errRead
+		}
+
+		// the signers of a round without a known group are positioned in the current consensus group
+		groupSize := numPubKeys
+		if len(group) > 0 {
+			groupSize = uint16(len(group))
+			groups[round] = group
+		}
+
+		roundSigs, errRead := sr.readRoundSigShares(groupSize)
+		if errRead != nil {
+			return nil, nil, errRead
+		}
+
+		rounds.shares[round] = roundSigs
+	}
+
+	return rounds, groups, nil
+}
+
+func (sr *snapshotReader) readRoundGroup() ([][]byte, error) {
+	numPubKeys, err := sr.readUint16()
+	if err != nil {
+		return nil, err
+	}
+
+	var group [][]byte
+	for i := uint16(0); i < numPubKeys; i++ {
+		pubKey, errRead := sr.readField()
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		group = append(group, pubKey)
+	}
+
+	return group, nil
+}
+
+func (sr *snapshotReader) readRoundSigShares(numPubKeys uint16) (*roundSigShares, error) {
+	message, err := sr.readField()
+	if err != nil {
+		return nil, err
+	}
+
+	roundSigs := &roundSigShares{
+		message: message,
+		sigs:    make(map[uint16][]byte),
+		invalid: make(map[uint16]bool),
+	}
+
+	numShares, err := sr.readUint16()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint16(0); i < numShares; i++ {
+		index, errRead := sr.readSignerIndex(numPubKeys)
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		sig, errRead := sr.readField()
+		if errRead != nil {
+			return nil, errRead
+		}
+		if len(sig) == 0 {
+			return nil, fmt.Errorf("%w: empty signature share in round", crypto.ErrInvalidSnapshot)
+		}
+
+		roundSigs.sigs[index] = sig
+	}
+
+	numInvalid, err := sr.readUint16()
+	if err != nil {
+		return nil, err
+	}
+	for i := uint16(0); i < numInvalid; i++ {
+		index, errRead := sr.readSignerIndex(numPubKeys)
+		if errRead != nil {
+			return nil, errRead
+		}
+
+		roundSigs.invalid[index] = true
+	}
+
+	return roundSigs, nil
+}
+
+type snapshotReader struct {
+	buff []byte
+}
+
+func (sr *snapshotReader) readSignerIndex(numPubKeys uint16) (uint16, error) {
+	index, err := sr.readUint16()
+	if err != nil {
+		return 0, err
+	}
+	if index >= numPubKeys {
+		return 0, fmt.Errorf("%w: signer index out of bounds", crypto.ErrInvalidSnapshot)
+	}
+
+	return index, nil
+}
+
+func (sr *snapshotReader) readByte() (byte, error) {
+	if len(sr.buff) < 1 {
+		return 0, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+
+	value := sr.buff[0]
+	sr.buff = sr.buff[1:]
+
+	return value, nil
+}
+
+func (sr *snapshotReader) readUint16() (uint16, error) {
+	if len(sr.buff) < 2 {
+		return 0, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+
+	value := binary.BigEndian.Uint16(sr.buff)
+	sr.buff = sr.buff[2:]
+
+	return value, nil
+}
+
+func (sr *snapshotReader) readUint32() (uint32, error) {
+	if len(sr.buff) < 4 {
+		return 0, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+
+	value := binary.BigEndian.Uint32(sr.buff)
+	sr.buff = sr.buff[4:]
+
+	return value, nil
+}
+
+func (sr *snapshotReader) readUint64() (uint64, error) {
+	if len(sr.buff) < 8 {
+		return 0, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+
+	value := binary.BigEndian.Uint64(sr.buff)
+	sr.buff = sr.buff[8:]
+
+	return value, nil
+}
+
+// readField returns nil for an empty field
+func (sr *snapshotReader) readField() ([]byte, error) {
+	if len(sr.buff) < 4 {
+		return nil, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+
+	fieldLen := binary.BigEndian.Uint32(sr.buff)
+	sr.buff = sr.buff[4:]
+	if uint64(fieldLen) > uint64(len(sr.buff)) {
+		return nil, fmt.Errorf("%w: unexpected end of data", crypto.ErrInvalidSnapshot)
+	}
+	if fieldLen == 0 {
+		return nil, nil
+	}
+
+	field := make([]byte, fieldLen)
+	copy(field, sr.buff)
+	sr.buff = sr.buff[fieldLen:]
+
+	return field, nil
+}
@@ -0,0 +1,311 @@
+package multisig_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotMultiSigner interface {
+	crypto.MultiSigner
+	Snapshot() ([]byte, error)
+}
+
+func TestBLSMultiSigner_SnapshotAndRestore(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	bitmap := []byte{0x07, 0x00}
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(10, 1)
+
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 1)
+	require.Nil(t, err)
+	for _, index := range []uint16{0, 2} {
+		sigShare, _ := llSigner.SignShare(privKeys[index], msg)
+		_ = multiSigner.StoreSignatureShare(index, sigShare)
+	}
+	_, err = multiSigner.CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+
+	// snapshot before the aggregation, missing the aggregated signature
+	snapshot, err := multiSigner.Snapshot()
+	require.Nil(t, err)
+	require.Equal(t, multisig.SnapshotVersion, snapshot[0])
+
+	restored, err := multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, privKey, kg)
+	require.Nil(t, err)
+	for i := uint16(0); i < 10; i++ {
+		expectedShare, errExpected := multiSigner.SignatureShare(i)
+		restoredShare, errRestored := restored.SignatureShare(i)
+		require.Equal(t, errExpected, errRestored)
+		require.Equal(t, expectedShare, restoredShare)
+	}
+
+	// the restored multi-signer signs with the supplied private key at the restored own index
+	sigShare, err := restored.CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+	require.Nil(t, restored.VerifySignatureShare(1, sigShare, msg, nil))
+
+	aggSig, err := restored.AggregateSigs(bitmap)
+	require.Nil(t, err)
+	require.Nil(t, restored.SetAggregatedSig(aggSig))
+
+	snapshot, err = restored.Snapshot()
+	require.Nil(t, err)
+	restored, err = multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, privKey, kg)
+	require.Nil(t, err)
+	require.Nil(t, restored.Verify(msg, bitmap))
+
+	restoredSnapshot, err := restored.Snapshot()
+	require.Nil(t, err)
+	require.Equal(t, snapshot, restoredSnapshot)
+}
+
+func TestBLSMultiSigner_SnapshotAndRestoreWithKeySet(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(5, 0)
+	keySet := []crypto.PrivateKey{privKeys[1], privKeys[4]}
+
+	multiSigner, err := multisig.NewBLSMultisigWithKeySet(llSigner, pubKeys, keySet, kg, 4)
+	require.Nil(t, err)
+	_, err = multiSigner.CreateSignatureSharesForBitmap(msg, []byte{0x1f})
+	require.Nil(t, err)
+
+	snapshot, err := multiSigner.Snapshot()
+	require.Nil(t, err)
+
+	restored, err := multisig.NewBLSMultisigWithKeySetFromSnapshot(llSigner, snapshot, keySet, kg)
+	require.Nil(t, err)
+	require.Equal(t, []uint16{1, 4}, restored.OwnIndexes())
+	for _, index := range []uint16{1, 4} {
+		sigShare, errGet := restored.SignatureShare(index)
+		require.Nil(t, errGet)
+		require.Nil(t, restored.VerifySignatureShare(index, sigShare, msg, nil))
+	}
+
+	_, err = multisig.NewBLSMultisigWithKeySetFromSnapshot(llSigner, []byte{}, keySet, kg)
+	require.Equal(t, crypto.ErrInvalidSnapshot, err)
+}
+
+func TestBLSMultiSigner_SnapshotAndRestoreStrictMode(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	roundMsg := []byte("round message")
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+	require.Nil(t, multiSigner.SetRetainedRounds(2))
+	multiSigner.SetCurrentRound(5)
+
+	// an invalid share for round 4 is stored before switching to strict mode, to be found when aggregating
+	invalidShare, _ := llSigner.SignShare(privKeys[3], msg)
+	require.Nil(t, multiSigner.StoreSignatureShareForRound(4, 3, invalidShare))
+	otherShare, _ := llSigner.SignShare(privKeys[3], []byte("other message"))
+	require.Nil(t, multiSigner.StoreSignatureShare(3, otherShare))
+	multiSigner.SetStrictMode(true)
+
+	roundShares := make([][]byte, len(privKeys))
+	for i, sk := range privKeys {
+		roundShares[i], _ = llSigner.SignShare(sk, roundMsg)
+	}
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(4, 0, roundShares[0], roundMsg))
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(4, 1, roundShares[1], roundMsg))
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(6, 2, roundShares[2], roundMsg))
+	_, err = multiSigner.AggregateSigsForRound(4, []byte{0x0b})
+	require.Nil(t, err)
+
+	sigShare, _ := llSigner.SignShare(privKeys[1], msg)
+	require.Nil(t, multiSigner.StoreVerifiedSignatureShare(1, sigShare, msg))
+	require.NotNil(t, multiSigner.StoreVerifiedSignatureShare(2, sigShare, msg))
+	require.NotNil(t, multiSigner.VerifySigShares(msg, []byte{0x0a}))
+
+	snapshot, err := multiSigner.Snapshot()
+	require.Nil(t, err)
+
+	restored, err := multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, privKey, kg)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrUnverifiedSigShare, restored.StoreSignatureShare(2, sigShare))
+
+	// the pinned message is restored
+	_, err = restored.CreateSignatureShare([]byte("other message"), nil)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	_, err = restored.CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+
+	// the rejected shares counters and the invalid shares are restored
+	for _, index := range []uint16{2, 3} {
+		rejected, errRejected := restored.RejectedSigShares(index)
+		require.Nil(t, errRejected)
+		require.Equal(t, uint32(1), rejected)
+	}
+	validBitmap, err := restored.ValidSignersBitmap([]byte{0x0f})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x07}, validBitmap)
+
+	// the round shares are restored, together with the pinned messages and the invalid shares of the rounds
+	for _, index := range []uint16{0, 1} {
+		restoredShare, errGet := restored.SignatureShareForRound(4, index)
+		require.Nil(t, errGet)
+		require.Equal(t, roundShares[index], restoredShare)
+	}
+	_, err = restored.SignatureShareForRound(4, 3)
+	require.Equal(t, crypto.ErrNilElement, err)
+	validBitmap, err = restored.ValidSignersBitmapForRound(4, []byte{0x0b})
+	require.Nil(t, err)
+	require.Equal(t, []byte{0x03}, validBitmap)
+
+	err = restored.StoreVerifiedSignatureShareForRound(6, 3, invalidShare, msg)
+	require.Equal(t, crypto.ErrSigShareMessageMismatch, err)
+	restoredShare, err := restored.SignatureShareForRound(6, 2)
+	require.Nil(t, err)
+	require.Equal(t, roundShares[2], restoredShare)
+
+	// the retained window is restored
+	err = restored.StoreVerifiedSignatureShareForRound(3, 3, roundShares[3], roundMsg)
+	require.Equal(t, crypto.ErrRoundOutOfWindow, err)
+	err = restored.StoreVerifiedSignatureShareForRound(7, 3, roundShares[3], roundMsg)
+	require.Equal(t, crypto.ErrRoundOutOfWindow, err)
+
+	aggSig, err := restored.AggregateSigsForRound(4, []byte{0x03})
+	require.Nil(t, err)
+	require.Nil(t, restored.SetAggregatedSig(aggSig))
+	require.Nil(t, restored.Verify(roundMsg, []byte{0x03}))
+
+	snapshot, err = restored.Snapshot()
+	require.Nil(t, err)
+	restored, err = multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, privKey, kg)
+	require.Nil(t, err)
+	restoredSnapshot, err := restored.Snapshot()
+	require.Nil(t, err)
+	require.Equal(t, snapshot, restoredSnapshot)
+}
+
+func TestBLSMultiSigner_SnapshotAndRestoreRoundGroups(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	bitmap := []byte{0x0f}
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(4, 0)
+
+	multiSigner, err := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	require.Nil(t, err)
+	multiSigner.SetCurrentRound(1)
+	for i := 0; i < 2; i++ {
+		sigShare, _ := llSigner.SignShare(privKeys[i], msg)
+		require.Nil(t, multiSigner.StoreVerifiedSignatureShareForRound(1, uint16(i), sigShare, msg))
+	}
+
+	// the consensus group of the next round holds the same signers in reverse order
+	nextPubKeys := make([]string, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		nextPubKeys[len(pubKeys)-1-i] = pubKey
+	}
+	require.Nil(t, multiSigner.Reset(nextPubKeys, uint16(len(pubKeys)-1)))
+	multiSigner.SetCurrentRound(2)
+
+	snapshot, err := multiSigner.Snapshot()
+	require.Nil(t, err)
+
+	restored, err := multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, privKey, kg)
+	require.Nil(t, err)
+
+	// the late shares for the previous round are verified against the restored group of the previous round
+	for i := 2; i < len(privKeys); i++ {
+		sigShare, _ := llSigner.SignShare(privKeys[i], msg)
+		require.Nil(t, restored.StoreVerifiedSignatureShareForRound(1, uint16(i), sigShare, msg))
+	}
+	sigShare, _ := llSigner.SignShare(privKeys[0], msg)
+	require.NotNil(t, restored.StoreVerifiedSignatureShareForRound(2, 0, sigShare, msg))
+
+	aggSig, err := restored.AggregateSigsForRound(1, bitmap)
+	require.Nil(t, err)
+	require.Nil(t, restored.Reset(pubKeys, 0))
+	require.Nil(t, restored.SetAggregatedSig(aggSig))
+	require.Nil(t, restored.Verify(msg, bitmap))
+
+	// the group of the last round, which ends with the first public key, is corrupted
+	corrupted := append([]byte{}, snapshot...)
+	pubKeyOffset := bytes.LastIndex(corrupted, []byte(pubKeys[0]))
+	for i := 0; i < 10; i++ {
+		corrupted[pubKeyOffset+i] = 0xff
+	}
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, corrupted, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+}
+
+func TestNewBLSMultisigFromSnapshot_InvalidSnapshotShouldErr(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, pubKeys, kg := generateMultiSigParamsBLS(3, 0)
+	multiSigner, _ := multisig.NewBLSMultisig(llSigner, pubKeys, privKey, kg, 0)
+	sigShare, _ := multiSigner.CreateSignatureShare([]byte("message"), nil)
+
+	var snapshotSigner snapshotMultiSigner = multiSigner
+	snapshot, err := snapshotSigner.Snapshot()
+	require.Nil(t, err)
+
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, nil, privKey, kg)
+	require.Equal(t, crypto.ErrInvalidSnapshot, err)
+
+	wrongVersion := append([]byte{multisig.SnapshotVersion + 1}, snapshot[1:]...)
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, wrongVersion, privKey, kg)
+	require.Equal(t, crypto.ErrUnsupportedSnapshotVersion, err)
+
+	for _, length := range []int{1, 4, 10, len(snapshot) - 1} {
+		_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot[:length], privKey, kg)
+		require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+	}
+
+	// the rounds count is set without any round following it
+	missingRounds := append([]byte{}, snapshot...)
+	missingRounds[len(missingRounds)-1] = 1
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, missingRounds, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	noRetainedRounds := append([]byte{}, snapshot...)
+	copy(noRetainedRounds[len(noRetainedRounds)-16:], []byte{0, 0, 0, 0})
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, noRetainedRounds, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	unknownFlags := append([]byte{}, snapshot...)
+	unknownFlags[1] = 0x02
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, unknownFlags, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	trailing := append(append([]byte{}, snapshot...), 0)
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, trailing, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	// the own signature share, stored after the 3 public keys, is corrupted
+	corrupted := append([]byte{}, snapshot...)
+	shareOffset := 1 + 1 + 2 + 2 + 3*(4+len(pubKeys[0])) + 4
+	for i := 0; i < 10; i++ {
+		corrupted[shareOffset+i] = 0xff
+	}
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, corrupted, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	// the invalid share flag of the first signer, following the 3 signature shares and its rejected counter, is not 0 or 1
+	invalidState := append([]byte{}, snapshot...)
+	invalidState[shareOffset+len(sigShare)+4+4+4] = 2
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, invalidState, privKey, kg)
+	require.True(t, errors.Is(err, crypto.ErrInvalidSnapshot))
+
+	_, err = multisig.NewBLSMultisigFromSnapshot(llSigner, snapshot, nil, kg)
+	require.Equal(t, crypto.ErrNilPrivateKey, err)
+}
@@ -0,0 +1,285 @@
+package multisig
+
+import (
+	"sync"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ crypto.MultiSigner = (*weightedBlsMultiSigner)(nil)
+var _ crypto.SigSharesVerifier = (*weightedBlsMultiSigner)(nil)
+
+// weightedBlsMultiSigner is a BLS multi-signer that also requires the signers of an aggregated signature to hold a
+// weighted quorum of the consensus group, the weights being taken from a validator set
+type weightedBlsMultiSigner struct {
+	*blsMultiSigner
+	validators  *WeightedValidatorSet
+	mutWeights  sync.RWMutex
+	weights     []uint64
+	totalWeight uint64
+}
+
+// NewWeightedBLSMultisig creates a new weighted BLS multi-signer. All the public keys of the consensus group need
+// to be part of the validator set
+func NewWeightedBLSMultisig(
+	llSigner crypto.LowLevelSignerBLS,
+	validators *WeightedValidatorSet,
+	pubKeys []string,
+	privKey crypto.PrivateKey,
+	keyGen crypto.KeyGenerator,
+	ownIndex uint16,
+) (*weightedBlsMultiSigner, error) {
+	if check.IfNil(validators) {
+		return nil, crypto.ErrNilParam
+	}
+
+	weights, err := validators.ConsensusGroupWeights(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	multiSigner, err := NewBLSMultisig(llSigner, pubKeys, privKey, keyGen, ownIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	return &weightedBlsMultiSigner{
+		blsMultiSigner: multiSigner,
+		validators:     validators,
+		weights:        weights,
+		totalWeight:    sumWeights(weights),
+	}, nil
+}
+
+// Reset resets the multiSigData inside the multiSigner, together with the weights of the new consensus group
+func (wbms *weightedBlsMultiSigner) Reset(pubKeys []string, index uint16) error {
+	if pubKeys == nil {
+		return crypto.ErrNilPublicKeys
+	}
+
+	return wbms.resetWeighted(pubKeys, func() error {
+		return wbms.blsMultiSigner.Reset(pubKeys, index)
+	})
+}
+
+// ResetWithPubKeyBytes resets the multiSigData inside the multiSigner for the consensus group given by the serialized
+// public keys, together with the weights of the new consensus group
+func (wbms *weightedBlsMultiSigner) ResetWithPubKeyBytes(pubKeys [][]byte, index uint16) error {
+	if pubKeys == nil {
+		return crypto.ErrNilPublicKeys
+	}
+
+	return wbms.resetWeighted(bytesToStrings(pubKeys), func() error {
+		return wbms.blsMultiSigner.ResetWithPubKeyBytes(pubKeys, index)
+	})
+}
+
+// ResetWithPublicKeys resets the multiSigData inside the multiSigner for the consensus group given by the public keys,
+// together with the weights of the new consensus group
+func (wbms *weightedBlsMultiSigner) ResetWithPublicKeys(pubKeys []crypto.PublicKey, index uint16) error {
+	if pubKeys == nil {
+		return crypto.ErrNilPublicKeys
+	}
+
+	serialized, err := pubKeysToStrings(pubKeys)
+	if err != nil {
+		return err
+	}
+
+	return wbms.resetWeighted(serialized, func() error {
+		return wbms.blsMultiSigner.ResetWithPublicKeys(pubKeys, index)
+	})
+}
+
+// resetWeighted looks up the weights of the serialized public keys before resetting the multiSigData, so the weights
+// are updated only together with the consensus group
+func (wbms *weightedBlsMultiSigner) resetWeighted(serialized []string, reset func() error) error {
+	weights, err := wbms.validators.ConsensusGroupWeights(serialized)
+	if err != nil {
+		return err
+	}
+
+	wbms.mutWeights.Lock()
+	defer wbms.mutWeights.Unlock()
+
+	err = reset()
+	if err != nil {
+		return err
+	}
+
+	wbms.weights = weights
+	wbms.totalWeight = sumWeights(weights)
+
+	return nil
+}
+
+// Create generates a weighted multiSigner with the same validator set and strict mode, initialized with the given
+// params
+func (wbms *weightedBlsMultiSigner) Create(pubKeys []string, index uint16) (crypto.MultiSigner, error) {
+	return wbms.createWeighted(pubKeys, index, func() (*consensusGroup, error) {
+		return newConsensusGroupFromStrings(pubKeys, wbms.keyGen)
+	})
+}
+
+// CreateWithPubKeyBytes generates a weighted multiSigner with the same validator set and strict mode, for the
+// consensus group given by the serialized public keys
+func (wbms *weightedBlsMultiSigner) CreateWithPubKeyBytes(pubKeys [][]byte, index uint16) (crypto.MultiSigner, error) {
+	if pubKeys == nil {
+		return nil, crypto.ErrNilPublicKeys
+	}
+
+	return wbms.createWeighted(bytesToStrings(pubKeys), index, func() (*consensusGroup, error) {
+		return newConsensusGroupFromBytes(pubKeys, wbms.keyGen)
+	})
+}
+
+// CreateWithPublicKeys generates a weighted multiSigner with the same validator set and strict mode, for the consensus
+// group given by the public keys
+func (wbms *weightedBlsMultiSigner) CreateWithPublicKeys(
+	pubKeys []crypto.PublicKey,
+	index uint16,
+) (crypto.MultiSigner, error) {
+	if pubKeys == nil {
+		return nil, crypto.ErrNilPublicKeys
+	}
+
+	serialized, err := pubKeysToStrings(pubKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	return wbms.createWeighted(serialized, index, func() (*consensusGroup, error) {
+		return newConsensusGroupFromPublicKeys(pubKeys)
+	})
+}
+
+// createWeighted looks up the weights of the serialized public keys before creating the consensus group, the created
+// multiSigner keeping the validator set and the strict mode
+func (wbms *weightedBlsMultiSigner) createWeighted(
+	serialized []string,
+	index uint16,
+	newGroup func() (*consensusGroup, error),
+) (*weightedBlsMultiSigner, error) {
+	weights, err := wbms.validators.ConsensusGroupWeights(serialized)
+	if err != nil {
+		return nil, err
+	}
+
+	err = checkMultiSigArgs(wbms.llSigner, wbms.privKeyForCreate(), len(serialized), wbms.keyGen, index)
+	if err != nil {
+		return nil, err
+	}
+
+	group, err := newGroup()
+	if err != nil {
+		return nil, err
+	}
+
+	return &weightedBlsMultiSigner{
+		blsMultiSigner: wbms.create(group, index),
+		validators:     wbms.validators,
+		weights:        weights,
+		totalWeight:    sumWeights(weights),
+	}, nil
+}
+
+// TotalWeight returns the total weight of the consensus group
+func (wbms *weightedBlsMultiSigner) TotalWeight() uint64 {
+	wbms.mutWeights.RLock()
+	defer wbms.mutWeights.RUnlock()
+
+	return wbms.totalWeight
+}
+
+// SignedWeight returns the weight of the signers selected by the bitmap
+func (wbms *weightedBlsMultiSigner) SignedWeight(bitmap []byte) (uint64, error) {
+	if bitmap == nil {
+		return 0, crypto.ErrNilBitmap
+	}
+
+	wbms.mutWeights.RLock()
+	defer wbms.mutWeights.RUnlock()
+
+	selection, err := NewBitmapFromBytes(bitmap, len(wbms.weights))
+	if err != nil {
+		return 0, err
+	}
+
+	return selection.SignedWeight(wbms.weights)
+}
+
+// Verify verifies the aggregated signature, failing with ErrInsufficientSignedWeight if the signers selected by the
+// bitmap do not hold the weighted quorum
+func (wbms *weightedBlsMultiSigner) Verify(message []byte, bitmap []byte) error {
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	wbms.mutWeights.RLock()
+	defer wbms.mutWeights.RUnlock()
+
+	selection, err := NewBitmapFromBytes(bitmap, len(wbms.weights))
+	if err != nil {
+		return err
+	}
+
+	err = wbms.checkQuorum(selection)
+	if err != nil {
+		return err
+	}
+
+	return wbms.blsMultiSigner.Verify(message, bitmap)
+}
+
+// VerifyWithBitmap verifies the aggregated signature, failing with ErrInsufficientSignedWeight if the signers
+// selected by the bitmap do not hold the weighted quorum
+func (wbms *weightedBlsMultiSigner) VerifyWithBitmap(message []byte, bitmap *Bitmap) error {
+	if bitmap == nil {
+		return crypto.ErrNilBitmap
+	}
+
+	wbms.mutWeights.RLock()
+	defer wbms.mutWeights.RUnlock()
+
+	err := bitmap.CheckSize(len(wbms.weights))
+	if err != nil {
+		return err
+	}
+
+	err = wbms.checkQuorum(bitmap)
+	if err != nil {
+		return err
+	}
+
+	return wbms.blsMultiSigner.VerifyWithBitmap(message, bitmap)
+}
+
+// not concurrent safe, should be used under RLock mutex
+func (wbms *weightedBlsMultiSigner) checkQuorum(bitmap *Bitmap) error {
+	signedWeight, err := bitmap.SignedWeight(wbms.weights)
+	if err != nil {
+		return err
+	}
+
+	if !wbms.validators.HasQuorum(signedWeight, wbms.totalWeight) {
+		return crypto.ErrInsufficientSignedWeight
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (wbms *weightedBlsMultiSigner) IsInterfaceNil() bool {
+	return wbms == nil
+}
+
+// sumWeights returns the sum of the weights, which were already checked against overflows
+func sumWeights(weights []uint64) uint64 {
+	sum := uint64(0)
+	for _, weight := range weights {
+		sum += weight
+	}
+
+	return sum
+}
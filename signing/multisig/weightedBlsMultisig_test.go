@@ -0,0 +1,251 @@
+package multisig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	llsig "github.com/ME-MotherEarth/me-crypto/signing/mcl/multisig"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createWeightedSigners(
+	t *testing.T,
+	weights []uint64,
+	message []byte,
+) ([]crypto.MultiSigner, *multisig.WeightedValidatorSet, []string) {
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	_, _, privKeys, pubKeys, kg := generateMultiSigParamsBLSWithPrivateKeys(len(weights), 0)
+
+	validators := make([]multisig.WeightedValidator, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		validators[i] = multisig.WeightedValidator{
+			PubKey: []byte(pubKey),
+			Weight: weights[i],
+		}
+	}
+	set, err := multisig.NewWeightedValidatorSet(validators, 2, 3)
+	require.Nil(t, err)
+
+	signers := make([]crypto.MultiSigner, len(pubKeys))
+	for i := range pubKeys {
+		signers[i], err = multisig.NewWeightedBLSMultisig(llSigner, set, pubKeys, privKeys[i], kg, uint16(i))
+		require.Nil(t, err)
+	}
+
+	for i := range signers {
+		sigShare, errSign := signers[i].CreateSignatureShare(message, nil)
+		require.Nil(t, errSign)
+		require.Nil(t, signers[0].StoreSignatureShare(uint16(i), sigShare))
+	}
+
+	return signers, set, pubKeys
+}
+
+func TestNewWeightedBLSMultisig(t *testing.T) {
+	t.Parallel()
+
+	llSigner := &llsig.BlsMultiSigner{Hasher: &mock.HasherSpongeMock{}}
+	privKey, _, pubKeys, kg := generateMultiSigParamsBLS(4, 0)
+	validators := make([]multisig.WeightedValidator, 0, len(pubKeys))
+	for _, pubKey := range pubKeys[:3] {
+		validators = append(validators, multisig.WeightedValidator{PubKey: []byte(pubKey), Weight: 10})
+	}
+	set, _ := multisig.NewWeightedValidatorSet(validators, 2, 3)
+
+	multiSig, err := multisig.NewWeightedBLSMultisig(llSigner, nil, pubKeys[:3], privKey, kg, 0)
+	require.True(t, check.IfNil(multiSig))
+	require.Equal(t, crypto.ErrNilParam, err)
+
+	multiSig, err = multisig.NewWeightedBLSMultisig(nil, set, pubKeys[:3], privKey, kg, 0)
+	require.True(t, check.IfNil(multiSig))
+	require.Equal(t, crypto.ErrNilLowLevelSigner, err)
+
+	multiSig, err = multisig.NewWeightedBLSMultisig(llSigner, set, pubKeys, privKey, kg, 0)
+	require.True(t, check.IfNil(multiSig))
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+
+	duplicatedPubKeys := []string{pubKeys[0], pubKeys[1], pubKeys[0]}
+	multiSig, err = multisig.NewWeightedBLSMultisig(llSigner, set, duplicatedPubKeys, privKey, kg, 0)
+	require.True(t, check.IfNil(multiSig))
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	multiSig, err = multisig.NewWeightedBLSMultisig(llSigner, set, pubKeys[:3], privKey, kg, 0)
+	require.Nil(t, err)
+	require.False(t, check.IfNil(multiSig))
+	require.Equal(t, uint64(30), multiSig.TotalWeight())
+}
+
+func TestWeightedBlsMultiSigner_SignedWeight(t *testing.T) {
+	t.Parallel()
+
+	signers, _, _ := createWeightedSigners(t, []uint64{10, 20, 30, 40}, []byte("message"))
+	type weightedSigner interface {
+		SignedWeight(bitmap []byte) (uint64, error)
+	}
+	signer := signers[0].(weightedSigner)
+
+	_, err := signer.SignedWeight(nil)
+	require.Equal(t, crypto.ErrNilBitmap, err)
+
+	weight, err := signer.SignedWeight([]byte{0x0a})
+	require.Nil(t, err)
+	require.Equal(t, uint64(60), weight)
+}
+
+func TestWeightedBlsMultiSigner_Verify(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	signers, _, _ := createWeightedSigners(t, []uint64{10, 20, 30, 40}, msg)
+	signer := signers[0]
+
+	t.Run("signed weight below threshold should err", func(t *testing.T) {
+		// 3 out of 4 signers, but only 60 out of 100
+		bitmap := []byte{0x07}
+		aggSig, err := signer.AggregateSigs(bitmap)
+		require.Nil(t, err)
+		require.Nil(t, signer.SetAggregatedSig(aggSig))
+
+		err = signer.Verify(msg, bitmap)
+		require.Equal(t, crypto.ErrInsufficientSignedWeight, err)
+
+		selection, _ := multisig.NewBitmapFromBytes(bitmap, 4)
+		err = signer.(interface {
+			VerifyWithBitmap(message []byte, bitmap *multisig.Bitmap) error
+		}).VerifyWithBitmap(msg, selection)
+		require.Equal(t, crypto.ErrInsufficientSignedWeight, err)
+	})
+	t.Run("signed weight above threshold should work", func(t *testing.T) {
+		// 2 out of 4 signers, but 70 out of 100
+		bitmap := []byte{0x0c}
+		aggSig, err := signer.AggregateSigs(bitmap)
+		require.Nil(t, err)
+		require.Nil(t, signer.SetAggregatedSig(aggSig))
+
+		require.Nil(t, signer.Verify(msg, bitmap))
+		require.Equal(t, crypto.ErrAggSigNotValid, signer.Verify([]byte("other message"), bitmap))
+	})
+	t.Run("invalid bitmap should err", func(t *testing.T) {
+		require.Equal(t, crypto.ErrNilBitmap, signer.Verify(msg, nil))
+		require.Equal(t, crypto.ErrBitmapMismatch, signer.Verify(msg, []byte{}))
+	})
+}
+
+func TestWeightedBlsMultiSigner_ResetAndCreate(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	signers, _, pubKeys := createWeightedSigners(t, []uint64{10, 20, 30, 40}, msg)
+	signer := signers[0].(interface {
+		crypto.MultiSigner
+		TotalWeight() uint64
+	})
+
+	err := signer.Reset([]string{pubKeys[3], "unknown"}, 0)
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+	require.Equal(t, uint64(100), signer.TotalWeight())
+
+	err = signer.Reset([]string{pubKeys[3], pubKeys[1]}, 0)
+	require.Nil(t, err)
+	require.Equal(t, uint64(60), signer.TotalWeight())
+
+	created, err := signer.Create([]string{pubKeys[0], pubKeys[2]}, 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(40), created.(interface{ TotalWeight() uint64 }).TotalWeight())
+
+	_, err = signer.Create([]string{"unknown"}, 0)
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+}
+
+func TestWeightedBlsMultiSigner_CreateKeepsStrictMode(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	signers, _, pubKeys := createWeightedSigners(t, []uint64{10, 20, 30}, msg)
+	signer := signers[0].(interface {
+		crypto.MultiSigner
+		SetStrictMode(strict bool)
+	})
+	sigShare, err := signer.SignatureShare(1)
+	require.Nil(t, err)
+
+	signer.SetStrictMode(true)
+	created, err := signer.Create(pubKeys, 0)
+	require.Nil(t, err)
+	require.Equal(t, crypto.ErrUnverifiedSigShare, created.StoreSignatureShare(1, sigShare))
+}
+
+type weightedPubKeyVariantsSigner interface {
+	crypto.MultiSigner
+	TotalWeight() uint64
+	SetStrictMode(strict bool)
+	ResetWithPubKeyBytes(pubKeys [][]byte, index uint16) error
+	ResetWithPublicKeys(pubKeys []crypto.PublicKey, index uint16) error
+	CreateWithPubKeyBytes(pubKeys [][]byte, index uint16) (crypto.MultiSigner, error)
+	CreateWithPublicKeys(pubKeys []crypto.PublicKey, index uint16) (crypto.MultiSigner, error)
+}
+
+func TestWeightedBlsMultiSigner_PubKeyVariantsResetAndCreate(t *testing.T) {
+	t.Parallel()
+
+	msg := []byte("message")
+	signers, _, pubKeys := createWeightedSigners(t, []uint64{10, 20, 30, 40}, msg)
+	signer := signers[0].(weightedPubKeyVariantsSigner)
+	_, _, otherPubKeys, kg := generateMultiSigParamsBLS(1, 0)
+	pubKeysBytes := stringsToBytes(pubKeys)
+	publicKeys := make([]crypto.PublicKey, 0, len(pubKeysBytes))
+	for _, pubKey := range pubKeysBytes {
+		publicKey, err := kg.PublicKeyFromByteArray(pubKey)
+		require.Nil(t, err)
+		publicKeys = append(publicKeys, publicKey)
+	}
+
+	// the keys out of the validator set are rejected, the weights being kept
+	err := signer.ResetWithPubKeyBytes([][]byte{pubKeysBytes[3], []byte(otherPubKeys[0])}, 0)
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+	require.Equal(t, uint64(100), signer.TotalWeight())
+	err = signer.ResetWithPublicKeys([]crypto.PublicKey{publicKeys[3], nil}, 0)
+	requireInvalidPublicKeyError(t, err, 1, crypto.ErrNilPublicKey)
+	require.Equal(t, uint64(100), signer.TotalWeight())
+
+	err = signer.ResetWithPubKeyBytes([][]byte{pubKeysBytes[3], pubKeysBytes[1]}, 0)
+	require.Nil(t, err)
+	require.Equal(t, uint64(60), signer.TotalWeight())
+
+	// the weights follow the new consensus group, so the signer with weight 20 out of 60 is not a quorum
+	sigShare, err := signers[1].CreateSignatureShare(msg, nil)
+	require.Nil(t, err)
+	require.Nil(t, signer.StoreSignatureShare(1, sigShare))
+	aggSig, err := signer.AggregateSigs([]byte{0x02})
+	require.Nil(t, err)
+	require.Nil(t, signer.SetAggregatedSig(aggSig))
+	require.Equal(t, crypto.ErrInsufficientSignedWeight, signer.Verify(msg, []byte{0x02}))
+
+	err = signer.ResetWithPublicKeys([]crypto.PublicKey{publicKeys[0], publicKeys[2]}, 0)
+	require.Nil(t, err)
+	require.Equal(t, uint64(40), signer.TotalWeight())
+
+	signer.SetStrictMode(true)
+	created, err := signer.CreateWithPubKeyBytes(pubKeysBytes[1:], 0)
+	require.Nil(t, err)
+	require.Equal(t, uint64(90), created.(weightedPubKeyVariantsSigner).TotalWeight())
+	require.Equal(t, crypto.ErrUnverifiedSigShare, created.StoreSignatureShare(0, sigShare))
+
+	created, err = signer.CreateWithPublicKeys(publicKeys[:2], 1)
+	require.Nil(t, err)
+	require.Equal(t, uint64(30), created.(weightedPubKeyVariantsSigner).TotalWeight())
+	require.Equal(t, crypto.ErrUnverifiedSigShare, created.StoreSignatureShare(1, sigShare))
+
+	_, err = signer.CreateWithPubKeyBytes([][]byte{[]byte(otherPubKeys[0])}, 0)
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+	_, err = signer.CreateWithPublicKeys([]crypto.PublicKey{nil}, 0)
+	requireInvalidPublicKeyError(t, err, 0, crypto.ErrNilPublicKey)
+	_, err = signer.CreateWithPubKeyBytes(nil, 0)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+	_, err = signer.CreateWithPublicKeys(publicKeys, 4)
+	require.Equal(t, crypto.ErrIndexOutOfBounds, err)
+}
@@ -0,0 +1,118 @@
+package multisig
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+// WeightedValidator holds the public key of a validator together with its weight, such as its stake
+type WeightedValidator struct {
+	PubKey []byte
+	Weight uint64
+}
+
+/*
+WeightedValidatorSet holds the weights of the validators eligible for the consensus groups, together with the quorum
+threshold. A multi-signature reaches the quorum if its signers hold more than
+thresholdNumerator/thresholdDenominator of the total weight of the consensus group, so 2/3 gives a stake-weighted
+supermajority.
+
+The set is immutable, so it can be shared between multisigners
+*/
+type WeightedValidatorSet struct {
+	weights              map[string]uint64
+	thresholdNumerator   uint64
+	thresholdDenominator uint64
+}
+
+// NewWeightedValidatorSet creates a weighted validator set with the given quorum threshold
+func NewWeightedValidatorSet(
+	validators []WeightedValidator,
+	thresholdNumerator uint64,
+	thresholdDenominator uint64,
+) (*WeightedValidatorSet, error) {
+	if len(validators) == 0 {
+		return nil, crypto.ErrNoPublicKeySet
+	}
+	if thresholdDenominator == 0 || thresholdNumerator >= thresholdDenominator {
+		return nil, crypto.ErrInvalidThreshold
+	}
+
+	weights := make(map[string]uint64, len(validators))
+	for i, validator := range validators {
+		if len(validator.PubKey) == 0 {
+			return nil, fmt.Errorf("%w at index %d", crypto.ErrEmptyPubKeyString, i)
+		}
+		if validator.Weight == 0 {
+			return nil, fmt.Errorf("%w: zero weight at index %d", crypto.ErrInvalidParam, i)
+		}
+
+		_, exists := weights[string(validator.PubKey)]
+		if exists {
+			return nil, fmt.Errorf("%w: duplicate public key at index %d", crypto.ErrInvalidParam, i)
+		}
+
+		weights[string(validator.PubKey)] = validator.Weight
+	}
+
+	return &WeightedValidatorSet{
+		weights:              weights,
+		thresholdNumerator:   thresholdNumerator,
+		thresholdDenominator: thresholdDenominator,
+	}, nil
+}
+
+// Weight returns the weight of the validator with the given public key
+func (wvs *WeightedValidatorSet) Weight(pubKey []byte) (uint64, bool) {
+	weight, exists := wvs.weights[string(pubKey)]
+
+	return weight, exists
+}
+
+// ConsensusGroupWeights returns the weights of the validators of a consensus group, in the order of the group.
+// A validator can appear only once in the group, so its weight is not counted multiple times
+func (wvs *WeightedValidatorSet) ConsensusGroupWeights(pubKeys []string) ([]uint64, error) {
+	if len(pubKeys) == 0 {
+		return nil, crypto.ErrNilPublicKeys
+	}
+
+	weights := make([]uint64, len(pubKeys))
+	seenPubKeys := make(map[string]struct{}, len(pubKeys))
+	totalWeight := uint64(0)
+	for i, pubKey := range pubKeys {
+		weight, exists := wvs.weights[pubKey]
+		if !exists {
+			return nil, fmt.Errorf("%w: not in the validator set at index %d", crypto.ErrInvalidPublicKey, i)
+		}
+		_, seen := seenPubKeys[pubKey]
+		if seen {
+			return nil, fmt.Errorf("%w: duplicate public key in the group at index %d", crypto.ErrInvalidParam, i)
+		}
+		seenPubKeys[pubKey] = struct{}{}
+		if totalWeight > math.MaxUint64-weight {
+			return nil, fmt.Errorf("%w: total weight overflow", crypto.ErrInvalidParam)
+		}
+
+		weights[i] = weight
+		totalWeight += weight
+	}
+
+	return weights, nil
+}
+
+// HasQuorum returns true if the signed weight is more than the threshold fraction of the total weight
+func (wvs *WeightedValidatorSet) HasQuorum(signedWeight uint64, totalWeight uint64) bool {
+	// signedWeight / totalWeight > numerator / denominator, without overflows
+	signed := new(big.Int).Mul(new(big.Int).SetUint64(signedWeight), new(big.Int).SetUint64(wvs.thresholdDenominator))
+	threshold := new(big.Int).Mul(new(big.Int).SetUint64(totalWeight), new(big.Int).SetUint64(wvs.thresholdNumerator))
+
+	return signed.Cmp(threshold) > 0
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (wvs *WeightedValidatorSet) IsInterfaceNil() bool {
+	return wvs == nil
+}
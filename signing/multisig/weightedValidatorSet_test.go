@@ -0,0 +1,112 @@
+package multisig_test
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/multisig"
+	"github.com/stretchr/testify/require"
+)
+
+func createWeightedValidators(weights ...uint64) []multisig.WeightedValidator {
+	validators := make([]multisig.WeightedValidator, len(weights))
+	for i, weight := range weights {
+		validators[i] = multisig.WeightedValidator{
+			PubKey: []byte{byte('a' + i)},
+			Weight: weight,
+		}
+	}
+
+	return validators
+}
+
+func TestNewWeightedValidatorSet(t *testing.T) {
+	t.Parallel()
+
+	t.Run("no validators should err", func(t *testing.T) {
+		set, err := multisig.NewWeightedValidatorSet(nil, 2, 3)
+		require.Nil(t, set)
+		require.Equal(t, crypto.ErrNoPublicKeySet, err)
+	})
+	t.Run("invalid threshold should err", func(t *testing.T) {
+		set, err := multisig.NewWeightedValidatorSet(createWeightedValidators(1, 2), 2, 0)
+		require.Nil(t, set)
+		require.Equal(t, crypto.ErrInvalidThreshold, err)
+
+		set, err = multisig.NewWeightedValidatorSet(createWeightedValidators(1, 2), 3, 3)
+		require.Nil(t, set)
+		require.Equal(t, crypto.ErrInvalidThreshold, err)
+	})
+	t.Run("empty public key should err", func(t *testing.T) {
+		validators := createWeightedValidators(1, 2)
+		validators[1].PubKey = nil
+		set, err := multisig.NewWeightedValidatorSet(validators, 2, 3)
+		require.Nil(t, set)
+		require.True(t, errors.Is(err, crypto.ErrEmptyPubKeyString))
+	})
+	t.Run("zero weight should err", func(t *testing.T) {
+		set, err := multisig.NewWeightedValidatorSet(createWeightedValidators(1, 0), 2, 3)
+		require.Nil(t, set)
+		require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+	})
+	t.Run("duplicate public key should err", func(t *testing.T) {
+		validators := createWeightedValidators(1, 2)
+		validators[1].PubKey = validators[0].PubKey
+		set, err := multisig.NewWeightedValidatorSet(validators, 2, 3)
+		require.Nil(t, set)
+		require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+	})
+	t.Run("should work", func(t *testing.T) {
+		set, err := multisig.NewWeightedValidatorSet(createWeightedValidators(1, 2), 2, 3)
+		require.Nil(t, err)
+		require.False(t, check.IfNil(set))
+
+		weight, exists := set.Weight([]byte("b"))
+		require.True(t, exists)
+		require.Equal(t, uint64(2), weight)
+		_, exists = set.Weight([]byte("c"))
+		require.False(t, exists)
+	})
+}
+
+func TestWeightedValidatorSet_ConsensusGroupWeights(t *testing.T) {
+	t.Parallel()
+
+	set, _ := multisig.NewWeightedValidatorSet(createWeightedValidators(1, 2, 3, math.MaxUint64), 2, 3)
+
+	weights, err := set.ConsensusGroupWeights(nil)
+	require.Nil(t, weights)
+	require.Equal(t, crypto.ErrNilPublicKeys, err)
+
+	weights, err = set.ConsensusGroupWeights([]string{"c", "e"})
+	require.Nil(t, weights)
+	require.True(t, errors.Is(err, crypto.ErrInvalidPublicKey))
+
+	weights, err = set.ConsensusGroupWeights([]string{"c", "d"})
+	require.Nil(t, weights)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	weights, err = set.ConsensusGroupWeights([]string{"c", "a", "c"})
+	require.Nil(t, weights)
+	require.True(t, errors.Is(err, crypto.ErrInvalidParam))
+
+	weights, err = set.ConsensusGroupWeights([]string{"c", "a", "b"})
+	require.Nil(t, err)
+	require.Equal(t, []uint64{3, 1, 2}, weights)
+}
+
+func TestWeightedValidatorSet_HasQuorum(t *testing.T) {
+	t.Parallel()
+
+	set, _ := multisig.NewWeightedValidatorSet(createWeightedValidators(1), 2, 3)
+
+	require.False(t, set.HasQuorum(0, 90))
+	require.False(t, set.HasQuorum(60, 90))
+	require.True(t, set.HasQuorum(61, 90))
+	require.True(t, set.HasQuorum(90, 90))
+	require.True(t, set.HasQuorum(math.MaxUint64, math.MaxUint64))
+	require.False(t, set.HasQuorum(math.MaxUint64/3*2, math.MaxUint64))
+}
@@ -0,0 +1,12 @@
+package peersig
+
+// Cacher defines the cache operations needed by the peer signature handler. Its method set is a subset of
+// the storage.Cacher interface from me-core so any of those implementations can be plugged in.
+type Cacher interface {
+	// Put adds a value to the cache. Returns true if an eviction occurred.
+	Put(key []byte, value interface{}, sizeInBytes int) (evicted bool)
+	// Get looks up a key's value from the cache.
+	Get(key []byte) (value interface{}, ok bool)
+	// IsInterfaceNil returns true if there is no value under the interface
+	IsInterfaceNil() bool
+}
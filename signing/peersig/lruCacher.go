@@ -0,0 +1,92 @@
+package peersig
+
+import (
+	"container/list"
+	"sync"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ Cacher = (*lruCacher)(nil)
+
+type lruEntry struct {
+	key   string
+	value interface{}
+}
+
+// lruCacher is a bounded, concurrent safe, least recently used cache
+type lruCacher struct {
+	mut      sync.Mutex
+	capacity int
+	items    map[string]*list.Element
+	order    *list.List
+}
+
+// NewLRUCacher creates a new LRU cacher that holds at most capacity elements
+func NewLRUCacher(capacity int) (*lruCacher, error) {
+	if capacity <= 0 {
+		return nil, crypto.ErrInvalidCacheCapacity
+	}
+
+	return &lruCacher{
+		capacity: capacity,
+		items:    make(map[string]*list.Element, capacity),
+		order:    list.New(),
+	}, nil
+}
+
+// Put adds or updates a value in the cache, evicting the least recently used element if the capacity is exceeded.
+// The size in bytes is ignored, the cache is bounded only by the number of elements
+func (lc *lruCacher) Put(key []byte, value interface{}, _ int) bool {
+	lc.mut.Lock()
+	defer lc.mut.Unlock()
+
+	element, exists := lc.items[string(key)]
+	if exists {
+		element.Value.(*lruEntry).value = value
+		lc.order.MoveToFront(element)
+		return false
+	}
+
+	lc.items[string(key)] = lc.order.PushFront(&lruEntry{
+		key:   string(key),
+		value: value,
+	})
+	if lc.order.Len() <= lc.capacity {
+		return false
+	}
+
+	oldest := lc.order.Back()
+	lc.order.Remove(oldest)
+	delete(lc.items, oldest.Value.(*lruEntry).key)
+
+	return true
+}
+
+// Get returns the value stored for the provided key, marking it as the most recently used
+func (lc *lruCacher) Get(key []byte) (interface{}, bool) {
+	lc.mut.Lock()
+	defer lc.mut.Unlock()
+
+	element, exists := lc.items[string(key)]
+	if !exists {
+		return nil, false
+	}
+
+	lc.order.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+// Len returns the number of elements held by the cache
+func (lc *lruCacher) Len() int {
+	lc.mut.Lock()
+	defer lc.mut.Unlock()
+
+	return lc.order.Len()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (lc *lruCacher) IsInterfaceNil() bool {
+	return lc == nil
+}
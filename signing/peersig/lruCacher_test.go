@@ -0,0 +1,74 @@
+package peersig_test
+
+import (
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing/peersig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLRUCacher_InvalidCapacityShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, err := peersig.NewLRUCacher(0)
+
+	assert.True(t, check.IfNil(cacher))
+	assert.Equal(t, crypto.ErrInvalidCacheCapacity, err)
+}
+
+func TestLRUCacher_PutGet(t *testing.T) {
+	t.Parallel()
+
+	cacher, err := peersig.NewLRUCacher(2)
+	require.Nil(t, err)
+	assert.False(t, check.IfNil(cacher))
+
+	evicted := cacher.Put([]byte("key"), "value", 0)
+	assert.False(t, evicted)
+
+	value, ok := cacher.Get([]byte("key"))
+	assert.True(t, ok)
+	assert.Equal(t, "value", value)
+
+	value, ok = cacher.Get([]byte("missing"))
+	assert.False(t, ok)
+	assert.Nil(t, value)
+}
+
+func TestLRUCacher_PutExistingKeyShouldUpdate(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := peersig.NewLRUCacher(2)
+	_ = cacher.Put([]byte("key"), "value1", 0)
+	evicted := cacher.Put([]byte("key"), "value2", 0)
+	assert.False(t, evicted)
+	assert.Equal(t, 1, cacher.Len())
+
+	value, _ := cacher.Get([]byte("key"))
+	assert.Equal(t, "value2", value)
+}
+
+func TestLRUCacher_ShouldEvictLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := peersig.NewLRUCacher(2)
+	_ = cacher.Put([]byte("key1"), 1, 0)
+	_ = cacher.Put([]byte("key2"), 2, 0)
+
+	// key1 becomes the most recently used
+	_, _ = cacher.Get([]byte("key1"))
+
+	evicted := cacher.Put([]byte("key3"), 3, 0)
+	assert.True(t, evicted)
+	assert.Equal(t, 2, cacher.Len())
+
+	_, ok := cacher.Get([]byte("key2"))
+	assert.False(t, ok)
+	_, ok = cacher.Get([]byte("key1"))
+	assert.True(t, ok)
+	_, ok = cacher.Get([]byte("key3"))
+	assert.True(t, ok)
+}
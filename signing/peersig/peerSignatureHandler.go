@@ -0,0 +1,153 @@
+package peersig
+
+import (
+	"bytes"
+
+	"github.com/ME-MotherEarth/me-core/core"
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+)
+
+var _ crypto.PeerSignatureHandler = (*peerSignatureHandler)(nil)
+
+type pidSignature struct {
+	pid       core.PeerID
+	signature []byte
+}
+
+// peerSignatureHandler is used to verify and create peer signatures, buffering the (pid, signature) pair
+// for every public key so repeated requests do not need to sign or verify again
+type peerSignatureHandler struct {
+	pkPIDSignature Cacher
+	singleSigner   crypto.SingleSigner
+	keyGen         crypto.KeyGenerator
+}
+
+// NewPeerSignatureHandler creates a new instance of peerSignatureHandler
+func NewPeerSignatureHandler(
+	pkPIDSignature Cacher,
+	singleSigner crypto.SingleSigner,
+	keyGen crypto.KeyGenerator,
+) (*peerSignatureHandler, error) {
+	if check.IfNil(pkPIDSignature) {
+		return nil, crypto.ErrNilCacher
+	}
+	if check.IfNil(singleSigner) {
+		return nil, crypto.ErrNilSingleSigner
+	}
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+
+	return &peerSignatureHandler{
+		pkPIDSignature: pkPIDSignature,
+		singleSigner:   singleSigner,
+		keyGen:         keyGen,
+	}, nil
+}
+
+// VerifyPeerSignature verifies the signature associated with the public key. It first checks the cache for the public key,
+// and if the cached entry does not hold the same pid and signature, it will verify the signature, caching the result on
+// success so a peer changing its pid replaces its cached entry. A signature not verifying against a cached entry fails
+// with ErrPIDMismatch or ErrSignatureMismatch
+func (psh *peerSignatureHandler) VerifyPeerSignature(pk []byte, pid core.PeerID, signature []byte) error {
+	if len(pk) == 0 {
+		return crypto.ErrInvalidPublicKey
+	}
+	if len(pid) == 0 {
+		return crypto.ErrInvalidPID
+	}
+	if len(signature) == 0 {
+		return crypto.ErrInvalidSignature
+	}
+
+	senderPubKey, err := psh.keyGen.PublicKeyFromByteArray(pk)
+	if err != nil {
+		return err
+	}
+
+	retrievedPID, retrievedSig := psh.getBufferedPIDSignature(pk)
+	if retrievedPID == pid && bytes.Equal(retrievedSig, signature) {
+		return nil
+	}
+
+	err = psh.singleSigner.Verify(senderPubKey, pid.Bytes(), signature)
+	if err != nil {
+		if len(retrievedPID) == 0 {
+			return err
+		}
+		if retrievedPID != pid {
+			return crypto.ErrPIDMismatch
+		}
+
+		return crypto.ErrSignatureMismatch
+	}
+
+	psh.bufferPIDSignature(pk, pid, signature)
+
+	return nil
+}
+
+// GetPeerSignature checks the cache for the signature of the given pid, and if it is not present, it signs the pid
+// with the provided private key and caches the result
+func (psh *peerSignatureHandler) GetPeerSignature(privateKey crypto.PrivateKey, pid []byte) ([]byte, error) {
+	if check.IfNil(privateKey) {
+		return nil, crypto.ErrNilPrivateKey
+	}
+	if len(pid) == 0 {
+		return nil, crypto.ErrInvalidPID
+	}
+
+	publicKey := privateKey.GeneratePublic()
+	if check.IfNil(publicKey) {
+		return nil, crypto.ErrNilPublicKey
+	}
+
+	pk, err := publicKey.ToByteArray()
+	if err != nil {
+		return nil, err
+	}
+
+	retrievedPID, retrievedSig := psh.getBufferedPIDSignature(pk)
+	if len(retrievedPID) != 0 && bytes.Equal(retrievedPID.Bytes(), pid) {
+		return append([]byte{}, retrievedSig...), nil
+	}
+
+	signature, err := psh.singleSigner.Sign(privateKey, pid)
+	if err != nil {
+		return nil, err
+	}
+
+	psh.bufferPIDSignature(pk, core.PeerID(pid), signature)
+
+	return signature, nil
+}
+
+// bufferPIDSignature caches a copy of the signature, so the caller can reuse its slice
+func (psh *peerSignatureHandler) bufferPIDSignature(pk []byte, pid core.PeerID, signature []byte) {
+	entry := &pidSignature{
+		pid:       pid,
+		signature: append([]byte{}, signature...),
+	}
+
+	psh.pkPIDSignature.Put(pk, entry, len(pid)+len(signature))
+}
+
+func (psh *peerSignatureHandler) getBufferedPIDSignature(pk []byte) (core.PeerID, []byte) {
+	entry, ok := psh.pkPIDSignature.Get(pk)
+	if !ok {
+		return "", nil
+	}
+
+	pidSig, ok := entry.(*pidSignature)
+	if !ok {
+		return "", nil
+	}
+
+	return pidSig.pid, pidSig.signature
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (psh *peerSignatureHandler) IsInterfaceNil() bool {
+	return psh == nil
+}
@@ -0,0 +1,245 @@
+package peersig_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ME-MotherEarth/me-core/core"
+	"github.com/ME-MotherEarth/me-core/core/check"
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/mock"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/ME-MotherEarth/me-crypto/signing/peersig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPeerSignatureHandler(t *testing.T, signer crypto.SingleSigner) (crypto.PeerSignatureHandler, crypto.KeyGenerator) {
+	cacher, err := peersig.NewLRUCacher(10)
+	require.Nil(t, err)
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	psh, err := peersig.NewPeerSignatureHandler(cacher, signer, kg)
+	require.Nil(t, err)
+
+	return psh, kg
+}
+
+func TestNewPeerSignatureHandler_NilCacherShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	psh, err := peersig.NewPeerSignatureHandler(nil, singlesig.NewBlsSigner(), kg)
+
+	assert.True(t, check.IfNil(psh))
+	assert.Equal(t, crypto.ErrNilCacher, err)
+}
+
+func TestNewPeerSignatureHandler_NilSingleSignerShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := peersig.NewLRUCacher(10)
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	psh, err := peersig.NewPeerSignatureHandler(cacher, nil, kg)
+
+	assert.True(t, check.IfNil(psh))
+	assert.Equal(t, crypto.ErrNilSingleSigner, err)
+}
+
+func TestNewPeerSignatureHandler_NilKeyGeneratorShouldErr(t *testing.T) {
+	t.Parallel()
+
+	cacher, _ := peersig.NewLRUCacher(10)
+	psh, err := peersig.NewPeerSignatureHandler(cacher, singlesig.NewBlsSigner(), nil)
+
+	assert.True(t, check.IfNil(psh))
+	assert.Equal(t, crypto.ErrNilKeyGenerator, err)
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureInvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	psh, kg := createPeerSignatureHandler(t, singlesig.NewBlsSigner())
+	_, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	pid := core.PeerID("pid")
+
+	assert.Equal(t, crypto.ErrInvalidPublicKey, psh.VerifyPeerSignature(nil, pid, []byte("sig")))
+	assert.Equal(t, crypto.ErrInvalidPID, psh.VerifyPeerSignature(pkBytes, "", []byte("sig")))
+	assert.Equal(t, crypto.ErrInvalidSignature, psh.VerifyPeerSignature(pkBytes, pid, nil))
+	assert.Equal(t, crypto.ErrInvalidParam, psh.VerifyPeerSignature([]byte("invalid pk"), pid, []byte("sig")))
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureInvalidSignatureShouldErr(t *testing.T) {
+	t.Parallel()
+
+	blsSigner := singlesig.NewBlsSigner()
+	psh, kg := createPeerSignatureHandler(t, blsSigner)
+	sk, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	signature, _ := blsSigner.Sign(sk, []byte("other pid"))
+
+	err := psh.VerifyPeerSignature(pkBytes, "pid", signature)
+	assert.Equal(t, crypto.ErrSigNotValid, err)
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureShouldCache(t *testing.T) {
+	t.Parallel()
+
+	numVerifyCalls := 0
+	blsSigner := singlesig.NewBlsSigner()
+	signer := &mock.SingleSignerStub{
+		SignCalled: blsSigner.Sign,
+		VerifyCalled: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			numVerifyCalls++
+			return blsSigner.Verify(public, msg, sig)
+		},
+	}
+
+	psh, kg := createPeerSignatureHandler(t, signer)
+	sk, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	pid := core.PeerID("pid")
+	signature, err := blsSigner.Sign(sk, pid.Bytes())
+	require.Nil(t, err)
+
+	err = psh.VerifyPeerSignature(pkBytes, pid, signature)
+	assert.Nil(t, err)
+	err = psh.VerifyPeerSignature(pkBytes, pid, signature)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numVerifyCalls)
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureMismatchesShouldErr(t *testing.T) {
+	t.Parallel()
+
+	psh, kg := createPeerSignatureHandler(t, singlesig.NewBlsSigner())
+	sk, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	pid := core.PeerID("pid")
+	signature, err := psh.GetPeerSignature(sk, pid.Bytes())
+	require.Nil(t, err)
+
+	err = psh.VerifyPeerSignature(pkBytes, pid, signature)
+	assert.Nil(t, err)
+
+	err = psh.VerifyPeerSignature(pkBytes, "other pid", signature)
+	assert.Equal(t, crypto.ErrPIDMismatch, err)
+
+	err = psh.VerifyPeerSignature(pkBytes, pid, []byte("other signature"))
+	assert.Equal(t, crypto.ErrSignatureMismatch, err)
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureNewPIDShouldReplaceCachedEntry(t *testing.T) {
+	t.Parallel()
+
+	numVerifyCalls := 0
+	blsSigner := singlesig.NewBlsSigner()
+	signer := &mock.SingleSignerStub{
+		SignCalled: blsSigner.Sign,
+		VerifyCalled: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			numVerifyCalls++
+			return blsSigner.Verify(public, msg, sig)
+		},
+	}
+
+	psh, kg := createPeerSignatureHandler(t, signer)
+	sk, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	pid := core.PeerID("pid")
+	newPID := core.PeerID("new pid")
+	signature, _ := blsSigner.Sign(sk, pid.Bytes())
+	newSignature, _ := blsSigner.Sign(sk, newPID.Bytes())
+
+	assert.Nil(t, psh.VerifyPeerSignature(pkBytes, pid, signature))
+	assert.Nil(t, psh.VerifyPeerSignature(pkBytes, newPID, newSignature))
+	assert.Nil(t, psh.VerifyPeerSignature(pkBytes, newPID, newSignature))
+	assert.Equal(t, 2, numVerifyCalls)
+
+	assert.Equal(t, crypto.ErrPIDMismatch, psh.VerifyPeerSignature(pkBytes, "other pid", newSignature))
+}
+
+func TestPeerSignatureHandler_VerifyPeerSignatureShouldCacheACopy(t *testing.T) {
+	t.Parallel()
+
+	numVerifyCalls := 0
+	blsSigner := singlesig.NewBlsSigner()
+	signer := &mock.SingleSignerStub{
+		SignCalled: blsSigner.Sign,
+		VerifyCalled: func(public crypto.PublicKey, msg []byte, sig []byte) error {
+			numVerifyCalls++
+			return blsSigner.Verify(public, msg, sig)
+		},
+	}
+
+	psh, kg := createPeerSignatureHandler(t, signer)
+	sk, pk := kg.GeneratePair()
+	pkBytes, _ := pk.ToByteArray()
+	pid := core.PeerID("pid")
+	signature, _ := blsSigner.Sign(sk, pid.Bytes())
+	buff := append([]byte{}, signature...)
+
+	require.Nil(t, psh.VerifyPeerSignature(pkBytes, pid, buff))
+	buff[0] ^= 0xff
+	assert.Nil(t, psh.VerifyPeerSignature(pkBytes, pid, signature))
+	assert.Equal(t, 1, numVerifyCalls)
+}
+
+func TestPeerSignatureHandler_GetPeerSignatureShouldCache(t *testing.T) {
+	t.Parallel()
+
+	numSignCalls := 0
+	blsSigner := singlesig.NewBlsSigner()
+	signer := &mock.SingleSignerStub{
+		SignCalled: func(private crypto.PrivateKey, msg []byte) ([]byte, error) {
+			numSignCalls++
+			return blsSigner.Sign(private, msg)
+		},
+		VerifyCalled: blsSigner.Verify,
+	}
+
+	psh, kg := createPeerSignatureHandler(t, signer)
+	sk, pk := kg.GeneratePair()
+	pid := []byte("pid")
+
+	sig1, err := psh.GetPeerSignature(sk, pid)
+	require.Nil(t, err)
+	sig2, err := psh.GetPeerSignature(sk, pid)
+	require.Nil(t, err)
+	assert.Equal(t, sig1, sig2)
+	assert.Equal(t, 1, numSignCalls)
+	assert.Nil(t, blsSigner.Verify(pk, pid, sig1))
+
+	sig3, err := psh.GetPeerSignature(sk, []byte("new pid"))
+	require.Nil(t, err)
+	assert.NotEqual(t, sig1, sig3)
+	assert.Equal(t, 2, numSignCalls)
+}
+
+func TestPeerSignatureHandler_GetPeerSignatureErrors(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	signer := &mock.SingleSignerStub{
+		SignCalled: func(_ crypto.PrivateKey, _ []byte) ([]byte, error) {
+			return nil, expectedErr
+		},
+	}
+
+	psh, kg := createPeerSignatureHandler(t, signer)
+	sk, _ := kg.GeneratePair()
+
+	sig, err := psh.GetPeerSignature(nil, []byte("pid"))
+	assert.Nil(t, sig)
+	assert.Equal(t, crypto.ErrNilPrivateKey, err)
+
+	sig, err = psh.GetPeerSignature(sk, nil)
+	assert.Nil(t, sig)
+	assert.Equal(t, crypto.ErrInvalidPID, err)
+
+	sig, err = psh.GetPeerSignature(sk, []byte("pid"))
+	assert.Nil(t, sig)
+	assert.Equal(t, expectedErr, err)
+}
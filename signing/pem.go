@@ -0,0 +1,144 @@
+package signing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+)
+
+// pemTypePrefix prefixes the hex encoded public key in the type of the PEM blocks holding private keys
+const pemTypePrefix = "PRIVATE KEY for "
+
+const pemFilePermissions = 0600
+
+/*
+EncodePrivateKeysToPEM encodes the private keys into PEM blocks, one for each key, in the format of the validator key
+files: the type of a block is "PRIVATE KEY for <hex public key>" and its content is the hex encoded private key.
+
+The private keys can be of any suite, as long as they can be loaded with the key generator of that suite
+*/
+func EncodePrivateKeysToPEM(privKeys []crypto.PrivateKey) ([]byte, error) {
+	if len(privKeys) == 0 {
+		return nil, crypto.ErrNilPrivateKeys
+	}
+
+	buff := &bytes.Buffer{}
+	for i, privKey := range privKeys {
+		if check.IfNil(privKey) {
+			return nil, fmt.Errorf("%w at index %d", crypto.ErrNilPrivateKey, i)
+		}
+
+		privKeyBytes, err := privKey.ToByteArray()
+		if err != nil {
+			return nil, fmt.Errorf("%w for private key at index %d", err, i)
+		}
+
+		pubKeyBytes, err := privKey.GeneratePublic().ToByteArray()
+		if err != nil {
+			return nil, fmt.Errorf("%w for private key at index %d", err, i)
+		}
+
+		block := &pem.Block{
+			Type:  pemTypePrefix + hex.EncodeToString(pubKeyBytes),
+			Bytes: []byte(hex.EncodeToString(privKeyBytes)),
+		}
+		err = pem.Encode(buff, block)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buff.Bytes(), nil
+}
+
+// DecodePrivateKeysFromPEM decodes the private keys from the PEM blocks, checking that the public key of each block
+// type matches the public key generated from its private key. A block that can not be loaded is reported with an
+// InvalidPEMBlockError holding its position
+func DecodePrivateKeysFromPEM(buff []byte, keyGen crypto.KeyGenerator) ([]crypto.PrivateKey, error) {
+	if check.IfNil(keyGen) {
+		return nil, crypto.ErrNilKeyGenerator
+	}
+
+	privKeys := make([]crypto.PrivateKey, 0)
+	rest := buff
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		privKey, err := decodePEMBlock(block, keyGen)
+		if err != nil {
+			return nil, &crypto.InvalidPEMBlockError{Index: len(privKeys), Err: err}
+		}
+
+		privKeys = append(privKeys, privKey)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, &crypto.InvalidPEMBlockError{Index: len(privKeys), Err: crypto.ErrInvalidPEMBlock}
+	}
+	if len(privKeys) == 0 {
+		return nil, crypto.ErrNoPEMBlock
+	}
+
+	return privKeys, nil
+}
+
+// SavePrivateKeysToPEMFile writes the private keys into the PEM file, readable only by its owner
+func SavePrivateKeysToPEMFile(path string, privKeys []crypto.PrivateKey) error {
+	buff, err := EncodePrivateKeysToPEM(privKeys)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, buff, pemFilePermissions)
+}
+
+// LoadPrivateKeysFromPEMFile reads the private keys from the PEM file
+func LoadPrivateKeysFromPEMFile(path string, keyGen crypto.KeyGenerator) ([]crypto.PrivateKey, error) {
+	buff, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodePrivateKeysFromPEM(buff, keyGen)
+}
+
+func decodePEMBlock(block *pem.Block, keyGen crypto.KeyGenerator) (crypto.PrivateKey, error) {
+	if !strings.HasPrefix(block.Type, pemTypePrefix) {
+		return nil, fmt.Errorf("%w: unexpected type %s", crypto.ErrInvalidPEMBlock, block.Type)
+	}
+
+	pubKeyBytes, err := hex.DecodeString(strings.TrimPrefix(block.Type, pemTypePrefix))
+	if err != nil {
+		return nil, fmt.Errorf("%w: public key is not hex encoded", crypto.ErrInvalidPEMBlock)
+	}
+
+	privKeyBytes, err := hex.DecodeString(string(block.Bytes))
+	if err != nil {
+		return nil, fmt.Errorf("%w: private key is not hex encoded", crypto.ErrInvalidPEMBlock)
+	}
+
+	privKey, err := keyGen.PrivateKeyFromByteArray(privKeyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	generatedPubKeyBytes, err := privKey.GeneratePublic().ToByteArray()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(pubKeyBytes, generatedPubKeyBytes) {
+		return nil, crypto.ErrPublicKeyMismatch
+	}
+
+	return privKey, nil
+}
@@ -0,0 +1,184 @@
+package signing_test
+
+import (
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/ed25519"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/stretchr/testify/require"
+)
+
+func generatePrivateKeys(keyGen crypto.KeyGenerator, numKeys int) []crypto.PrivateKey {
+	privKeys := make([]crypto.PrivateKey, numKeys)
+	for i := range privKeys {
+		privKeys[i], _ = keyGen.GeneratePair()
+	}
+
+	return privKeys
+}
+
+func requireSamePrivateKeys(t *testing.T, expected []crypto.PrivateKey, actual []crypto.PrivateKey) {
+	require.Equal(t, len(expected), len(actual))
+	for i := range expected {
+		expectedBytes, _ := expected[i].ToByteArray()
+		actualBytes, _ := actual[i].ToByteArray()
+		require.Equal(t, expectedBytes, actualBytes)
+	}
+}
+
+func TestEncodeDecodePrivateKeysPEM(t *testing.T) {
+	t.Parallel()
+
+	keyGens := map[string]crypto.KeyGenerator{
+		"BLS12-381": signing.NewKeyGenerator(mcl.NewSuiteBLS12()),
+		"Ed25519":   signing.NewKeyGenerator(ed25519.NewEd25519()),
+	}
+
+	for name, keyGen := range keyGens {
+		privKeys := generatePrivateKeys(keyGen, 3)
+
+		buff, err := signing.EncodePrivateKeysToPEM(privKeys)
+		require.Nil(t, err, name)
+
+		// the type of each block holds the hex encoded public key
+		rest := buff
+		for _, privKey := range privKeys {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			require.NotNil(t, block)
+
+			pubKeyBytes, _ := privKey.GeneratePublic().ToByteArray()
+			require.Equal(t, "PRIVATE KEY for "+hex.EncodeToString(pubKeyBytes), block.Type)
+		}
+
+		decoded, err := signing.DecodePrivateKeysFromPEM(buff, keyGen)
+		require.Nil(t, err, name)
+		requireSamePrivateKeys(t, privKeys, decoded)
+	}
+}
+
+func TestDecodePrivateKeysFromPEM_ExistingFileFormat(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKey, pubKey := keyGen.GeneratePair()
+	privKeyBytes, _ := privKey.ToByteArray()
+	pubKeyBytes, _ := pubKey.ToByteArray()
+
+	block := &pem.Block{
+		Type:  "PRIVATE KEY for " + hex.EncodeToString(pubKeyBytes),
+		Bytes: []byte(hex.EncodeToString(privKeyBytes)),
+	}
+	buff := pem.EncodeToMemory(block)
+
+	decoded, err := signing.DecodePrivateKeysFromPEM(buff, keyGen)
+	require.Nil(t, err)
+	requireSamePrivateKeys(t, []crypto.PrivateKey{privKey}, decoded)
+}
+
+func TestEncodePrivateKeysToPEM_ShouldErr(t *testing.T) {
+	t.Parallel()
+
+	_, err := signing.EncodePrivateKeysToPEM(nil)
+	require.Equal(t, crypto.ErrNilPrivateKeys, err)
+
+	_, err = signing.EncodePrivateKeysToPEM(make([]crypto.PrivateKey, 0))
+	require.Equal(t, crypto.ErrNilPrivateKeys, err)
+
+	privKeys := generatePrivateKeys(signing.NewKeyGenerator(mcl.NewSuiteBLS12()), 2)
+	_, err = signing.EncodePrivateKeysToPEM(append(privKeys, nil))
+	require.True(t, errors.Is(err, crypto.ErrNilPrivateKey))
+	require.True(t, strings.Contains(err.Error(), "index 2"))
+}
+
+func TestDecodePrivateKeysFromPEM_ShouldErr(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKeys := generatePrivateKeys(keyGen, 3)
+	buff, _ := signing.EncodePrivateKeysToPEM(privKeys)
+
+	blocks := make([]*pem.Block, 0, len(privKeys))
+	for rest := buff; ; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+
+	encodeWithBlock := func(index int, block *pem.Block) []byte {
+		encoded := make([]byte, 0)
+		for i := range blocks {
+			if i == index {
+				encoded = append(encoded, pem.EncodeToMemory(block)...)
+				continue
+			}
+			encoded = append(encoded, pem.EncodeToMemory(blocks[i])...)
+		}
+
+		return encoded
+	}
+
+	requireBlockErr := func(err error, index int, expectedErr error) {
+		blockErr := &crypto.InvalidPEMBlockError{}
+		require.True(t, errors.As(err, &blockErr))
+		require.Equal(t, index, blockErr.Index)
+		require.True(t, errors.Is(err, expectedErr))
+	}
+
+	_, err := signing.DecodePrivateKeysFromPEM(buff, nil)
+	require.Equal(t, crypto.ErrNilKeyGenerator, err)
+
+	_, err = signing.DecodePrivateKeysFromPEM(nil, keyGen)
+	require.Equal(t, crypto.ErrNoPEMBlock, err)
+
+	_, err = signing.DecodePrivateKeysFromPEM([]byte("not a pem file"), keyGen)
+	requireBlockErr(err, 0, crypto.ErrInvalidPEMBlock)
+
+	_, err = signing.DecodePrivateKeysFromPEM(append(buff, []byte("garbage")...), keyGen)
+	requireBlockErr(err, 3, crypto.ErrInvalidPEMBlock)
+
+	// the header of the second block holds the public key of the third one
+	_, err = signing.DecodePrivateKeysFromPEM(encodeWithBlock(1, &pem.Block{Type: blocks[2].Type, Bytes: blocks[1].Bytes}), keyGen)
+	requireBlockErr(err, 1, crypto.ErrPublicKeyMismatch)
+
+	_, err = signing.DecodePrivateKeysFromPEM(encodeWithBlock(2, &pem.Block{Type: "EC PRIVATE KEY", Bytes: blocks[2].Bytes}), keyGen)
+	requireBlockErr(err, 2, crypto.ErrInvalidPEMBlock)
+
+	_, err = signing.DecodePrivateKeysFromPEM(encodeWithBlock(0, &pem.Block{Type: "PRIVATE KEY for zz", Bytes: blocks[0].Bytes}), keyGen)
+	requireBlockErr(err, 0, crypto.ErrInvalidPEMBlock)
+
+	_, err = signing.DecodePrivateKeysFromPEM(encodeWithBlock(1, &pem.Block{Type: blocks[1].Type, Bytes: []byte("zz")}), keyGen)
+	requireBlockErr(err, 1, crypto.ErrInvalidPEMBlock)
+
+	// the keys are loaded with the key generator of another suite
+	_, err = signing.DecodePrivateKeysFromPEM(buff, signing.NewKeyGenerator(ed25519.NewEd25519()))
+	requireBlockErr(err, 0, crypto.ErrPublicKeyMismatch)
+}
+
+func TestSaveLoadPrivateKeysPEMFile(t *testing.T) {
+	t.Parallel()
+
+	keyGen := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	privKeys := generatePrivateKeys(keyGen, 2)
+	path := filepath.Join(t.TempDir(), "validatorKey.pem")
+
+	err := signing.SavePrivateKeysToPEMFile(path, privKeys)
+	require.Nil(t, err)
+
+	loaded, err := signing.LoadPrivateKeysFromPEMFile(path, keyGen)
+	require.Nil(t, err)
+	requireSamePrivateKeys(t, privKeys, loaded)
+
+	_, err = signing.LoadPrivateKeysFromPEMFile(filepath.Join(t.TempDir(), "missing.pem"), keyGen)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,44 @@
+package signing
+
+import (
+	"fmt"
+
+	"github.com/ME-MotherEarth/me-core/core/check"
+	"github.com/ME-MotherEarth/me-crypto"
+)
+
+// VerifyProofsOfPossession checks that every public key, given as byte array, is accompanied by a valid proof of
+// possession of its private key. The first failing pair is reported together with its index
+func VerifyProofsOfPossession(
+	keyGen crypto.KeyGenerator,
+	popSigner crypto.ProofOfPossessionSigner,
+	pubKeys [][]byte,
+	proofs [][]byte,
+) error {
+	if check.IfNil(keyGen) {
+		return crypto.ErrNilKeyGenerator
+	}
+	if check.IfNil(popSigner) {
+		return crypto.ErrNilProofOfPossessionSigner
+	}
+	if len(pubKeys) == 0 {
+		return crypto.ErrNilPublicKeys
+	}
+	if len(pubKeys) != len(proofs) {
+		return crypto.ErrProofsPubKeysLenMismatch
+	}
+
+	for i := range pubKeys {
+		pubKey, err := keyGen.PublicKeyFromByteArray(pubKeys[i])
+		if err != nil {
+			return fmt.Errorf("%w for public key at index %d", err, i)
+		}
+
+		err = popSigner.VerifyProofOfPossession(pubKey, proofs[i])
+		if err != nil {
+			return fmt.Errorf("%w for public key at index %d", err, i)
+		}
+	}
+
+	return nil
+}
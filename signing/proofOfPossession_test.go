@@ -0,0 +1,78 @@
+package signing_test
+
+import (
+	"errors"
+	"testing"
+
+	crypto "github.com/ME-MotherEarth/me-crypto"
+	"github.com/ME-MotherEarth/me-crypto/signing"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl"
+	"github.com/ME-MotherEarth/me-crypto/signing/mcl/singlesig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createPubKeysAndProofs(t *testing.T, kg crypto.KeyGenerator, numKeys int) ([][]byte, [][]byte) {
+	signer := singlesig.NewBlsSigner()
+	pubKeys := make([][]byte, 0, numKeys)
+	proofs := make([][]byte, 0, numKeys)
+	for i := 0; i < numKeys; i++ {
+		sk, pk := kg.GeneratePair()
+		pkBytes, err := pk.ToByteArray()
+		require.Nil(t, err)
+		pop, err := signer.CreateProofOfPossession(sk)
+		require.Nil(t, err)
+
+		pubKeys = append(pubKeys, pkBytes)
+		proofs = append(proofs, pop)
+	}
+
+	return pubKeys, proofs
+}
+
+func TestVerifyProofsOfPossession_InvalidParamsShouldErr(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	signer := singlesig.NewBlsSigner()
+	pubKeys, proofs := createPubKeysAndProofs(t, kg, 2)
+
+	assert.Equal(t, crypto.ErrNilKeyGenerator, signing.VerifyProofsOfPossession(nil, signer, pubKeys, proofs))
+	assert.Equal(t, crypto.ErrNilProofOfPossessionSigner, signing.VerifyProofsOfPossession(kg, nil, pubKeys, proofs))
+	assert.Equal(t, crypto.ErrNilPublicKeys, signing.VerifyProofsOfPossession(kg, signer, nil, proofs))
+	assert.Equal(t, crypto.ErrProofsPubKeysLenMismatch, signing.VerifyProofsOfPossession(kg, signer, pubKeys, proofs[:1]))
+}
+
+func TestVerifyProofsOfPossession_ShouldWork(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	pubKeys, proofs := createPubKeysAndProofs(t, kg, 5)
+
+	err := signing.VerifyProofsOfPossession(kg, singlesig.NewBlsSigner(), pubKeys, proofs)
+	assert.Nil(t, err)
+}
+
+func TestVerifyProofsOfPossession_InvalidProofShouldReportIndex(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	pubKeys, proofs := createPubKeysAndProofs(t, kg, 5)
+	proofs[3], proofs[4] = proofs[4], proofs[3]
+
+	err := signing.VerifyProofsOfPossession(kg, singlesig.NewBlsSigner(), pubKeys, proofs)
+	assert.True(t, errors.Is(err, crypto.ErrPoPNotValid))
+	assert.Contains(t, err.Error(), "index 3")
+}
+
+func TestVerifyProofsOfPossession_InvalidPublicKeyShouldReportIndex(t *testing.T) {
+	t.Parallel()
+
+	kg := signing.NewKeyGenerator(mcl.NewSuiteBLS12())
+	pubKeys, proofs := createPubKeysAndProofs(t, kg, 3)
+	pubKeys[1] = []byte("invalid public key")
+
+	err := signing.VerifyProofsOfPossession(kg, singlesig.NewBlsSigner(), pubKeys, proofs)
+	assert.True(t, errors.Is(err, crypto.ErrInvalidParam))
+	assert.Contains(t, err.Error(), "index 1")
+}